@@ -32,7 +32,9 @@ type buildOpts struct {
 	snapshot      bool
 	skipValidate  bool
 	skipPostHooks bool
+	skipTests     bool
 	rmDist        bool
+	incremental   bool
 	deprecated    bool
 	parallelism   int
 	timeout       time.Duration
@@ -81,7 +83,9 @@ When using ` + "`--single-target`" + `, the ` + "`GOOS`" + ` and ` + "`GOARCH`"
 	cmd.Flags().BoolVar(&root.opts.snapshot, "snapshot", false, "Generate an unversioned snapshot build, skipping all validations")
 	cmd.Flags().BoolVar(&root.opts.skipValidate, "skip-validate", false, "Skips several sanity checks")
 	cmd.Flags().BoolVar(&root.opts.skipPostHooks, "skip-post-hooks", false, "Skips all post-build hooks")
+	cmd.Flags().BoolVar(&root.opts.skipTests, "skip-tests", false, "Skips running the tests")
 	cmd.Flags().BoolVar(&root.opts.rmDist, "rm-dist", false, "Remove the dist folder before building")
+	cmd.Flags().BoolVar(&root.opts.incremental, "incremental", false, "Reuses binaries from the previous snapshot build if their sources and settings didn't change")
 	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Amount tasks to run concurrently (default: number of CPUs)")
 	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 30*time.Minute, "Timeout to the entire build process")
 	cmd.Flags().BoolVar(&root.opts.singleTarget, "single-target", false, "Builds only for current GOOS and GOARCH")
@@ -122,25 +126,30 @@ func buildProject(options buildOpts) (*context.Context, error) {
 }
 
 func setupPipeline(ctx *context.Context, options buildOpts) []pipeline.Piper {
-	if options.output != "" && options.singleTarget && (options.id != "" || len(ctx.Config.Builds) == 1) {
+	singleTarget := options.singleTarget || ctx.Config.SingleTarget
+	if options.output != "" && singleTarget && (options.id != "" || len(ctx.Config.Builds) == 1) {
 		return append(pipeline.BuildCmdPipeline, withOutputPipe{options.output})
 	}
 	return pipeline.BuildCmdPipeline
 }
 
 func setupBuildContext(ctx *context.Context, options buildOpts) error {
-	ctx.Parallelism = runtime.NumCPU()
-	if options.parallelism > 0 {
-		ctx.Parallelism = options.parallelism
-	}
-	log.Debugf("parallelism: %v", ctx.Parallelism)
+	setupParallelism(ctx, options.parallelism)
 	ctx.Snapshot = options.snapshot
 	ctx.SkipValidate = ctx.Snapshot || options.skipValidate
 	ctx.SkipPostBuildHooks = options.skipPostHooks
+	ctx.SkipTests = options.skipTests
 	ctx.RmDist = options.rmDist
+	if options.incremental {
+		if ctx.Snapshot {
+			ctx.Incremental = true
+		} else {
+			log.Warn("--incremental only works with --snapshot, ignoring")
+		}
+	}
 	ctx.SkipTokenCheck = true
 
-	if options.singleTarget {
+	if options.singleTarget || ctx.Config.SingleTarget {
 		setupBuildSingleTarget(ctx)
 	}
 
@@ -155,6 +164,19 @@ func setupBuildContext(ctx *context.Context, options buildOpts) error {
 	return nil
 }
 
+// setupParallelism sets how many tasks the pipes run concurrently: the flag
+// wins over the config, which wins over the number of CPUs.
+func setupParallelism(ctx *context.Context, flag int) {
+	ctx.Parallelism = runtime.NumCPU()
+	if ctx.Config.Parallelism > 0 {
+		ctx.Parallelism = ctx.Config.Parallelism
+	}
+	if flag > 0 {
+		ctx.Parallelism = flag
+	}
+	log.Debugf("parallelism: %v", ctx.Parallelism)
+}
+
 func setupBuildSingleTarget(ctx *context.Context) {
 	goos := os.Getenv("GOOS")
 	if goos == "" {
@@ -172,6 +194,8 @@ func setupBuildSingleTarget(ctx *context.Context) {
 		build := &ctx.Config.Builds[i]
 		build.Goos = []string{goos}
 		build.Goarch = []string{goarch}
+		// targets take precedence over goos and goarch, so they need to go.
+		build.Targets = nil
 	}
 }
 
@@ -146,13 +146,25 @@ func TestBuildFlags(t *testing.T) {
 		require.True(t, ctx.SkipTokenCheck)
 	})
 
+	t.Run("incremental", func(t *testing.T) {
+		require.True(t, setup(buildOpts{
+			snapshot:    true,
+			incremental: true,
+		}).Incremental)
+		require.False(t, setup(buildOpts{
+			incremental: true,
+		}).Incremental)
+	})
+
 	t.Run("skips", func(t *testing.T) {
 		ctx := setup(buildOpts{
 			skipValidate:  true,
 			skipPostHooks: true,
+			skipTests:     true,
 		})
 		require.True(t, ctx.SkipValidate)
 		require.True(t, ctx.SkipPostBuildHooks)
+		require.True(t, ctx.SkipTests)
 		require.True(t, ctx.SkipTokenCheck)
 	})
 
@@ -162,6 +174,16 @@ func TestBuildFlags(t *testing.T) {
 		}).Parallelism)
 	})
 
+	t.Run("parallelism from config", func(t *testing.T) {
+		ctx := context.New(config.Project{Parallelism: 3})
+		require.NoError(t, setupBuildContext(ctx, buildOpts{}))
+		require.Equal(t, 3, ctx.Parallelism)
+
+		ctx = context.New(config.Project{Parallelism: 3})
+		require.NoError(t, setupBuildContext(ctx, buildOpts{parallelism: 2}))
+		require.Equal(t, 2, ctx.Parallelism)
+	})
+
 	t.Run("rm dist", func(t *testing.T) {
 		require.True(t, setup(buildOpts{
 			rmDist: true,
@@ -179,6 +201,19 @@ func TestBuildFlags(t *testing.T) {
 			require.Equal(t, []string{runtime.GOARCH}, result.Config.Builds[0].Goarch)
 		})
 
+		t.Run("from config", func(t *testing.T) {
+			ctx := context.New(config.Project{
+				SingleTarget: true,
+				Builds: []config.Build{{
+					Targets: []string{"linux_amd64", "darwin_arm64"},
+				}},
+			})
+			require.NoError(t, setupBuildContext(ctx, buildOpts{}))
+			require.Equal(t, []string{runtime.GOOS}, ctx.Config.Builds[0].Goos)
+			require.Equal(t, []string{runtime.GOARCH}, ctx.Config.Builds[0].Goarch)
+			require.Empty(t, ctx.Config.Builds[0].Targets)
+		})
+
 		t.Run("from env", func(t *testing.T) {
 			os.Setenv("GOOS", "linux")
 			os.Setenv("GOARCH", "arm64")
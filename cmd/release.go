@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"runtime"
 	"time"
 
 	"github.com/apex/log"
@@ -31,12 +30,15 @@ type releaseOpts struct {
 	releaseFooterTmpl  string
 	autoSnapshot       bool
 	snapshot           bool
+	singleTarget       bool
 	skipPublish        bool
 	skipSign           bool
 	skipValidate       bool
+	skipTests          bool
 	skipAnnounce       bool
 	skipSBOMCataloging bool
 	rmDist             bool
+	incremental        bool
 	deprecated         bool
 	parallelism        int
 	timeout            time.Duration
@@ -85,7 +87,10 @@ func newReleaseCmd() *releaseCmd {
 	cmd.Flags().BoolVar(&root.opts.skipSign, "skip-sign", false, "Skips signing artifacts")
 	cmd.Flags().BoolVar(&root.opts.skipSBOMCataloging, "skip-sbom", false, "Skips cataloging artifacts")
 	cmd.Flags().BoolVar(&root.opts.skipValidate, "skip-validate", false, "Skips git checks")
+	cmd.Flags().BoolVar(&root.opts.skipTests, "skip-tests", false, "Skips running the tests")
 	cmd.Flags().BoolVar(&root.opts.rmDist, "rm-dist", false, "Removes the dist folder")
+	cmd.Flags().BoolVar(&root.opts.singleTarget, "single-target", false, "Builds only for current GOOS and GOARCH, only taken into account with --snapshot")
+	cmd.Flags().BoolVar(&root.opts.incremental, "incremental", false, "Reuses binaries from the previous snapshot build if their sources and settings didn't change")
 	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Amount tasks to run concurrently (default: number of CPUs)")
 	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 30*time.Minute, "Timeout to the entire release process")
 	cmd.Flags().BoolVar(&root.opts.deprecated, "deprecated", false, "Force print the deprecation message - tests only")
@@ -121,11 +126,7 @@ func releaseProject(options releaseOpts) (*context.Context, error) {
 }
 
 func setupReleaseContext(ctx *context.Context, options releaseOpts) *context.Context {
-	ctx.Parallelism = runtime.NumCPU()
-	if options.parallelism > 0 {
-		ctx.Parallelism = options.parallelism
-	}
-	log.Debugf("parallelism: %v", ctx.Parallelism)
+	setupParallelism(ctx, options.parallelism)
 	ctx.ReleaseNotesFile = options.releaseNotesFile
 	ctx.ReleaseNotesTmpl = options.releaseNotesTmpl
 	ctx.ReleaseHeaderFile = options.releaseHeaderFile
@@ -141,8 +142,23 @@ func setupReleaseContext(ctx *context.Context, options releaseOpts) *context.Con
 	ctx.SkipAnnounce = ctx.Snapshot || options.skipPublish || options.skipAnnounce
 	ctx.SkipValidate = ctx.Snapshot || options.skipValidate
 	ctx.SkipSign = options.skipSign
+	ctx.SkipTests = options.skipTests
 	ctx.SkipSBOMCataloging = options.skipSBOMCataloging
 	ctx.RmDist = options.rmDist
+	if options.incremental {
+		if ctx.Snapshot {
+			ctx.Incremental = true
+		} else {
+			log.Warn("--incremental only works with --snapshot, ignoring")
+		}
+	}
+	if options.singleTarget || ctx.Config.SingleTarget {
+		if ctx.Snapshot {
+			setupBuildSingleTarget(ctx)
+		} else {
+			log.Warn("single target builds only work with --snapshot, ignoring")
+		}
+	}
 
 	// test only
 	ctx.Deprecated = options.deprecated
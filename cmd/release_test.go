@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/pkg/config"
@@ -68,13 +69,38 @@ func TestReleaseFlags(t *testing.T) {
 		require.True(t, ctx.SkipAnnounce)
 	})
 
+	t.Run("single-target", func(t *testing.T) {
+		ctx := setup(releaseOpts{
+			snapshot:     true,
+			singleTarget: true,
+		})
+		require.Equal(t, []string{runtime.GOOS}, ctx.Config.Builds[0].Goos)
+		require.Equal(t, []string{runtime.GOARCH}, ctx.Config.Builds[0].Goarch)
+
+		require.Empty(t, setup(releaseOpts{
+			singleTarget: true,
+		}).Config.Builds)
+	})
+
+	t.Run("incremental", func(t *testing.T) {
+		require.True(t, setup(releaseOpts{
+			snapshot:    true,
+			incremental: true,
+		}).Incremental)
+		require.False(t, setup(releaseOpts{
+			incremental: true,
+		}).Incremental)
+	})
+
 	t.Run("skips", func(t *testing.T) {
 		ctx := setup(releaseOpts{
 			skipPublish:  true,
 			skipSign:     true,
 			skipValidate: true,
+			skipTests:    true,
 		})
 		require.True(t, ctx.SkipSign)
+		require.True(t, ctx.SkipTests)
 		require.True(t, ctx.SkipPublish)
 		require.True(t, ctx.SkipValidate)
 		require.True(t, ctx.SkipAnnounce)
@@ -86,6 +112,14 @@ func TestReleaseFlags(t *testing.T) {
 		}).Parallelism)
 	})
 
+	t.Run("parallelism from config", func(t *testing.T) {
+		ctx := setupReleaseContext(context.New(config.Project{Parallelism: 3}), releaseOpts{})
+		require.Equal(t, 3, ctx.Parallelism)
+
+		ctx = setupReleaseContext(context.New(config.Project{Parallelism: 3}), releaseOpts{parallelism: 2})
+		require.Equal(t, 2, ctx.Parallelism)
+	})
+
 	t.Run("notes", func(t *testing.T) {
 		notes := "foo.md"
 		header := "header.md"
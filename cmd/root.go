@@ -82,6 +82,7 @@ You can also customize your entire release process through a single .goreleaser.
 		newDocsCmd().cmd,
 		newManCmd().cmd,
 		newSchemaCmd().cmd,
+		newVerifyCmd().cmd,
 	)
 
 	root.cmd = cmd
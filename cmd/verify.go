@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/caarlos0/ctrlc"
+	"github.com/fatih/color"
+	"github.com/goreleaser/goreleaser/internal/client"
+	"github.com/goreleaser/goreleaser/internal/pipe/defaults"
+	"github.com/goreleaser/goreleaser/internal/verify"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+type verifyCmd struct {
+	cmd  *cobra.Command
+	opts verifyOpts
+}
+
+type verifyOpts struct {
+	config      string
+	urlTemplate string
+	cmd         string
+	args        []string
+	timeout     time.Duration
+}
+
+func newVerifyCmd() *verifyCmd {
+	root := &verifyCmd{}
+	cmd := &cobra.Command{
+		Use:   "verify tag",
+		Short: "Verifies the checksums and signatures of a release",
+		Long: `Downloads the artifacts of the release with the given tag, and checks them against its checksums file.
+
+If the configuration has signs, the signatures of the matching files are downloaded as well and verified with the given command.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Infof(color.New(color.Bold).Sprint("verifying..."))
+			report, err := verifyRelease(args[0], root.opts)
+			if err != nil {
+				return wrapError(err, color.New(color.Bold).Sprint("verification failed"))
+			}
+			printReport(report)
+			if report.Failed() {
+				return wrapError(fmt.Errorf("some checks failed"), color.New(color.Bold).Sprint("verification failed"))
+			}
+			log.Infof(color.New(color.Bold).Sprint("verification succeeded"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&root.opts.config, "config", "f", "", "Load configuration from file")
+	cmd.Flags().StringVar(&root.opts.urlTemplate, "url-template", "", "Template of the download URLs, defaults to the one of the configured release")
+	cmd.Flags().StringVar(&root.opts.cmd, "cmd", "gpg", "Command used to verify the signatures")
+	cmd.Flags().StringSliceVar(&root.opts.args, "args", []string{"--verify", "${signature}", "${artifact}"}, "Arguments of the command used to verify the signatures")
+	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 30*time.Minute, "Timeout to the entire verification process")
+
+	root.cmd = cmd
+	return root
+}
+
+func verifyRelease(tag string, options verifyOpts) (verify.Report, error) {
+	var report verify.Report
+	cfg, err := loadConfig(options.config)
+	if err != nil {
+		return report, err
+	}
+	ctx, cancel := context.NewWithTimeout(cfg, options.timeout)
+	defer cancel()
+	ctx.Git.CurrentTag = tag
+	ctx.Version = strings.TrimPrefix(tag, "v")
+
+	dir, err := os.MkdirTemp("", "goreleaser-verify")
+	if err != nil {
+		return report, err
+	}
+	defer os.RemoveAll(dir)
+
+	err = ctrlc.Default.Run(ctx, func() error {
+		if err := (defaults.Pipe{}).Run(ctx); err != nil {
+			return err
+		}
+		urlTemplate := options.urlTemplate
+		if urlTemplate == "" {
+			urlTemplate, err = releaseURLTemplate(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		report, err = verify.Run(ctx, verify.Options{
+			URLTemplate: urlTemplate,
+			Cmd:         options.cmd,
+			Args:        options.args,
+			Dir:         dir,
+		})
+		return err
+	})
+	return report, err
+}
+
+// releaseURLTemplate gets the download URL template of the configured
+// release. No token is needed to build it, so the client type is guessed
+// from the release configuration.
+func releaseURLTemplate(ctx *context.Context) (string, error) {
+	switch {
+	case ctx.Config.Release.GitLab.Name != "":
+		ctx.TokenType = context.TokenTypeGitLab
+	case ctx.Config.Release.Gitea.Name != "":
+		ctx.TokenType = context.TokenTypeGitea
+	default:
+		ctx.TokenType = context.TokenTypeGitHub
+	}
+	cli, err := client.New(ctx)
+	if err != nil {
+		return "", err
+	}
+	return cli.ReleaseURLTemplate(ctx)
+}
+
+func printReport(report verify.Report) {
+	for _, check := range report.Checks {
+		entry := log.WithField("file", check.Name)
+		if check.Signature != "" {
+			entry = entry.WithField("signature", check.Signature)
+		}
+		switch check.Status {
+		case verify.StatusOK:
+			entry.Infof("%s ok", check.Kind)
+		case verify.StatusMissing:
+			entry.Warnf("%s missing", check.Kind)
+		default:
+			entry.WithError(check.Err).Errorf("%s failed", check.Kind)
+		}
+	}
+}
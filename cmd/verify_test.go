@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyConfigThatDoesNotExist(t *testing.T) {
+	cmd := newVerifyCmd()
+	cmd.cmd.SetArgs([]string{"v1.2.3", "-f", "testdata/nope.yml"})
+	require.EqualError(t, cmd.cmd.Execute(), "open testdata/nope.yml: no such file or directory")
+}
+
+func TestVerifyRequiresTag(t *testing.T) {
+	cmd := newVerifyCmd()
+	cmd.cmd.SetArgs([]string{})
+	require.EqualError(t, cmd.cmd.Execute(), "accepts 1 arg(s), received 0")
+}
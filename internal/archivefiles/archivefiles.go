@@ -3,8 +3,10 @@ package archivefiles
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/apex/log"
 	"github.com/goreleaser/fileglob"
@@ -13,23 +15,69 @@ import (
 )
 
 // Eval evaluates the given list of files to their final form.
-func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
+//
+// If rlcp is true, the destination of each file is its path relative to the
+// longest common prefix of all the files matched by the same glob, which
+// avoids paths like `../shared/LICENSE` inside archives.
+func Eval(template *tmpl.Template, rlcp bool, files []config.File) ([]config.File, error) {
 	var result []config.File
+	excluded, err := exclusions(template, files)
+	if err != nil {
+		return result, err
+	}
 	for _, f := range files {
+		if strings.HasPrefix(f.Source, "!") {
+			continue
+		}
 		replaced, err := template.Apply(f.Source)
 		if err != nil {
 			return result, fmt.Errorf("failed to apply template %s: %w", f.Source, err)
 		}
 
-		files, err := fileglob.Glob(replaced)
+		matches, err := fileglob.Glob(replaced, fileglob.MaybeRootFS)
 		if err != nil {
 			return result, fmt.Errorf("globbing failed for pattern %s: %w", f.Source, err)
 		}
+		var files []string
+		for _, file := range matches {
+			if excluded[file] {
+				log.Debugf("excluding file '%s'", file)
+				continue
+			}
+			files = append(files, file)
+		}
+
+		dst, err := template.Apply(f.Destination)
+		if err != nil {
+			return result, fmt.Errorf("failed to apply template %s: %w", f.Destination, err)
+		}
+		f.Destination = dst
+
+		if f.NameTemplate != "" {
+			if len(files) != 1 {
+				return result, fmt.Errorf("name_template can only be used when the pattern matches a single file, but %s matched %d", f.Source, len(files))
+			}
+			name, err := template.Apply(f.NameTemplate)
+			if err != nil {
+				return result, fmt.Errorf("failed to apply template %s: %w", f.NameTemplate, err)
+			}
+			result = append(result, config.File{
+				Source:      files[0],
+				Destination: filepath.Join(f.Destination, name),
+				Info:        f.Info,
+			})
+			continue
+		}
 
+		prefix := longestCommonPrefix(files)
 		for _, file := range files {
+			dst, err := destinationFor(f, prefix, file, rlcp)
+			if err != nil {
+				return result, err
+			}
 			result = append(result, config.File{
 				Source:      file,
-				Destination: destinationFor(f, file),
+				Destination: dst,
 				Info:        f.Info,
 			})
 		}
@@ -42,6 +90,35 @@ func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
 	return unique(result), nil
 }
 
+// exclusions returns the files matched by the negated globs (prefixed with
+// `!`) in the given list.
+func exclusions(template *tmpl.Template, files []config.File) (map[string]bool, error) {
+	result := map[string]bool{}
+	for _, f := range files {
+		if !strings.HasPrefix(f.Source, "!") {
+			continue
+		}
+		replaced, err := template.Apply(strings.TrimPrefix(f.Source, "!"))
+		if err != nil {
+			return result, fmt.Errorf("failed to apply template %s: %w", f.Source, err)
+		}
+		if !fileglob.ContainsMatchers(replaced) {
+			// excluding a file that doesn't exist is not an error
+			if _, err := os.Lstat(replaced); err != nil {
+				continue
+			}
+		}
+		matches, err := fileglob.Glob(replaced, fileglob.MaybeRootFS)
+		if err != nil {
+			return result, fmt.Errorf("globbing failed for pattern %s: %w", f.Source, err)
+		}
+		for _, match := range matches {
+			result[match] = true
+		}
+	}
+	return result, nil
+}
+
 // remove duplicates
 func unique(in []config.File) []config.File {
 	var result []config.File
@@ -63,12 +140,39 @@ func unique(in []config.File) []config.File {
 	return result
 }
 
-func destinationFor(f config.File, path string) string {
-	if f.Destination == "" {
-		return path
+func destinationFor(f config.File, prefix, path string, rlcp bool) (string, error) {
+	if f.Destination == "" && !rlcp {
+		if filepath.IsAbs(path) {
+			log.Warnf("file '%s' is outside the current directory, consider enabling rlcp", path)
+		}
+		return path, nil
 	}
 	if f.StripParent {
-		return filepath.Join(f.Destination, filepath.Base(path))
+		return filepath.Join(f.Destination, filepath.Base(path)), nil
+	}
+	if rlcp {
+		relpath, err := filepath.Rel(prefix, path)
+		if err != nil {
+			// since prefix is a prefix of path, this should never happen
+			return "", fmt.Errorf("failed to make %s relative to %s: %w", path, prefix, err)
+		}
+		return filepath.Join(f.Destination, relpath), nil
+	}
+	return filepath.Join(f.Destination, path), nil
+}
+
+// longestCommonPrefix returns the deepest directory that contains all the
+// given paths.
+func longestCommonPrefix(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	prefix := filepath.Dir(paths[0])
+	for _, path := range paths[1:] {
+		for prefix != "." && prefix != string(filepath.Separator) &&
+			!strings.HasPrefix(path, prefix+string(filepath.Separator)) {
+			prefix = filepath.Dir(prefix)
+		}
 	}
-	return filepath.Join(f.Destination, path)
+	return prefix
 }
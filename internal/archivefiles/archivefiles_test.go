@@ -1,6 +1,8 @@
 package archivefiles
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,10 +14,10 @@ import (
 
 func TestEval(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
-	tmpl := tmpl.New(context.New(config.Project{}))
+	tmpl := tmpl.New(context.New(config.Project{ProjectName: "foo"}))
 
 	t.Run("single file", func(t *testing.T) {
-		result, err := Eval(tmpl, []config.File{
+		result, err := Eval(tmpl, false, []config.File{
 			{
 				Source:      "./testdata/**/d.txt",
 				Destination: "var/foobar/d.txt",
@@ -32,7 +34,7 @@ func TestEval(t *testing.T) {
 	})
 
 	t.Run("match multiple files within tree without destination", func(t *testing.T) {
-		result, err := Eval(tmpl, []config.File{{Source: "./testdata/a"}})
+		result, err := Eval(tmpl, false, []config.File{{Source: "./testdata/a"}})
 
 		require.NoError(t, err)
 		require.Equal(t, []config.File{
@@ -43,7 +45,7 @@ func TestEval(t *testing.T) {
 	})
 
 	t.Run("match multiple files within tree specific destination", func(t *testing.T) {
-		result, err := Eval(tmpl, []config.File{
+		result, err := Eval(tmpl, false, []config.File{
 			{
 				Source:      "./testdata/a",
 				Destination: "usr/local/test",
@@ -92,7 +94,7 @@ func TestEval(t *testing.T) {
 	})
 
 	t.Run("match multiple files within tree specific destination stripping parents", func(t *testing.T) {
-		result, err := Eval(tmpl, []config.File{
+		result, err := Eval(tmpl, false, []config.File{
 			{
 				Source:      "./testdata/a",
 				Destination: "usr/local/test",
@@ -130,4 +132,168 @@ func TestEval(t *testing.T) {
 			},
 		}, result)
 	})
+	t.Run("stripping parents without destination", func(t *testing.T) {
+		result, err := Eval(tmpl, false, []config.File{
+			{Source: "./testdata/a/b", StripParent: true},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/a.txt", Destination: "testdata/a/b/a.txt"},
+			{Source: "testdata/a/b/c/d.txt", Destination: "testdata/a/b/c/d.txt"},
+		}, result)
+	})
+
+	t.Run("templated destination", func(t *testing.T) {
+		result, err := Eval(tmpl, false, []config.File{
+			{Source: "./testdata/a/a.txt", Destination: "share/{{ .ProjectName }}"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/a.txt", Destination: "share/foo/testdata/a/a.txt"},
+		}, result)
+	})
+
+	t.Run("invalid destination template", func(t *testing.T) {
+		_, err := Eval(tmpl, false, []config.File{
+			{Source: "./testdata/a/a.txt", Destination: "{{ .Nope }"},
+		})
+		require.EqualError(t, err, `failed to apply template {{ .Nope }: template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("name template", func(t *testing.T) {
+		result, err := Eval(tmpl, false, []config.File{
+			{
+				Source:       "./testdata/a/b/c/d.txt",
+				Destination:  "completions",
+				NameTemplate: "{{ .ProjectName }}",
+			},
+			{
+				Source:       "./testdata/a/b/*.txt",
+				NameTemplate: "{{ .ProjectName }}.txt",
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/c/d.txt", Destination: "completions/foo"},
+			{Source: "testdata/a/b/a.txt", Destination: "foo.txt"},
+		}, result)
+	})
+
+	t.Run("name template matching multiple files", func(t *testing.T) {
+		_, err := Eval(tmpl, false, []config.File{
+			{
+				Source:       "./testdata/a",
+				NameTemplate: "foo",
+			},
+		})
+		require.EqualError(t, err, "name_template can only be used when the pattern matches a single file, but ./testdata/a matched 3")
+	})
+
+	t.Run("invalid name template", func(t *testing.T) {
+		_, err := Eval(tmpl, false, []config.File{
+			{
+				Source:       "./testdata/a/a.txt",
+				NameTemplate: "{{ .Nope }",
+			},
+		})
+		require.EqualError(t, err, `failed to apply template {{ .Nope }: template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("exclusions", func(t *testing.T) {
+		result, err := Eval(tmpl, false, []config.File{
+			{Source: "./testdata/a"},
+			{Source: "!testdata/a/b/c/**"},
+			{Source: "!nope.txt"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/a.txt", Destination: "testdata/a/a.txt"},
+			{Source: "testdata/a/b/a.txt", Destination: "testdata/a/b/a.txt"},
+		}, result)
+	})
+
+	t.Run("exclusions with globs and templates", func(t *testing.T) {
+		result, err := Eval(tmpl, false, []config.File{
+			{Source: "!**/{{ .ProjectName }}.txt"},
+			{Source: "!**/a.txt"},
+			{Source: "./testdata/a", Destination: "usr"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/c/d.txt", Destination: "usr/testdata/a/b/c/d.txt"},
+		}, result)
+	})
+
+	t.Run("exclusion invalid template", func(t *testing.T) {
+		_, err := Eval(tmpl, false, []config.File{
+			{Source: "!{{ .Nope }"},
+		})
+		require.EqualError(t, err, `failed to apply template !{{ .Nope }: template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("rlcp", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		result, err := Eval(tmpl, true, []config.File{
+			{Source: "./testdata/a"},
+			{Source: "../archivefiles/testdata/a/b/c/d.txt", Destination: "shared"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/a.txt", Destination: "a.txt"},
+			{Source: "testdata/a/b/a.txt", Destination: "b/a.txt"},
+			{Source: "testdata/a/b/c/d.txt", Destination: "b/c/d.txt"},
+			{Source: filepath.Join(wd, "testdata/a/b/c/d.txt"), Destination: "shared/d.txt"},
+		}, result)
+	})
+
+	t.Run("outside the current directory without rlcp", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		result, err := Eval(tmpl, false, []config.File{
+			{Source: "../archivefiles/testdata/a/b/c/d.txt"},
+		})
+
+		require.NoError(t, err)
+		path := filepath.Join(wd, "testdata/a/b/c/d.txt")
+		require.Equal(t, []config.File{
+			{Source: path, Destination: path},
+		}, result)
+	})
+
+	t.Run("rlcp with strip parent", func(t *testing.T) {
+		result, err := Eval(tmpl, true, []config.File{
+			{Source: "./testdata/a/b", Destination: "usr", StripParent: true},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/a.txt", Destination: "usr/a.txt"},
+			{Source: "testdata/a/b/c/d.txt", Destination: "usr/d.txt"},
+		}, result)
+	})
+}
+
+func TestLongestCommonPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		paths    []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"a/b.txt"}, "a"},
+		{[]string{"a/b/c.txt", "a/b/d/e.txt"}, "a/b"},
+		{[]string{"a/b/c.txt", "a/d.txt"}, "a"},
+		{[]string{"a.txt", "b/c.txt"}, "."},
+		{[]string{"ab/c.txt", "a/d.txt"}, "."},
+		{[]string{"../shared/LICENSE", "../shared/NOTICE"}, "../shared"},
+		{[]string{"/usr/share/doc/a", "/usr/share/doc/b/c"}, "/usr/share/doc"},
+	} {
+		require.Equal(t, tt.expected, longestCommonPrefix(tt.paths), "%v", tt.paths)
+	}
 }
@@ -14,9 +14,11 @@ import (
 	"hash/crc32"
 	"io"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/apex/log"
+	"golang.org/x/crypto/blake2b"
 )
 
 // Type defines the type of an artifact.
@@ -67,6 +69,14 @@ const (
 	ScoopManifest
 	// SBOM is a Software Bill of Materials file.
 	SBOM
+	// DebugSymbols is a file with the debug symbols stripped from a binary.
+	DebugSymbols
+	// UploadableDockerImage is a Docker image exported with docker save.
+	UploadableDockerImage
+	// Provenance is a SLSA provenance statement of the released artifacts.
+	Provenance
+	// Attestation is an in-toto attestation of the released artifacts.
+	Attestation
 )
 
 func (t Type) String() string {
@@ -107,6 +117,14 @@ func (t Type) String() string {
 		return "PKGBUILD"
 	case SrcInfo:
 		return "SRCINFO"
+	case DebugSymbols:
+		return "Debug Symbols"
+	case UploadableDockerImage:
+		return "Docker Image Archive"
+	case Provenance:
+		return "Provenance"
+	case Attestation:
+		return "Attestation"
 	default:
 		return "unknown"
 	}
@@ -117,15 +135,20 @@ func (t Type) MarshalJSON() ([]byte, error) {
 }
 
 const (
-	ExtraID        = "ID"
-	ExtraBinary    = "Binary"
-	ExtraExt       = "Ext"
-	ExtraBuilds    = "Builds"
-	ExtraFormat    = "Format"
-	ExtraWrappedIn = "WrappedIn"
-	ExtraBinaries  = "Binaries"
-	ExtraRefresh   = "Refresh"
-	ExtraReplaces  = "Replaces"
+	ExtraID            = "ID"
+	ExtraBinary        = "Binary"
+	ExtraExt           = "Ext"
+	ExtraBuilds        = "Builds"
+	ExtraFormat        = "Format"
+	ExtraWrappedIn     = "WrappedIn"
+	ExtraBinaries      = "Binaries"
+	ExtraRefresh       = "Refresh"
+	ExtraReplaces      = "Replaces"
+	ExtraDigest        = "Digest"
+	ExtraSigned        = "Signed"
+	ExtraRekorURL      = "RekorURL"
+	ExtraRekorLogIndex = "RekorLogIndex"
+	ExtraChecksumsMain = "ChecksumsMain"
 )
 
 // Extras represents the extra fields in an artifact.
@@ -153,6 +176,7 @@ type Artifact struct {
 	Goarm   string `json:"goarm,omitempty"`
 	Gomips  string `json:"gomips,omitempty"`
 	Goamd64 string `json:"goamd64,omitempty"`
+	Goarm64 string `json:"goarm64,omitempty"`
 	Type    Type   `json:"type,omitempty"`
 	Extra   Extras `json:"extra,omitempty"`
 }
@@ -179,30 +203,46 @@ func (a Artifact) Checksum(algorithm string) (string, error) {
 		return "", fmt.Errorf("failed to checksum: %w", err)
 	}
 	defer file.Close()
-	var h hash.Hash
+	h, err := newHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to checksum: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ValidChecksumAlgorithm reports whether the given algorithm can be used to
+// checksum artifacts.
+func ValidChecksumAlgorithm(algorithm string) bool {
+	_, err := newHash(algorithm)
+	return err == nil
+}
+
+// nolint: gosec
+func newHash(algorithm string) (hash.Hash, error) {
 	switch algorithm {
 	case "crc32":
-		h = crc32.NewIEEE()
+		return crc32.NewIEEE(), nil
 	case "md5":
-		h = md5.New()
+		return md5.New(), nil
 	case "sha224":
-		h = sha256.New224()
+		return sha256.New224(), nil
 	case "sha384":
-		h = sha512.New384()
+		return sha512.New384(), nil
 	case "sha256":
-		h = sha256.New()
+		return sha256.New(), nil
 	case "sha1":
-		h = sha1.New()
+		return sha1.New(), nil
 	case "sha512":
-		h = sha512.New()
+		return sha512.New(), nil
+	case "blake2b":
+		return blake2b.New512(nil)
 	default:
-		return "", fmt.Errorf("invalid algorithm: %s", algorithm)
-	}
-
-	if _, err := io.Copy(h, file); err != nil {
-		return "", fmt.Errorf("failed to checksum: %w", err)
+		return nil, fmt.Errorf("invalid algorithm: %s", algorithm)
 	}
-	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 var noRefresh = func() error { return nil }
@@ -270,7 +310,7 @@ func (artifacts Artifacts) GroupByID() map[string][]*Artifact {
 func (artifacts Artifacts) GroupByPlatform() map[string][]*Artifact {
 	result := map[string][]*Artifact{}
 	for _, a := range artifacts.items {
-		plat := a.Goos + a.Goarch + a.Goarm + a.Gomips + a.Goamd64
+		plat := a.Goos + a.Goarch + a.Goarm + a.Gomips + a.Goamd64 + a.Goarm64
 		result[plat] = append(result[plat], a)
 	}
 	return result
@@ -346,6 +386,20 @@ func ByGoarm(s string) Filter {
 	}
 }
 
+// ByGomips is a predefined filter that filters by the given gomips.
+func ByGomips(s string) Filter {
+	return func(a *Artifact) bool {
+		return a.Gomips == s
+	}
+}
+
+// ByGoarm64 is a predefined filter that filters by the given goarm64.
+func ByGoarm64(s string) Filter {
+	return func(a *Artifact) bool {
+		return a.Goarm64 == s
+	}
+}
+
 // ByGoamd64 is a predefined filter that filters by the given goamd64.
 func ByGoamd64(s string) Filter {
 	return func(a *Artifact) bool {
@@ -360,6 +414,25 @@ func ByType(t Type) Filter {
 	}
 }
 
+// ByReleaseUploadableTypes is a predefined filter that filters the types of
+// artifacts that are uploaded to releases, blobs and the like.
+func ByReleaseUploadableTypes() Filter {
+	return Or(
+		ByType(UploadableArchive),
+		ByType(UploadableBinary),
+		ByType(UploadableSourceArchive),
+		ByType(Checksum),
+		ByType(Signature),
+		ByType(Certificate),
+		ByType(LinuxPackage),
+		ByType(SBOM),
+		ByType(DebugSymbols),
+		ByType(UploadableDockerImage),
+		ByType(Provenance),
+		ByType(Attestation),
+	)
+}
+
 // ByFormats filters artifacts by a `Format` extra field.
 func ByFormats(formats ...string) Filter {
 	filters := make([]Filter, 0, len(formats))
@@ -373,18 +446,39 @@ func ByFormats(formats ...string) Filter {
 }
 
 // ByIDs filter artifacts by an `ID` extra field.
+// IDs prefixed with `!` are excluded instead, so `!daemon` matches all
+// artifacts but the ones with the `daemon` ID.
 func ByIDs(ids ...string) Filter {
 	filters := make([]Filter, 0, len(ids))
+	excludes := map[string]bool{}
 	for _, id := range ids {
+		if strings.HasPrefix(id, "!") {
+			excludes[strings.TrimPrefix(id, "!")] = true
+			continue
+		}
 		id := id
 		filters = append(filters, func(a *Artifact) bool {
-			// checksum and source archive are always for all artifacts, so return always true.
+			// checksum, source archive and provenance are always for all artifacts, so return always true.
 			return a.Type == Checksum ||
 				a.Type == UploadableSourceArchive ||
+				a.Type == Provenance ||
 				a.ID() == id
 		})
 	}
-	return Or(filters...)
+	if len(excludes) == 0 {
+		return Or(filters...)
+	}
+	filter := func(a *Artifact) bool {
+		return a.Type == Checksum ||
+			a.Type == UploadableSourceArchive ||
+			a.Type == Provenance ||
+			!excludes[a.ID()]
+	}
+	if len(filters) == 0 {
+		// only exclusions were given, so everything else is included.
+		return filter
+	}
+	return And(Or(filters...), filter)
 }
 
 // ByExt filter artifact by their 'Ext' extra field.
@@ -78,6 +78,11 @@ func TestFilter(t *testing.T) {
 			Name:  "foobar",
 			Goarm: "6",
 		},
+		{
+			Name:   "foomips",
+			Goarch: "mips",
+			Gomips: "softfloat",
+		},
 		{
 			Name: "check",
 			Type: Checksum,
@@ -122,13 +127,16 @@ func TestFilter(t *testing.T) {
 	require.Len(t, artifacts.Filter(ByGoarm("6")).items, 1)
 	require.Len(t, artifacts.Filter(ByGoarm("7")).items, 0)
 
+	require.Len(t, artifacts.Filter(ByGomips("softfloat")).items, 1)
+	require.Len(t, artifacts.Filter(ByGomips("hardfloat")).items, 0)
+
 	require.Len(t, artifacts.Filter(ByType(Checksum)).items, 2)
 	require.Len(t, artifacts.Filter(ByType(Binary)).items, 0)
 
-	require.Len(t, artifacts.Filter(OnlyReplacingUnibins).items, 9)
+	require.Len(t, artifacts.Filter(OnlyReplacingUnibins).items, 10)
 	require.Len(t, artifacts.Filter(And(OnlyReplacingUnibins, ByGoos("darwin"))).items, 1)
 
-	require.Len(t, artifacts.Filter(nil).items, 10)
+	require.Len(t, artifacts.Filter(nil).items, 11)
 
 	require.Len(t, artifacts.Filter(
 		And(
@@ -321,13 +329,14 @@ func TestChecksum(t *testing.T) {
 	}
 
 	for algo, result := range map[string]string{
-		"sha256": "5e2bf57d3f40c4b6df69daf1936cb766f832374b4fc0259a7cbff06e2f70f269",
-		"sha512": "f80eebd9aabb1a15fb869ed568d858a5c0dca3d5da07a410e1bd988763918d973e344814625f7c844695b2de36ffd27af290d0e34362c51dee5947d58d40527a",
-		"sha1":   "bfb7759a67daeb65410490b4d98bb9da7d1ea2ce",
-		"crc32":  "72d7748e",
-		"md5":    "80a751fde577028640c419000e33eba6",
-		"sha224": "e191edf06005712583518ced92cc2ac2fac8d6e4623b021a50736a91",
-		"sha384": "597493a6cf1289757524e54dfd6f68b332c7214a716a3358911ef5c09907adc8a654a18c1d721e183b0025f996f6e246",
+		"sha256":  "5e2bf57d3f40c4b6df69daf1936cb766f832374b4fc0259a7cbff06e2f70f269",
+		"sha512":  "f80eebd9aabb1a15fb869ed568d858a5c0dca3d5da07a410e1bd988763918d973e344814625f7c844695b2de36ffd27af290d0e34362c51dee5947d58d40527a",
+		"sha1":    "bfb7759a67daeb65410490b4d98bb9da7d1ea2ce",
+		"crc32":   "72d7748e",
+		"md5":     "80a751fde577028640c419000e33eba6",
+		"sha224":  "e191edf06005712583518ced92cc2ac2fac8d6e4623b021a50736a91",
+		"sha384":  "597493a6cf1289757524e54dfd6f68b332c7214a716a3358911ef5c09907adc8a654a18c1d721e183b0025f996f6e246",
+		"blake2b": "ca0dbbe27fca7e5d97b612a76b66d9d42fd67ece4265a50c09ccaefcdc03d9d5a87fa1fddc926ae10c6667342c69df5c33117cf636fca82ac1377c2b4e23e2bc",
 	} {
 		t.Run(algo, func(t *testing.T) {
 			sum, err := artifact.Checksum(algo)
@@ -359,6 +368,14 @@ func TestInvalidAlgorithm(t *testing.T) {
 	require.Empty(t, sum)
 }
 
+func TestValidChecksumAlgorithm(t *testing.T) {
+	for _, algo := range []string{"crc32", "md5", "sha1", "sha224", "sha256", "sha384", "sha512", "blake2b"} {
+		require.True(t, ValidChecksumAlgorithm(algo), algo)
+	}
+	require.False(t, ValidChecksumAlgorithm("sha1ssss"))
+	require.False(t, ValidChecksumAlgorithm(""))
+}
+
 func TestExtraOr(t *testing.T) {
 	a := &Artifact{
 		Extra: map[string]interface{}{
@@ -399,15 +416,22 @@ func TestByIDs(t *testing.T) {
 			Name: "checksum",
 			Type: Checksum,
 		},
+		{
+			Name: "provenance",
+			Type: Provenance,
+		},
 	}
 	artifacts := New()
 	for _, a := range data {
 		artifacts.Add(a)
 	}
 
-	require.Len(t, artifacts.Filter(ByIDs("check")).items, 2)
-	require.Len(t, artifacts.Filter(ByIDs("foo")).items, 3)
-	require.Len(t, artifacts.Filter(ByIDs("foo", "bar")).items, 4)
+	require.Len(t, artifacts.Filter(ByIDs("check")).items, 3)
+	require.Len(t, artifacts.Filter(ByIDs("foo")).items, 4)
+	require.Len(t, artifacts.Filter(ByIDs("foo", "bar")).items, 5)
+	require.Len(t, artifacts.Filter(ByIDs("!foo")).items, 4)
+	require.Len(t, artifacts.Filter(ByIDs("!foo", "!check")).items, 3)
+	require.Len(t, artifacts.Filter(ByIDs("foo", "bar", "!foo")).items, 3)
 }
 
 func TestByExts(t *testing.T) {
@@ -483,6 +507,51 @@ func TestByFormats(t *testing.T) {
 	require.Len(t, artifacts.Filter(ByFormats("zip", "tar.gz")).items, 3)
 }
 
+func TestByReleaseUploadableTypes(t *testing.T) {
+	artifacts := New()
+	for _, typ := range []Type{
+		UploadableArchive,
+		UploadableBinary,
+		UploadableFile,
+		Binary,
+		UniversalBinary,
+		LinuxPackage,
+		PublishableDockerImage,
+		DockerImage,
+		Checksum,
+		Signature,
+		Certificate,
+		UploadableSourceArchive,
+		BrewTap,
+		SBOM,
+		DebugSymbols,
+		UploadableDockerImage,
+		Provenance,
+		Attestation,
+	} {
+		artifacts.Add(&Artifact{Name: typ.String(), Type: typ})
+	}
+
+	var types []Type
+	for _, a := range artifacts.Filter(ByReleaseUploadableTypes()).List() {
+		types = append(types, a.Type)
+	}
+	require.ElementsMatch(t, []Type{
+		UploadableArchive,
+		UploadableBinary,
+		LinuxPackage,
+		Checksum,
+		Signature,
+		Certificate,
+		UploadableSourceArchive,
+		SBOM,
+		DebugSymbols,
+		UploadableDockerImage,
+		Provenance,
+		Attestation,
+	}, types)
+}
+
 func TestTypeToString(t *testing.T) {
 	for _, a := range []Type{
 		UploadableArchive,
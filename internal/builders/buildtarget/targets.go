@@ -4,9 +4,11 @@ package buildtarget
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/apex/log"
@@ -15,30 +17,50 @@ import (
 )
 
 type target struct {
-	os, arch, arm, mips, amd64 string
+	os, arch, arm, arm64, mips, amd64 string
 }
 
 func (t target) String() string {
-	if extra := t.arm + t.mips + t.amd64; extra != "" {
+	if extra := t.arm + t.arm64 + t.mips + t.amd64; extra != "" {
 		return fmt.Sprintf("%s_%s_%s", t.os, t.arch, extra)
 	}
 	return fmt.Sprintf("%s_%s", t.os, t.arch)
 }
 
 // List compiles the list of targets for the given builds.
+//
+// If the build has no go binary set, no Go version specific checks are made.
 func List(build config.Build) ([]string, error) {
+	return list(build, true)
+}
+
+// ListDefaults is like List, but for builds whose goos or goarch come from
+// the defaults: invalid goos/goarch combinations weren't asked for by the
+// user, so they are skipped without warning and never fail the build.
+func ListDefaults(build config.Build) ([]string, error) {
+	return list(build, false)
+}
+
+func list(build config.Build, explicit bool) ([]string, error) {
+	if build.GoBinary == "" {
+		return matrix(build, nil, explicit)
+	}
 	version, err := goVersion(build)
 	if err != nil {
 		return nil, err
 	}
-	return matrix(build, version)
+	return matrix(build, version, explicit)
 }
 
-func matrix(build config.Build, version []byte) ([]string, error) {
+func matrix(build config.Build, version []byte, explicit bool) ([]string, error) {
 	// nolint:prealloc
 	var targets []target
 	// nolint:prealloc
 	var result []string
+	var invalid []string
+	if err := validateIgnores(build.Ignore); err != nil {
+		return result, err
+	}
 	for _, target := range allBuildTargets(build) {
 		if !contains(target.os, validGoos) {
 			return result, fmt.Errorf("invalid goos: %s", target.os)
@@ -55,23 +77,41 @@ func matrix(build config.Build, version []byte) ([]string, error) {
 		if target.amd64 != "" && !contains(target.amd64, validGoamd64) {
 			return result, fmt.Errorf("invalid goamd64: %s", target.amd64)
 		}
-		if target.os == "windows" && target.arch == "arm64" && !go117re.Match(version) {
+		if target.arm64 != "" && !validGoarm64.MatchString(target.arm64) {
+			return result, fmt.Errorf("invalid goarm64: %s", target.arm64)
+		}
+		if target.os == "windows" && target.arch == "arm64" && goMinor(version) < 17 {
 			log.Warn(color.New(color.Bold, color.FgHiYellow).Sprintf(
 				"DEPRECATED: skipped windows/arm64 build on Go < 1.17 for compatibility, check %s for more info.",
 				"https://goreleaser.com/deprecations/#builds-for-windowsarm64",
 			))
 			continue
 		}
-		if !valid(target) {
-			log.WithField("target", target).Debug("skipped invalid build")
+		if target.os == "freebsd" && target.arch == "riscv64" && goMinor(version) < 20 {
+			log.WithField("target", target).Debug("skipped freebsd/riscv64 build on Go < 1.20")
 			continue
 		}
 		if ignored(build, target) {
 			log.WithField("target", target).Debug("skipped ignored build")
 			continue
 		}
+		if !valid(target) {
+			if !explicit {
+				log.WithField("target", target).Debug("skipped invalid default build")
+				continue
+			}
+			if build.FailOnInvalidTarget {
+				return result, fmt.Errorf("invalid target: %s", target)
+			}
+			invalid = append(invalid, target.String())
+			continue
+		}
 		targets = append(targets, target)
 	}
+	if len(invalid) > 0 {
+		log.WithField("targets", strings.Join(invalid, ", ")).
+			Warn("skipped invalid goos/goarch combinations, add them to ignore to silence this warning")
+	}
 	for _, target := range targets {
 		result = append(result, target.String())
 	}
@@ -101,6 +141,16 @@ func allBuildTargets(build config.Build) (targets []target) {
 				}
 				continue
 			}
+			if goarch == "arm64" && len(build.Goarm64) > 0 {
+				for _, goarm64 := range build.Goarm64 {
+					targets = append(targets, target{
+						os:    goos,
+						arch:  goarch,
+						arm64: goarm64,
+					})
+				}
+				continue
+			}
 			if strings.HasPrefix(goarch, "mips") {
 				for _, gomips := range build.Gomips {
 					targets = append(targets, target{
@@ -122,6 +172,30 @@ func allBuildTargets(build config.Build) (targets []target) {
 
 // TODO: this could be improved by using a map.
 // https://github.com/goreleaser/goreleaser/pull/522#discussion_r164245014
+func validateIgnores(ignores []config.IgnoredBuild) error {
+	for _, ig := range ignores {
+		if ig.Goos != "" && !contains(ig.Goos, validGoos) {
+			return fmt.Errorf("invalid goos in ignore: %s", ig.Goos)
+		}
+		if ig.Goarch != "" && !contains(ig.Goarch, validGoarch) {
+			return fmt.Errorf("invalid goarch in ignore: %s", ig.Goarch)
+		}
+		if ig.Goarm != "" && !contains(ig.Goarm, validGoarm) {
+			return fmt.Errorf("invalid goarm in ignore: %s", ig.Goarm)
+		}
+		if ig.Gomips != "" && !contains(ig.Gomips, validGomips) {
+			return fmt.Errorf("invalid gomips in ignore: %s", ig.Gomips)
+		}
+		if ig.Goamd64 != "" && !contains(ig.Goamd64, validGoamd64) {
+			return fmt.Errorf("invalid goamd64 in ignore: %s", ig.Goamd64)
+		}
+		if ig.Goarm64 != "" && !validGoarm64.MatchString(ig.Goarm64) {
+			return fmt.Errorf("invalid goarm64 in ignore: %s", ig.Goarm64)
+		}
+	}
+	return nil
+}
+
 func ignored(build config.Build, target target) bool {
 	for _, ig := range build.Ignore {
 		if ig.Goos != "" && ig.Goos != target.os {
@@ -139,12 +213,29 @@ func ignored(build config.Build, target target) bool {
 		if ig.Goamd64 != "" && ig.Goamd64 != target.amd64 {
 			continue
 		}
+		if ig.Goarm64 != "" && ig.Goarm64 != target.arm64 {
+			continue
+		}
 		return true
 	}
 	return false
 }
 
-var go117re = regexp.MustCompile(`go version go1.1[7-9]`)
+var goMinorRe = regexp.MustCompile(`go1\.(\d+)`)
+
+// goMinor returns the minor version of the given `go version` output.
+// If it can't be parsed (e.g. devel builds), it is assumed to be the latest.
+func goMinor(version []byte) int {
+	match := goMinorRe.FindSubmatch(version)
+	if len(match) < 2 {
+		return math.MaxInt
+	}
+	minor, err := strconv.Atoi(string(match[1]))
+	if err != nil {
+		return math.MaxInt
+	}
+	return minor
+}
 
 func goVersion(build config.Build) ([]byte, error) {
 	cmd := exec.Command(build.GoBinary, "version")
@@ -192,6 +283,7 @@ var (
 		"freebsdamd64",
 		"freebsdarm",
 		"freebsdarm64", // not on the official list for some reason, yet its supported on go 1.14+
+		"freebsdriscv64",
 		"illumosamd64",
 		"jswasm",
 		"linux386",
@@ -209,10 +301,12 @@ var (
 		"netbsd386",
 		"netbsdamd64",
 		"netbsdarm",
+		"netbsdarm64",
 		"openbsd386",
 		"openbsdamd64",
 		"openbsdarm",
 		"openbsdarm64",
+		"openbsdmips64",
 		"plan9386",
 		"plan9amd64",
 		"plan9arm",
@@ -258,4 +352,7 @@ var (
 	validGoarm   = []string{"5", "6", "7"}
 	validGomips  = []string{"hardfloat", "softfloat"}
 	validGoamd64 = []string{"v1", "v2", "v3", "v4"}
+
+	// see https://go.dev/doc/install/source#environment
+	validGoarm64 = regexp.MustCompile(`^v(8\.[0-9]|9\.[0-5])(,lse)?(,crypto)?$`)
 )
@@ -2,6 +2,7 @@ package buildtarget
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/pkg/config"
@@ -67,7 +68,7 @@ func TestAllBuildTargets(t *testing.T) {
 	}
 
 	t.Run("go 1.16", func(t *testing.T) {
-		result, err := matrix(build, []byte("go version go1.16.2"))
+		result, err := matrix(build, []byte("go version go1.16.2"), true)
 		require.NoError(t, err)
 		require.Equal(t, []string{
 			"linux_386",
@@ -99,6 +100,7 @@ func TestAllBuildTargets(t *testing.T) {
 			"openbsd_amd64_v2",
 			"openbsd_amd64_v4",
 			"openbsd_arm64",
+			"openbsd_mips64_softfloat",
 			"windows_386",
 			"windows_amd64_v1",
 			"windows_amd64_v2",
@@ -110,7 +112,7 @@ func TestAllBuildTargets(t *testing.T) {
 	})
 
 	t.Run("go 1.18", func(t *testing.T) {
-		result, err := matrix(build, []byte("go version go1.18.0"))
+		result, err := matrix(build, []byte("go version go1.18.0"), true)
 		require.NoError(t, err)
 		require.Equal(t, []string{
 			"linux_386",
@@ -142,6 +144,7 @@ func TestAllBuildTargets(t *testing.T) {
 			"openbsd_amd64_v2",
 			"openbsd_amd64_v4",
 			"openbsd_arm64",
+			"openbsd_mips64_softfloat",
 			"windows_386",
 			"windows_amd64_v1",
 			"windows_amd64_v2",
@@ -153,12 +156,76 @@ func TestAllBuildTargets(t *testing.T) {
 		}, result)
 	})
 
+	t.Run("riscv64", func(t *testing.T) {
+		build := config.Build{
+			Goos:   []string{"linux", "freebsd", "windows"},
+			Goarch: []string{"riscv64"},
+		}
+		result, err := matrix(build, []byte("go version go1.19.5"), true)
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_riscv64"}, result)
+
+		result, err = matrix(build, []byte("go version go1.20.1"), true)
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_riscv64", "freebsd_riscv64"}, result)
+	})
+
+	t.Run("goarm64", func(t *testing.T) {
+		result, err := matrix(config.Build{
+			Goos:    []string{"linux", "darwin"},
+			Goarch:  []string{"arm64", "386"},
+			Goarm64: []string{"v8.0", "v9.0,lse"},
+			Ignore: []config.IgnoredBuild{{
+				Goos:    "darwin",
+				Goarch:  "arm64",
+				Goarm64: "v9.0,lse",
+			}},
+		}, []byte("go version go1.23.0"), true)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"linux_arm64_v8.0",
+			"linux_arm64_v9.0,lse",
+			"linux_386",
+			"darwin_arm64_v8.0",
+		}, result)
+	})
+
+	t.Run("fail on invalid target", func(t *testing.T) {
+		build := config.Build{
+			Goos:   []string{"linux", "darwin"},
+			Goarch: []string{"386"},
+		}
+		result, err := matrix(build, []byte("go version go1.18.0"), true)
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_386"}, result)
+
+		build.FailOnInvalidTarget = true
+		_, err = matrix(build, []byte("go version go1.18.0"), true)
+		require.EqualError(t, err, "invalid target: darwin_386")
+
+		build.Ignore = []config.IgnoredBuild{{Goos: "darwin", Goarch: "386"}}
+		result, err = matrix(build, []byte("go version go1.18.0"), true)
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_386"}, result)
+	})
+
+	t.Run("invalid default target", func(t *testing.T) {
+		build := config.Build{
+			Goos:                []string{"linux", "darwin"},
+			Goarch:              []string{"386"},
+			FailOnInvalidTarget: true,
+		}
+		result, err := matrix(build, []byte("go version go1.18.0"), false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_386"}, result)
+	})
+
 	t.Run("invalid goos", func(t *testing.T) {
 		_, err := matrix(config.Build{
 			Goos:    []string{"invalid"},
 			Goarch:  []string{"amd64"},
 			Goamd64: []string{"v2"},
-		}, []byte("go version go1.18.0"))
+		}, []byte("go version go1.18.0"), true)
 		require.EqualError(t, err, "invalid goos: invalid")
 	})
 
@@ -166,7 +233,7 @@ func TestAllBuildTargets(t *testing.T) {
 		_, err := matrix(config.Build{
 			Goos:   []string{"linux"},
 			Goarch: []string{"invalid"},
-		}, []byte("go version go1.18.0"))
+		}, []byte("go version go1.18.0"), true)
 		require.EqualError(t, err, "invalid goarch: invalid")
 	})
 
@@ -175,7 +242,7 @@ func TestAllBuildTargets(t *testing.T) {
 			Goos:   []string{"linux"},
 			Goarch: []string{"arm"},
 			Goarm:  []string{"invalid"},
-		}, []byte("go version go1.18.0"))
+		}, []byte("go version go1.18.0"), true)
 		require.EqualError(t, err, "invalid goarm: invalid")
 	})
 
@@ -184,7 +251,7 @@ func TestAllBuildTargets(t *testing.T) {
 			Goos:   []string{"linux"},
 			Goarch: []string{"mips"},
 			Gomips: []string{"invalid"},
-		}, []byte("go version go1.18.0"))
+		}, []byte("go version go1.18.0"), true)
 		require.EqualError(t, err, "invalid gomips: invalid")
 	})
 
@@ -193,9 +260,31 @@ func TestAllBuildTargets(t *testing.T) {
 			Goos:    []string{"linux"},
 			Goarch:  []string{"amd64"},
 			Goamd64: []string{"invalid"},
-		}, []byte("go version go1.18.0"))
+		}, []byte("go version go1.18.0"), true)
 		require.EqualError(t, err, "invalid goamd64: invalid")
 	})
+
+	t.Run("invalid goarm64", func(t *testing.T) {
+		_, err := matrix(config.Build{
+			Goos:    []string{"linux"},
+			Goarch:  []string{"arm64"},
+			Goarm64: []string{"v7.0"},
+		}, []byte("go version go1.23.0"), true)
+		require.EqualError(t, err, "invalid goarm64: v7.0")
+	})
+
+	t.Run("invalid ignore", func(t *testing.T) {
+		_, err := matrix(config.Build{
+			Goos:   []string{"linux"},
+			Goarch: []string{"arm"},
+			Goarm:  []string{"6"},
+			Ignore: []config.IgnoredBuild{{
+				Goarch: "arm",
+				Goarm:  "8",
+			}},
+		}, []byte("go version go1.18.0"), true)
+		require.EqualError(t, err, "invalid goarm in ignore: 8")
+	})
 }
 
 func TestGoosGoarchCombos(t *testing.T) {
@@ -216,6 +305,7 @@ func TestGoosGoarchCombos(t *testing.T) {
 		{"freebsd", "386", true},
 		{"freebsd", "amd64", true},
 		{"freebsd", "arm", true},
+		{"freebsd", "riscv64", true},
 		{"illumos", "amd64", true},
 		{"linux", "386", true},
 		{"linux", "amd64", true},
@@ -232,9 +322,12 @@ func TestGoosGoarchCombos(t *testing.T) {
 		{"netbsd", "386", true},
 		{"netbsd", "amd64", true},
 		{"netbsd", "arm", true},
+		{"netbsd", "arm64", true},
 		{"openbsd", "386", true},
 		{"openbsd", "amd64", true},
 		{"openbsd", "arm", true},
+		{"openbsd", "arm64", true},
+		{"openbsd", "mips64", true},
 		{"plan9", "386", true},
 		{"plan9", "amd64", true},
 		{"plan9", "arm", true},
@@ -248,10 +341,14 @@ func TestGoosGoarchCombos(t *testing.T) {
 		{"darwin", "386", false},
 		{"darwin", "arm", false},
 		{"windows", "riscv64", false},
+		{"solaris", "arm64", false},
+		{"aix", "amd64", false},
+		{"plan9", "arm64", false},
+		{"dragonfly", "386", false},
 	}
 	for _, p := range platforms {
 		t.Run(fmt.Sprintf("%v %v valid=%v", p.os, p.arch, p.valid), func(t *testing.T) {
-			require.Equal(t, p.valid, valid(target{p.os, p.arch, "", "", ""}))
+			require.Equal(t, p.valid, valid(target{p.os, p.arch, "", "", "", ""}))
 		})
 	}
 }
@@ -301,3 +398,17 @@ func TestList(t *testing.T) {
 		require.EqualError(t, err, `unable to determine version of go binary (nope): exec: "nope": executable file not found in $PATH`)
 	})
 }
+
+func TestGoMinor(t *testing.T) {
+	for version, minor := range map[string]int{
+		"go version go1.16.2 linux/amd64":                 16,
+		"go version go1.18 darwin/arm64":                  18,
+		"go version go1.20.1 linux/riscv64":               20,
+		"go version devel go1.21-4d5f5ad linux/amd64":     21,
+		"go version devel +4d5f5ad Tue Jan 1 linux/amd64": math.MaxInt,
+	} {
+		t.Run(version, func(t *testing.T) {
+			require.Equal(t, minor, goMinor([]byte(version)))
+		})
+	}
+}
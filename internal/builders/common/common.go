@@ -0,0 +1,127 @@
+package common
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/builders/buildtarget"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// WithDefaultTargets sets the default goos/goarch matrix of the non-go
+// builders, unless the build has its own targets.
+func WithDefaultTargets(build config.Build) (config.Build, error) {
+	if len(build.Targets) > 0 {
+		return build, nil
+	}
+	if len(build.Goos) == 0 {
+		build.Goos = []string{"linux", "darwin"}
+	}
+	if len(build.Goarch) == 0 {
+		build.Goarch = []string{"amd64", "arm64"}
+	}
+	if len(build.Goarm) == 0 {
+		build.Goarm = []string{"6"}
+	}
+	if len(build.Goamd64) == 0 {
+		build.Goamd64 = []string{"v1"}
+	}
+	targets, err := buildtarget.List(build)
+	if err != nil {
+		return build, err
+	}
+	build.Targets = targets
+	return build, nil
+}
+
+// Triple converts a goreleaser build target into the target of the given
+// tool, using its table of targets.
+// Tables are keyed by goos_goarch, or goos_arm_goarm for arm.
+func Triple(tool string, triples map[string]string, target string) (string, error) {
+	parts := strings.Split(target, "_")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("%s is not a valid build target", target)
+	}
+	key := parts[0] + "_" + parts[1]
+	if parts[1] == "arm" && len(parts) > 2 {
+		key += "_" + parts[2]
+	}
+	t, ok := triples[key]
+	if !ok {
+		return "", fmt.Errorf("unsupported %s target: %s", tool, target)
+	}
+	return t, nil
+}
+
+// Artifact returns the binary artifact the given build creates with the
+// given options.
+func Artifact(build config.Build, options api.Options) *artifact.Artifact {
+	return &artifact.Artifact{
+		Type:    artifact.Binary,
+		Path:    options.Path,
+		Name:    options.Name,
+		Goos:    options.Goos,
+		Goarch:  options.Goarch,
+		Goamd64: options.Goamd64,
+		Goarm64: options.Goarm64,
+		Goarm:   options.Goarm,
+		Gomips:  options.Gomips,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: strings.TrimSuffix(filepath.Base(options.Path), options.Ext),
+			artifact.ExtraExt:    options.Ext,
+			artifact.ExtraID:     build.ID,
+		},
+	}
+}
+
+// Env returns the host env followed by the build env, templating each entry
+// of the latter so it can reference the ones defined before it.
+func Env(ctx *context.Context, build config.Build, a *artifact.Artifact) ([]string, error) {
+	return TemplateEnv(ctx, api.HostEnv(ctx, build), build.Env, a)
+}
+
+// TemplateEnv templates and appends each of the given entries to env.
+func TemplateEnv(ctx *context.Context, env, entries []string, a *artifact.Artifact) ([]string, error) {
+	for _, e := range entries {
+		ee, err := tmpl.New(ctx).WithEnvS(env).WithArtifact(a, map[string]string{}).Apply(e)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, ee)
+	}
+	return env, nil
+}
+
+// Flags templates the given flags.
+func Flags(ctx *context.Context, env []string, a *artifact.Artifact, flags []string) ([]string, error) {
+	result := make([]string, 0, len(flags))
+	for _, rawFlag := range flags {
+		flag, err := tmpl.New(ctx).WithEnvS(env).WithArtifact(a, map[string]string{}).Apply(rawFlag)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, flag)
+	}
+	return result, nil
+}
+
+// Run runs the given command in dir with the given env.
+func Run(ctx *context.Context, command, env []string, dir string) error {
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	log := log.WithField("env", env).WithField("cmd", command)
+	cmd.Env = env
+	cmd.Dir = dir
+	log.Debug("running")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, string(out))
+	}
+	return nil
+}
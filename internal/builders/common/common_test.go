@@ -0,0 +1,87 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaultTargets(t *testing.T) {
+	build, err := WithDefaultTargets(config.Build{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"linux_amd64_v1",
+		"linux_arm64",
+		"darwin_amd64_v1",
+		"darwin_arm64",
+	}, build.Targets)
+
+	build, err = WithDefaultTargets(config.Build{Targets: []string{"linux_arm64"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"linux_arm64"}, build.Targets)
+	require.Empty(t, build.Goos)
+}
+
+func TestTriple(t *testing.T) {
+	triples := map[string]string{
+		"linux_amd64": "x86_64-linux",
+		"linux_arm_7": "armv7-linux",
+	}
+	for target, expected := range map[string]string{
+		"linux_amd64_v1": "x86_64-linux",
+		"linux_arm_7":    "armv7-linux",
+	} {
+		got, err := Triple("foo", triples, target)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	}
+
+	_, err := Triple("foo", triples, "linux_arm_6")
+	require.EqualError(t, err, "unsupported foo target: linux_arm_6")
+
+	_, err = Triple("foo", triples, "linux")
+	require.EqualError(t, err, "linux is not a valid build target")
+}
+
+func TestEnv(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Env = map[string]string{"HOST": "1"}
+	build := config.Build{
+		BuildDetails: config.BuildDetails{
+			Env: []string{
+				"FOO=foo",
+				"BAR={{ .Env.FOO }}_{{ .Os }}_{{ .Env.HOST }}",
+			},
+		},
+	}
+	a := Artifact(build, api.Options{Goos: "linux"})
+
+	env, err := Env(ctx, build, a)
+	require.NoError(t, err)
+	require.Equal(t, []string{"HOST=1", "FOO=foo", "BAR=foo_linux_1"}, env)
+
+	build.Env = []string{"FOO={{ .Nope }"}
+	_, err = Env(ctx, build, a)
+	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+}
+
+func TestFlags(t *testing.T) {
+	ctx := context.New(config.Project{})
+	a := &artifact.Artifact{Goos: "darwin"}
+	flags, err := Flags(ctx, []string{"FOO=bar"}, a, []string{"--os={{ .Os }}", "--foo={{ .Env.FOO }}"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"--os=darwin", "--foo=bar"}, flags)
+
+	_, err = Flags(ctx, nil, a, []string{"{{ .Nope }"})
+	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.NoError(t, Run(ctx, []string{"sh", "-c", "test \"$FOO\" = bar"}, []string{"FOO=bar"}, "."))
+	require.EqualError(t, Run(ctx, []string{"sh", "-c", "echo nope; exit 1"}, nil, "."), "exit status 1: nope\n")
+}
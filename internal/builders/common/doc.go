@@ -0,0 +1,2 @@
+// Package common provides the helpers shared by the Builder implementations.
+package common
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/imdario/mergo"
+)
+
+// WithOverrides returns the given build with the details of the first
+// override matching the given options merged into its build details, and no
+// overrides left, so it can be used as is by both the hooks and the builder.
+func WithOverrides(ctx *context.Context, build config.Build, options api.Options) (config.Build, error) {
+	optsTarget := options.Goos + options.Goarch + options.Goarm + options.Gomips + options.Goamd64 + options.Goarm64
+	overrides := build.BuildDetailsOverrides
+	build.BuildDetailsOverrides = nil
+	for _, o := range overrides {
+		matches, err := overrideMatches(ctx, o, options.Target, optsTarget)
+		if err != nil {
+			return build, err
+		}
+		if !matches {
+			continue
+		}
+
+		dets := config.BuildDetails{
+			Ldflags:      build.BuildDetails.Ldflags,
+			Tags:         build.BuildDetails.Tags,
+			Flags:        build.BuildDetails.Flags,
+			Asmflags:     build.BuildDetails.Asmflags,
+			Gcflags:      build.BuildDetails.Gcflags,
+			Env:          build.BuildDetails.Env,
+			Goflags:      build.BuildDetails.Goflags,
+			Goexperiment: build.BuildDetails.Goexperiment,
+		}
+		if err := mergo.Merge(&dets, o.BuildDetails, mergo.WithOverride); err != nil {
+			return build, err
+		}
+		// env is appended instead of replaced, so a target can set
+		// e.g. CC and CXX while keeping the build-wide env.
+		if len(o.BuildDetails.Env) > 0 {
+			dets.Env = append(append([]string{}, build.BuildDetails.Env...), o.BuildDetails.Env...)
+		}
+		log.WithField("dets", dets).Info("will use")
+		build.BuildDetails = dets
+		return build, nil
+	}
+	return build, nil
+}
+
+func overrideMatches(ctx *context.Context, o config.BuildDetailsOverride, target, optsTarget string) (bool, error) {
+	if o.Target != "" {
+		overrideTarget, err := tmpl.New(ctx).Apply(o.Target)
+		if err != nil {
+			return false, err
+		}
+		return FixTarget(overrideTarget) == target, nil
+	}
+	overrideTarget, err := tmpl.New(ctx).Apply(o.Goos + o.Goarch + o.Gomips + o.Goarm + o.Goamd64 + o.Goarm64)
+	if err != nil {
+		return false, err
+	}
+	return overrideTarget == optsTarget, nil
+}
+
+// FixTarget adds the default goamd64, goarm or gomips suffix to the given
+// target, if it has none.
+func FixTarget(target string) string {
+	if strings.HasSuffix(target, "_amd64") {
+		return target + "_v1"
+	}
+	if strings.HasSuffix(target, "_arm") {
+		return target + "_6"
+	}
+	if strings.HasSuffix(target, "_mips") ||
+		strings.HasSuffix(target, "_mips64") ||
+		strings.HasSuffix(target, "_mipsle") ||
+		strings.HasSuffix(target, "_mips64le") {
+		return target + "_hardfloat"
+	}
+	return target
+}
@@ -0,0 +1,291 @@
+package common
+
+import (
+	"runtime"
+	"testing"
+
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverrides(t *testing.T) {
+	t.Run("linux amd64", func(t *testing.T) {
+		dets, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"original"},
+				},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos:   "linux",
+						Goarch: "amd64",
+						BuildDetails: config.BuildDetails{
+							Ldflags: []string{"overridden"},
+						},
+					},
+				},
+			}, api.Options{
+				Goos:   "linux",
+				Goarch: "amd64",
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, dets, config.BuildDetails{
+			Ldflags: []string{"overridden"},
+		})
+	})
+
+	t.Run("by target", func(t *testing.T) {
+		build := config.Build{
+			BuildDetails: config.BuildDetails{
+				Ldflags: []string{"original"},
+			},
+			BuildDetailsOverrides: []config.BuildDetailsOverride{
+				{
+					Target: "darwin_amd64",
+					BuildDetails: config.BuildDetails{
+						Ldflags: []string{"overridden"},
+					},
+				},
+			},
+		}
+
+		dets, err := detailsWithOverrides(context.New(config.Project{}), build, api.Options{
+			Target:  "darwin_amd64_v1",
+			Goos:    "darwin",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, config.BuildDetails{
+			Ldflags: []string{"overridden"},
+		}, dets)
+
+		dets, err = detailsWithOverrides(context.New(config.Project{}), build, api.Options{
+			Target:  "darwin_amd64_v3",
+			Goos:    "darwin",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+		})
+		require.NoError(t, err)
+		require.Equal(t, config.BuildDetails{
+			Ldflags: []string{"original"},
+		}, dets)
+	})
+
+	t.Run("invalid target template", func(t *testing.T) {
+		_, err := detailsWithOverrides(context.New(config.Project{}), config.Build{
+			BuildDetailsOverrides: []config.BuildDetailsOverride{
+				{Target: "{{ .Os }"},
+			},
+		}, api.Options{
+			Target: "linux_arm64",
+		})
+		require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("single sided", func(t *testing.T) {
+		dets, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetails: config.BuildDetails{},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos:   "linux",
+						Goarch: "amd64",
+						BuildDetails: config.BuildDetails{
+							Ldflags:  []string{"overridden"},
+							Tags:     []string{"tag1"},
+							Asmflags: []string{"asm1"},
+							Gcflags:  []string{"gcflag1"},
+						},
+					},
+				},
+			}, api.Options{
+				Goos:   "linux",
+				Goarch: "amd64",
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, dets, config.BuildDetails{
+			Ldflags:  []string{"overridden"},
+			Gcflags:  []string{"gcflag1"},
+			Asmflags: []string{"asm1"},
+			Tags:     []string{"tag1"},
+		})
+	})
+
+	t.Run("with template", func(t *testing.T) {
+		dets, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetails: config.BuildDetails{
+					Ldflags:  []string{"original"},
+					Asmflags: []string{"asm1"},
+				},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos:   "{{ .Runtime.Goos }}",
+						Goarch: "{{ .Runtime.Goarch }}",
+						BuildDetails: config.BuildDetails{
+							Ldflags: []string{"overridden"},
+						},
+					},
+				},
+			}, api.Options{
+				Goos:   runtime.GOOS,
+				Goarch: runtime.GOARCH,
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, dets, config.BuildDetails{
+			Ldflags:  []string{"overridden"},
+			Asmflags: []string{"asm1"},
+		})
+	})
+
+	t.Run("with invalid template", func(t *testing.T) {
+		_, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos: "{{ .Runtime.Goos }",
+					},
+				},
+			}, api.Options{
+				Goos:   runtime.GOOS,
+				Goarch: runtime.GOARCH,
+			},
+		)
+		require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("with goarm", func(t *testing.T) {
+		dets, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"original"},
+				},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos:   "linux",
+						Goarch: "arm",
+						Goarm:  "6",
+						BuildDetails: config.BuildDetails{
+							Ldflags: []string{"overridden"},
+						},
+					},
+				},
+			}, api.Options{
+				Goos:   "linux",
+				Goarch: "arm",
+				Goarm:  "6",
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, dets, config.BuildDetails{
+			Ldflags: []string{"overridden"},
+		})
+	})
+
+	t.Run("with env", func(t *testing.T) {
+		dets, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetails: config.BuildDetails{
+					Env: []string{"CGO_ENABLED=1"},
+				},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos:   "linux",
+						Goarch: "arm64",
+						BuildDetails: config.BuildDetails{
+							Env: []string{"CC=aarch64-linux-gnu-gcc", "CXX=aarch64-linux-gnu-g++"},
+						},
+					},
+				},
+			}, api.Options{
+				Goos:   "linux",
+				Goarch: "arm64",
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, dets, config.BuildDetails{
+			Env: []string{"CGO_ENABLED=1", "CC=aarch64-linux-gnu-gcc", "CXX=aarch64-linux-gnu-g++"},
+		})
+	})
+
+	t.Run("with gomips", func(t *testing.T) {
+		dets, err := detailsWithOverrides(
+			context.New(config.Project{}),
+			config.Build{
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"original"},
+				},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{
+					{
+						Goos:   "linux",
+						Goarch: "mips",
+						Gomips: "softfloat",
+						BuildDetails: config.BuildDetails{
+							Ldflags: []string{"overridden"},
+						},
+					},
+				},
+			}, api.Options{
+				Goos:   "linux",
+				Goarch: "mips",
+				Gomips: "softfloat",
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, dets, config.BuildDetails{
+			Ldflags: []string{"overridden"},
+		})
+	})
+}
+
+func TestWithOverridesClearsOverrides(t *testing.T) {
+	build, err := WithOverrides(context.New(config.Project{}), config.Build{
+		BuildDetails: config.BuildDetails{
+			Env: []string{"FOO=1"},
+		},
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos:   "linux",
+				Goarch: "amd64",
+				BuildDetails: config.BuildDetails{
+					Env: []string{"BAR=2"},
+				},
+			},
+		},
+	}, api.Options{
+		Goos:   "linux",
+		Goarch: "amd64",
+	})
+	require.NoError(t, err)
+	require.Empty(t, build.BuildDetailsOverrides)
+	require.Equal(t, []string{"FOO=1", "BAR=2"}, build.Env)
+}
+
+func TestFixTarget(t *testing.T) {
+	for target, expected := range map[string]string{
+		"linux_amd64":    "linux_amd64_v1",
+		"linux_amd64_v3": "linux_amd64_v3",
+		"linux_arm":      "linux_arm_6",
+		"linux_mips64le": "linux_mips64le_hardfloat",
+		"darwin_arm64":   "darwin_arm64",
+	} {
+		require.Equal(t, expected, FixTarget(target), target)
+	}
+}
+
+func detailsWithOverrides(ctx *context.Context, build config.Build, options api.Options) (config.BuildDetails, error) {
+	build, err := WithOverrides(ctx, build, options)
+	return build.BuildDetails, err
+}
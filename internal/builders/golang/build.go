@@ -12,14 +12,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/apex/log"
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/builders/buildtarget"
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	"github.com/goreleaser/goreleaser/internal/builders/zig"
 	"github.com/goreleaser/goreleaser/internal/tmpl"
 	api "github.com/goreleaser/goreleaser/pkg/build"
 	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
-	"github.com/imdario/mergo"
 )
 
 // Default builder instance.
@@ -51,7 +51,21 @@ func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
 	if len(build.Ldflags) == 0 {
 		build.Ldflags = []string{"-s -w -X main.version={{.Version}} -X main.commit={{.Commit}} -X main.date={{.Date}} -X main.builtBy=goreleaser"}
 	}
+	for _, details := range append(
+		[]config.BuildDetails{build.BuildDetails},
+		overridesDetails(build.BuildDetailsOverrides)...,
+	) {
+		if err := validateGoflags(details.Goflags); err != nil {
+			return build, err
+		}
+	}
 	if len(build.Targets) == 0 {
+		list := buildtarget.List
+		if len(build.Goos) == 0 || len(build.Goarch) == 0 {
+			// the default matrix contains combinations that aren't valid,
+			// e.g. darwin/386, those are not worth a warning.
+			list = buildtarget.ListDefaults
+		}
 		if len(build.Goos) == 0 {
 			build.Goos = []string{"linux", "darwin"}
 		}
@@ -67,7 +81,7 @@ func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
 		if len(build.Goamd64) == 0 {
 			build.Goamd64 = []string{"v1"}
 		}
-		targets, err := buildtarget.List(build)
+		targets, err := list(build)
 		if err != nil {
 			return build, err
 		}
@@ -82,28 +96,46 @@ func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
 				}
 				continue
 			}
-			if strings.HasSuffix(target, "_amd64") {
-				targets[target+"_v1"] = true
-				continue
-			}
-			if strings.HasSuffix(target, "_arm") {
-				targets[target+"_6"] = true
-				continue
-			}
-			if strings.HasSuffix(target, "_mips") ||
-				strings.HasSuffix(target, "_mips64") ||
-				strings.HasSuffix(target, "_mipsle") ||
-				strings.HasSuffix(target, "_mips64le") {
-				targets[target+"_hardfloat"] = true
-				continue
-			}
-			targets[target] = true
+			targets[common.FixTarget(target)] = true
 		}
 		build.Targets = keys(targets)
 	}
 	return build, nil
 }
 
+func overridesDetails(overrides []config.BuildDetailsOverride) []config.BuildDetails {
+	result := make([]config.BuildDetails, 0, len(overrides))
+	for _, o := range overrides {
+		result = append(result, o.BuildDetails)
+	}
+	return result
+}
+
+func validateGoflags(flags []string) error {
+	for _, flag := range flags {
+		if !strings.HasPrefix(flag, "-") || strings.ContainsAny(flag, " \t") {
+			return fmt.Errorf("invalid goflags: %q: each entry should be a single flag starting with a dash", flag)
+		}
+	}
+	return nil
+}
+
+// checkGoexperiment asks the go binary to validate the experiments set in
+// the build env, as the available ones change between go versions.
+func checkGoexperiment(ctx *context.Context, build config.Build, env []string) error {
+	if len(build.Goexperiment) == 0 {
+		return nil
+	}
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, build.GoBinary, "env", "GOEXPERIMENT")
+	cmd.Env = env
+	cmd.Dir = build.Dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("invalid goexperiment: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 func keys(m map[string]bool) []string {
 	result := make([]string, 0, len(m))
 	for k := range m {
@@ -135,39 +167,40 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 		return err
 	}
 
-	artifact := &artifact.Artifact{
-		Type:    artifact.Binary,
-		Path:    options.Path,
-		Name:    options.Name,
-		Goos:    options.Goos,
-		Goarch:  options.Goarch,
-		Goamd64: options.Goamd64,
-		Goarm:   options.Goarm,
-		Gomips:  options.Gomips,
-		Extra: map[string]interface{}{
-			artifact.ExtraBinary: strings.TrimSuffix(filepath.Base(options.Path), options.Ext),
-			artifact.ExtraExt:    options.Ext,
-			artifact.ExtraID:     build.ID,
-		},
-	}
-
-	env := append(ctx.Env.Strings(), build.Env...)
-	env = append(
-		env,
-		"GOOS="+options.Goos,
-		"GOARCH="+options.Goarch,
-		"GOARM="+options.Goarm,
-		"GOMIPS="+options.Gomips,
-		"GOMIPS64="+options.Gomips,
-		"GOAMD64="+options.Goamd64,
-	)
+	artifact := common.Artifact(build, options)
+
+	if build.BuildIn != "" {
+		// the host environment makes no sense inside the container.
+		build.CleanEnv = true
+	}
+
+	env, err := buildEnv(ctx, build, build.BuildDetails, options, artifact)
+	if err != nil {
+		return err
+	}
+
+	if build.BuildIn == "" {
+		if err := checkGoexperiment(ctx, build, env); err != nil {
+			return err
+		}
+	}
 
 	cmd, err := buildGoBuildLine(ctx, build, options, artifact, env)
 	if err != nil {
 		return err
 	}
 
-	if err := run(ctx, cmd, env, build.Dir); err != nil {
+	if build.BuildIn != "" {
+		cmd, err = containerize(ctx, build, cmd, env)
+		if err != nil {
+			return err
+		}
+		// docker runs with the host environment, plus the build env, which
+		// it forwards by name to the container.
+		env = append(ctx.Env.Strings(), env...)
+	}
+
+	if err := common.Run(ctx, cmd, env, build.Dir); err != nil {
 		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
 	}
 
@@ -191,54 +224,82 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 	return nil
 }
 
-func withOverrides(ctx *context.Context, build config.Build, options api.Options) (config.BuildDetails, error) {
-	optsTarget := options.Goos + options.Goarch + options.Goarm + options.Gomips + options.Goamd64
-	for _, o := range build.BuildDetailsOverrides {
-		overrideTarget, err := tmpl.New(ctx).Apply(o.Goos + o.Goarch + o.Gomips + o.Goarm + o.Goamd64)
+func buildEnv(ctx *context.Context, build config.Build, details config.BuildDetails, options api.Options, a *artifact.Artifact) ([]string, error) {
+	env := api.HostEnv(ctx, build)
+	if build.ZigCC {
+		// zig env goes first, so users can still override CC and CXX.
+		zigEnv, err := zig.CCEnv(options.Target)
 		if err != nil {
-			return build.BuildDetails, err
+			return nil, err
 		}
+		env = append(env, "CGO_ENABLED=1")
+		env = append(env, zigEnv...)
+	}
+	env, err := common.TemplateEnv(ctx, env, details.Env, a)
+	if err != nil {
+		return nil, err
+	}
+	env = mergeEnv(env, "GOFLAGS", " ", details.Goflags)
+	env = mergeEnv(env, "GOEXPERIMENT", ",", details.Goexperiment)
+	return append(
+		env,
+		"GOOS="+options.Goos,
+		"GOARCH="+options.Goarch,
+		"GOARM="+options.Goarm,
+		"GOMIPS="+options.Gomips,
+		"GOMIPS64="+options.Gomips,
+		"GOAMD64="+options.Goamd64,
+		"GOARM64="+options.Goarm64,
+	), nil
+}
 
-		if optsTarget == overrideTarget {
-			dets := config.BuildDetails{
-				Ldflags:  build.BuildDetails.Ldflags,
-				Tags:     build.BuildDetails.Tags,
-				Flags:    build.BuildDetails.Flags,
-				Asmflags: build.BuildDetails.Asmflags,
-				Gcflags:  build.BuildDetails.Gcflags,
-			}
-			if err := mergo.Merge(&dets, o.BuildDetails, mergo.WithOverride); err != nil {
-				return build.BuildDetails, err
+// mergeEnv appends the given values to the last definition of key in env, if
+// any, using sep to separate them.
+func mergeEnv(env []string, key, sep string, values []string) []string {
+	if len(values) == 0 {
+		return env
+	}
+	value := strings.Join(values, sep)
+	for i := len(env) - 1; i >= 0; i-- {
+		if current := strings.TrimPrefix(env[i], key+"="); current != env[i] {
+			if current != "" {
+				value = current + sep + value
 			}
-			log.WithField("dets", dets).Info("will use")
-			return dets, nil
+			break
 		}
 	}
-	return build.BuildDetails, nil
+	return append(env, key+"="+value)
 }
 
 func buildGoBuildLine(ctx *context.Context, build config.Build, options api.Options, artifact *artifact.Artifact, env []string) ([]string, error) {
 	cmd := []string{build.GoBinary, build.Command}
 
-	details, err := withOverrides(ctx, build, options)
-	if err != nil {
-		return cmd, err
-	}
+	details := build.BuildDetails
 	flags, err := processFlags(ctx, artifact, env, details.Flags, "")
 	if err != nil {
-		return cmd, err
+		return cmd, fmt.Errorf("invalid flags: %w", err)
 	}
 	cmd = append(cmd, flags...)
+	// proxied builds run in their own module, which has no vendor directory.
+	if mod := ctx.Config.GoMod.Mod; mod != "" && build.UnproxiedMain == "" && !hasFlag(flags, "-mod=") {
+		cmd = append(cmd, "-mod="+mod)
+	}
+	if build.Trimpath && !contains(flags, "-trimpath") {
+		cmd = append(cmd, "-trimpath")
+	}
+	if build.Buildmode != "" && !hasFlag(flags, "-buildmode=") {
+		cmd = append(cmd, "-buildmode="+build.Buildmode)
+	}
 
 	asmflags, err := processFlags(ctx, artifact, env, details.Asmflags, "-asmflags=")
 	if err != nil {
-		return cmd, err
+		return cmd, fmt.Errorf("invalid asmflags: %w", err)
 	}
 	cmd = append(cmd, asmflags...)
 
 	gcflags, err := processFlags(ctx, artifact, env, details.Gcflags, "-gcflags=")
 	if err != nil {
-		return cmd, err
+		return cmd, fmt.Errorf("invalid gcflags: %w", err)
 	}
 	cmd = append(cmd, gcflags...)
 
@@ -246,9 +307,11 @@ func buildGoBuildLine(ctx *context.Context, build config.Build, options api.Opti
 	if len(details.Tags) > 0 {
 		tags, err := processFlags(ctx, artifact, env, details.Tags, "")
 		if err != nil {
-			return cmd, err
+			return cmd, fmt.Errorf("invalid tags: %w", err)
+		}
+		if len(tags) > 0 {
+			cmd = append(cmd, "-tags="+strings.Join(tags, ","))
 		}
-		cmd = append(cmd, "-tags="+strings.Join(tags, ","))
 	}
 
 	// ldflags is not a repeatable flag
@@ -256,7 +319,7 @@ func buildGoBuildLine(ctx *context.Context, build config.Build, options api.Opti
 		// flag prefix is skipped because ldflags need to output a single string
 		ldflags, err := processFlags(ctx, artifact, env, details.Ldflags, "")
 		if err != nil {
-			return cmd, err
+			return cmd, fmt.Errorf("invalid ldflags: %w", err)
 		}
 		// ldflags need to be single string in order to apply correctly
 		cmd = append(cmd, "-ldflags="+strings.Join(ldflags, " "))
@@ -271,28 +334,84 @@ func processFlags(ctx *context.Context, a *artifact.Artifact, env, flags []strin
 	for _, rawFlag := range flags {
 		flag, err := processFlag(ctx, a, env, rawFlag)
 		if err != nil {
-			return nil, err
+			// each entry is templated on its own, so point at the broken one.
+			return nil, fmt.Errorf("%s: %w", rawFlag, err)
+		}
+		// templated flags might evaluate to empty, e.g. `{{ if .IsSnapshot }}-race{{ end }}`
+		if strings.TrimSpace(flag) == "" {
+			continue
 		}
 		processed = append(processed, flagPrefix+flag)
 	}
 	return processed, nil
 }
 
+func hasFlag(flags []string, prefix string) bool {
+	for _, f := range flags {
+		if strings.HasPrefix(f, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(ss []string, s string) bool {
+	for _, z := range ss {
+		if z == s {
+			return true
+		}
+	}
+	return false
+}
+
 func processFlag(ctx *context.Context, a *artifact.Artifact, env []string, rawFlag string) (string, error) {
 	return tmpl.New(ctx).WithEnvS(env).WithArtifact(a, map[string]string{}).Apply(rawFlag)
 }
 
-func run(ctx *context.Context, command, env []string, dir string) error {
-	/* #nosec */
-	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
-	log := log.WithField("env", env).WithField("cmd", command)
-	cmd.Env = env
-	cmd.Dir = dir
-	log.Debug("running")
-	if out, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("%w: %s", err, string(out))
+// containerize wraps the given command so it runs inside the build_in docker
+// image, with the current directory and dist mounted at the same paths.
+// The given env must also be set in the env of the docker command.
+func containerize(ctx *context.Context, build config.Build, command, env []string) ([]string, error) {
+	image, err := tmpl.New(ctx).Apply(build.BuildIn)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	dist, err := filepath.Abs(ctx.Config.Dist)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Abs(build.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"docker", "run", "--rm", "-v", wd + ":" + wd}
+	if !strings.HasPrefix(dist, wd+string(filepath.Separator)) {
+		args = append(args, "-v", dist+":"+dist)
+	}
+	args = append(args, "-w", dir)
+	if uid := os.Getuid(); uid >= 0 {
+		// so the binaries are owned by the current user, which then needs
+		// a writable home for the go caches.
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, os.Getgid()), "-e", "HOME=/tmp")
+	}
+	// only the keys go in the arguments, so values like tokens can't be
+	// seen in the process list; docker reads them from its own env.
+	seen := map[string]bool{}
+	for _, e := range env {
+		key := strings.SplitN(e, "=", 2)[0]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		args = append(args, "-e", key)
+	}
+	args = append(args, image)
+	return append(args, command...), nil
 }
 
 func checkMain(build config.Build) error {
@@ -311,11 +430,16 @@ func checkMain(build config.Build) error {
 	if main == "" {
 		main = "."
 	}
+	pkg := main
 	if dir != "" {
 		main = filepath.Join(dir, main)
 	}
 	stat, ferr := os.Stat(main)
 	if ferr != nil {
+		if !strings.HasSuffix(pkg, ".go") {
+			// main might be a package path, e.g. github.com/foo/bar/cmd/bar.
+			return checkMainPackage(build, dir, pkg)
+		}
 		return fmt.Errorf("couldn't find main file: %w", ferr)
 	}
 	if stat.IsDir() {
@@ -342,6 +466,26 @@ func checkMain(build config.Build) error {
 	return errNoMain{build.Binary}
 }
 
+// checkMainPackage uses `go list` to check if the given package path is a
+// main package.
+func checkMainPackage(build config.Build, dir, pkg string) error {
+	gobin := build.GoBinary
+	if gobin == "" {
+		gobin = "go"
+	}
+	/* #nosec */
+	cmd := exec.Command(gobin, "list", "-f", "{{.Name}}", pkg)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("couldn't find main package: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	if strings.TrimSpace(string(out)) != "main" {
+		return errNoMain{build.Binary}
+	}
+	return nil
+}
+
 type errNoMain struct {
 	bin string
 }
@@ -254,6 +254,22 @@ func TestDefaults(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "test", build.Command)
 	})
+	t.Run("invalid default targets", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:                []string{"darwin"},
+			FailOnInvalidTarget: true,
+		})
+		require.NoError(t, err)
+		require.NotContains(t, build.Targets, "darwin_386")
+	})
+	t.Run("invalid explicit targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Goos:                []string{"darwin"},
+			Goarch:              []string{"386"},
+			FailOnInvalidTarget: true,
+		})
+		require.EqualError(t, err, "invalid target: darwin_386")
+	})
 }
 
 // createFakeGoBinaryWithVersion creates a temporary executable with the
@@ -280,6 +296,29 @@ func createFakeGoBinaryWithVersion(tb testing.TB, name, version string) {
 	require.NoError(tb, os.Setenv("PATH", path))
 }
 
+func TestGoflagsAndGoexperiment(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"linux_amd64"},
+			BuildDetails: config.BuildDetails{
+				Goflags:      []string{"-mod=vendor", "-trimpath"},
+				Goexperiment: []string{"arenas"},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid goflags", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"linux_amd64"},
+			BuildDetails: config.BuildDetails{
+				Goflags: []string{"mod=vendor"},
+			},
+		})
+		require.EqualError(t, err, `invalid goflags: "mod=vendor": each entry should be a single flag starting with a dash`)
+	})
+}
+
 func TestInvalidTargets(t *testing.T) {
 	type testcase struct {
 		build       config.Build
@@ -340,7 +379,6 @@ func TestBuild(t *testing.T) {
 		Builds: []config.Build{
 			{
 				ID:     "foo",
-				Env:    []string{"GO111MODULE=off"},
 				Binary: "bin/foo-{{ .Version }}",
 				Targets: []string{
 					"linux_amd64",
@@ -354,6 +392,7 @@ func TestBuild(t *testing.T) {
 				GoBinary: "go",
 				Command:  "build",
 				BuildDetails: config.BuildDetails{
+					Env:      []string{"GO111MODULE=off"},
 					Asmflags: []string{".=", "all="},
 					Gcflags:  []string{"all="},
 					Flags:    []string{"{{.Env.GO_FLAGS}}"},
@@ -523,8 +562,10 @@ func TestBuildCodeInSubdir(t *testing.T) {
 	config := config.Project{
 		Builds: []config.Build{
 			{
-				ID:     "foo",
-				Env:    []string{"GO111MODULE=off"},
+				ID: "foo",
+				BuildDetails: config.BuildDetails{
+					Env: []string{"GO111MODULE=off"},
+				},
 				Dir:    "bar",
 				Binary: "foo",
 				Targets: []string{
@@ -554,8 +595,10 @@ func TestBuildWithDotGoDir(t *testing.T) {
 	config := config.Project{
 		Builds: []config.Build{
 			{
-				ID:       "foo",
-				Env:      []string{"GO111MODULE=off"},
+				ID: "foo",
+				BuildDetails: config.BuildDetails{
+					Env: []string{"GO111MODULE=off"},
+				},
 				Binary:   "foo",
 				Targets:  []string{runtimeTarget},
 				GoBinary: "go",
@@ -601,6 +644,33 @@ func TestBuildFailed(t *testing.T) {
 	require.Empty(t, ctx.Artifacts.List())
 }
 
+func TestBuildInvalidGoexperiment(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	writeGoodMain(t, folder)
+	config := config.Project{
+		Builds: []config.Build{
+			{
+				ID: "buildid",
+				BuildDetails: config.BuildDetails{
+					Goexperiment: []string{"foo"},
+				},
+				Targets: []string{
+					runtimeTarget,
+				},
+				GoBinary: "go",
+				Command:  "build",
+			},
+		},
+	}
+	ctx := context.New(config)
+	ctx.Git.CurrentTag = "5.6.7"
+	err := Default.Build(ctx, ctx.Config.Builds[0], api.Options{
+		Target: runtimeTarget,
+	})
+	require.EqualError(t, err, "invalid goexperiment: go: unknown GOEXPERIMENT foo")
+	require.Empty(t, ctx.Artifacts.List())
+}
+
 func TestRunInvalidAsmflags(t *testing.T) {
 	folder := testlib.Mktmp(t)
 	writeGoodMain(t, folder)
@@ -622,7 +692,7 @@ func TestRunInvalidAsmflags(t *testing.T) {
 	err := Default.Build(ctx, ctx.Config.Builds[0], api.Options{
 		Target: runtimeTarget,
 	})
-	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	require.EqualError(t, err, `invalid asmflags: {{.Version}: template: tmpl:1: unexpected "}" in operand`)
 }
 
 func TestRunInvalidGcflags(t *testing.T) {
@@ -646,7 +716,7 @@ func TestRunInvalidGcflags(t *testing.T) {
 	err := Default.Build(ctx, ctx.Config.Builds[0], api.Options{
 		Target: runtimeTarget,
 	})
-	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	require.EqualError(t, err, `invalid gcflags: {{.Version}: template: tmpl:1: unexpected "}" in operand`)
 }
 
 func TestRunInvalidLdflags(t *testing.T) {
@@ -671,7 +741,7 @@ func TestRunInvalidLdflags(t *testing.T) {
 	err := Default.Build(ctx, ctx.Config.Builds[0], api.Options{
 		Target: runtimeTarget,
 	})
-	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	require.EqualError(t, err, `invalid ldflags: -s -w -X main.version={{.Version}: template: tmpl:1: unexpected "}" in operand`)
 }
 
 func TestRunInvalidFlags(t *testing.T) {
@@ -694,7 +764,7 @@ func TestRunInvalidFlags(t *testing.T) {
 	err := Default.Build(ctx, ctx.Config.Builds[0], api.Options{
 		Target: runtimeTarget,
 	})
-	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	require.EqualError(t, err, `invalid flags: {{.Env.GOOS}: template: tmpl:1: unexpected "}" in operand`)
 }
 
 func TestRunPipeWithoutMainFunc(t *testing.T) {
@@ -750,6 +820,38 @@ func TestRunPipeWithoutMainFunc(t *testing.T) {
 	})
 }
 
+func TestCheckMainPackage(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "go.mod"), []byte("module example.com/foo\n\ngo 1.18\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "cmd", "app"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "cmd", "app", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "lib"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "lib", "lib.go"), []byte("package lib\n"), 0o644))
+
+	t.Run("main package", func(t *testing.T) {
+		require.NoError(t, checkMain(config.Build{
+			Binary: "app",
+			Main:   "example.com/foo/cmd/app",
+		}))
+	})
+
+	t.Run("not a main package", func(t *testing.T) {
+		require.EqualError(t, checkMain(config.Build{
+			Binary: "lib",
+			Main:   "example.com/foo/lib",
+		}), errNoMain{"lib"}.Error())
+	})
+
+	t.Run("package not found", func(t *testing.T) {
+		err := checkMain(config.Build{
+			Binary: "nope",
+			Main:   "example.com/foo/nope",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "couldn't find main package")
+	})
+}
+
 func TestBuildTests(t *testing.T) {
 	folder := testlib.Mktmp(t)
 	writeTest(t, folder)
@@ -834,7 +936,9 @@ func TestRunPipeWithMainFuncNotInMainGoFile(t *testing.T) {
 	config := config.Project{
 		Builds: []config.Build{
 			{
-				Env:    []string{"GO111MODULE=off"},
+				BuildDetails: config.BuildDetails{
+					Env: []string{"GO111MODULE=off"},
+				},
 				Binary: "foo",
 				Hooks:  config.BuildHookConfig{},
 				Targets: []string{
@@ -935,6 +1039,7 @@ func TestProcessFlags(t *testing.T) {
 		"{{.Arm}}",
 		"{{.Binary}}",
 		"{{.ArtifactName}}",
+		"{{ if .IsSnapshot }}snapshot{{ end }}",
 	}
 
 	expected := []string{
@@ -960,7 +1065,7 @@ func TestProcessFlagsInvalid(t *testing.T) {
 		"{{.Version}",
 	}
 
-	expected := `template: tmpl:1: unexpected "}" in operand`
+	expected := `{{.Version}: template: tmpl:1: unexpected "}" in operand`
 
 	flags, err := processFlags(ctx, &artifact.Artifact{}, []string{}, source, "-testflag=")
 	require.EqualError(t, err, expected)
@@ -978,7 +1083,6 @@ func TestBuildModTimestamp(t *testing.T) {
 		Builds: []config.Build{
 			{
 				ID:     "foo",
-				Env:    []string{"GO111MODULE=off"},
 				Binary: "bin/foo-{{ .Version }}",
 				Targets: []string{
 					"linux_amd64",
@@ -990,6 +1094,7 @@ func TestBuildModTimestamp(t *testing.T) {
 					"linux_mips64le_softfloat",
 				},
 				BuildDetails: config.BuildDetails{
+					Env:      []string{"GO111MODULE=off"},
 					Asmflags: []string{".=", "all="},
 					Gcflags:  []string{"all="},
 					Flags:    []string{"{{.Env.GO_FLAGS}}"},
@@ -1081,41 +1186,93 @@ func TestBuildGoBuildLine(t *testing.T) {
 		})
 	})
 
-	t.Run("with overrides", func(t *testing.T) {
+	t.Run("trimpath", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main:     ".",
+			Trimpath: true,
+			BuildDetails: config.BuildDetails{
+				Gcflags: []string{"all=-N -l"},
+			},
+			GoBinary: "go",
+			Command:  "build",
+			Binary:   "foo",
+		}, []string{"go", "build", "-trimpath", "-gcflags=all=-N -l", "-o", "foo", "."})
+	})
+
+	t.Run("trimpath already in flags", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main:     ".",
+			Trimpath: true,
+			BuildDetails: config.BuildDetails{
+				Flags: []string{"-trimpath"},
+			},
+			GoBinary: "go",
+			Command:  "build",
+			Binary:   "foo",
+		}, strings.Fields("go build -trimpath -o foo ."))
+	})
+
+	t.Run("templated tags", func(t *testing.T) {
 		requireEqualCmd(t, config.Build{
 			Main: ".",
 			BuildDetails: config.BuildDetails{
-				Asmflags: []string{"asmflag1", "asmflag2"},
-				Gcflags:  []string{"gcflag1", "gcflag2"},
-				Flags:    []string{"-flag1", "-flag2"},
-				Tags:     []string{"tag1", "tag2"},
-				Ldflags:  []string{"ldflag1", "ldflag2"},
+				Tags: []string{"netgo", "{{ if .IsSnapshot }}dev{{ end }}", "osusergo"},
 			},
-			BuildDetailsOverrides: []config.BuildDetailsOverride{
-				{
-					Goos:   "linux",
-					Goarch: "amd64",
-					BuildDetails: config.BuildDetails{
-						Asmflags: []string{"asmflag3"},
-						Gcflags:  []string{"gcflag3"},
-						Flags:    []string{"-flag3"},
-						Tags:     []string{"tag3"},
-						Ldflags:  []string{"ldflag3"},
-					},
-				},
+			GoBinary: "go",
+			Command:  "build",
+			Binary:   "foo",
+		}, strings.Fields("go build -tags=netgo,osusergo -o foo ."))
+	})
+
+	t.Run("empty templated tags", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main: ".",
+			BuildDetails: config.BuildDetails{
+				Tags: []string{"{{ if .IsSnapshot }}dev{{ end }}"},
 			},
 			GoBinary: "go",
+			Command:  "build",
 			Binary:   "foo",
+		}, strings.Fields("go build -o foo ."))
+	})
+
+	t.Run("buildmode", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main:      ".",
+			Buildmode: "pie",
+			GoBinary:  "go",
+			Command:   "build",
+			Binary:    "foo",
+		}, strings.Fields("go build -buildmode=pie -o foo ."))
+	})
+
+	t.Run("buildmode already in flags", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main:      ".",
+			Buildmode: "pie",
+			BuildDetails: config.BuildDetails{
+				Flags: []string{"-buildmode=c-shared"},
+			},
+			GoBinary: "go",
 			Command:  "build",
-		}, []string{
-			"go", "build",
-			"-flag3",
-			"-asmflags=asmflag3",
-			"-gcflags=gcflag3",
-			"-tags=tag3",
-			"-ldflags=ldflag3",
-			"-o", "foo", ".",
-		})
+			Binary:   "foo",
+		}, strings.Fields("go build -buildmode=c-shared -o foo ."))
+	})
+
+	t.Run("templated flags", func(t *testing.T) {
+		requireEqualCmd(t, config.Build{
+			Main: ".",
+			BuildDetails: config.BuildDetails{
+				Flags: []string{
+					"-pgo=profiles/{{ .Version }}.pprof",
+					"{{ if .IsSnapshot }}-race{{ end }}",
+				},
+				Gcflags: []string{"{{ if .IsSnapshot }}all=-N -l{{ end }}"},
+			},
+			GoBinary: "go",
+			Command:  "build",
+			Binary:   "foo",
+		}, strings.Fields("go build -pgo=profiles/1.2.3.pprof -o foo ."))
 	})
 
 	t.Run("simple", func(t *testing.T) {
@@ -1168,167 +1325,44 @@ func TestBuildGoBuildLine(t *testing.T) {
 	})
 }
 
-func TestOverrides(t *testing.T) {
-	t.Run("linux amd64", func(t *testing.T) {
-		dets, err := withOverrides(
-			context.New(config.Project{}),
-			config.Build{
-				BuildDetails: config.BuildDetails{
-					Ldflags: []string{"original"},
-				},
-				BuildDetailsOverrides: []config.BuildDetailsOverride{
-					{
-						Goos:   "linux",
-						Goarch: "amd64",
-						BuildDetails: config.BuildDetails{
-							Ldflags: []string{"overridden"},
-						},
-					},
-				},
-			}, api.Options{
-				Goos:   "linux",
-				Goarch: "amd64",
-			},
-		)
-		require.NoError(t, err)
-		require.Equal(t, dets, config.BuildDetails{
-			Ldflags: []string{"overridden"},
-		})
+func TestBuildGoBuildLineWithMod(t *testing.T) {
+	build := config.Build{
+		Main:     ".",
+		GoBinary: "go",
+		Command:  "build",
+		Binary:   "foo",
+	}
+	ctx := context.New(config.Project{
+		GoMod: config.GoMod{
+			Mod: "vendor",
+		},
 	})
+	opts := api.Options{
+		Path:   "foo",
+		Goos:   "linux",
+		Goarch: "amd64",
+	}
 
-	t.Run("single sided", func(t *testing.T) {
-		dets, err := withOverrides(
-			context.New(config.Project{}),
-			config.Build{
-				BuildDetails: config.BuildDetails{},
-				BuildDetailsOverrides: []config.BuildDetailsOverride{
-					{
-						Goos:   "linux",
-						Goarch: "amd64",
-						BuildDetails: config.BuildDetails{
-							Ldflags:  []string{"overridden"},
-							Tags:     []string{"tag1"},
-							Asmflags: []string{"asm1"},
-							Gcflags:  []string{"gcflag1"},
-						},
-					},
-				},
-			}, api.Options{
-				Goos:   "linux",
-				Goarch: "amd64",
-			},
-		)
+	t.Run("from gomod", func(t *testing.T) {
+		line, err := buildGoBuildLine(ctx, build, opts, &artifact.Artifact{}, []string{})
 		require.NoError(t, err)
-		require.Equal(t, dets, config.BuildDetails{
-			Ldflags:  []string{"overridden"},
-			Gcflags:  []string{"gcflag1"},
-			Asmflags: []string{"asm1"},
-			Tags:     []string{"tag1"},
-		})
+		require.Equal(t, strings.Fields("go build -mod=vendor -o foo ."), line)
 	})
 
-	t.Run("with template", func(t *testing.T) {
-		dets, err := withOverrides(
-			context.New(config.Project{}),
-			config.Build{
-				BuildDetails: config.BuildDetails{
-					Ldflags:  []string{"original"},
-					Asmflags: []string{"asm1"},
-				},
-				BuildDetailsOverrides: []config.BuildDetailsOverride{
-					{
-						Goos:   "{{ .Runtime.Goos }}",
-						Goarch: "{{ .Runtime.Goarch }}",
-						BuildDetails: config.BuildDetails{
-							Ldflags: []string{"overridden"},
-						},
-					},
-				},
-			}, api.Options{
-				Goos:   runtime.GOOS,
-				Goarch: runtime.GOARCH,
-			},
-		)
+	t.Run("flag set", func(t *testing.T) {
+		build := build
+		build.Flags = []string{"-mod=readonly"}
+		line, err := buildGoBuildLine(ctx, build, opts, &artifact.Artifact{}, []string{})
 		require.NoError(t, err)
-		require.Equal(t, dets, config.BuildDetails{
-			Ldflags:  []string{"overridden"},
-			Asmflags: []string{"asm1"},
-		})
-	})
-
-	t.Run("with invalid template", func(t *testing.T) {
-		_, err := withOverrides(
-			context.New(config.Project{}),
-			config.Build{
-				BuildDetailsOverrides: []config.BuildDetailsOverride{
-					{
-						Goos: "{{ .Runtime.Goos }",
-					},
-				},
-			}, api.Options{
-				Goos:   runtime.GOOS,
-				Goarch: runtime.GOARCH,
-			},
-		)
-		require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+		require.Equal(t, strings.Fields("go build -mod=readonly -o foo ."), line)
 	})
 
-	t.Run("with goarm", func(t *testing.T) {
-		dets, err := withOverrides(
-			context.New(config.Project{}),
-			config.Build{
-				BuildDetails: config.BuildDetails{
-					Ldflags: []string{"original"},
-				},
-				BuildDetailsOverrides: []config.BuildDetailsOverride{
-					{
-						Goos:   "linux",
-						Goarch: "arm",
-						Goarm:  "6",
-						BuildDetails: config.BuildDetails{
-							Ldflags: []string{"overridden"},
-						},
-					},
-				},
-			}, api.Options{
-				Goos:   "linux",
-				Goarch: "arm",
-				Goarm:  "6",
-			},
-		)
+	t.Run("proxied", func(t *testing.T) {
+		build := build
+		build.UnproxiedMain = "."
+		line, err := buildGoBuildLine(ctx, build, opts, &artifact.Artifact{}, []string{})
 		require.NoError(t, err)
-		require.Equal(t, dets, config.BuildDetails{
-			Ldflags: []string{"overridden"},
-		})
-	})
-
-	t.Run("with gomips", func(t *testing.T) {
-		dets, err := withOverrides(
-			context.New(config.Project{}),
-			config.Build{
-				BuildDetails: config.BuildDetails{
-					Ldflags: []string{"original"},
-				},
-				BuildDetailsOverrides: []config.BuildDetailsOverride{
-					{
-						Goos:   "linux",
-						Goarch: "mips",
-						Gomips: "softfloat",
-						BuildDetails: config.BuildDetails{
-							Ldflags: []string{"overridden"},
-						},
-					},
-				},
-			}, api.Options{
-				Goos:   "linux",
-				Goarch: "mips",
-				Gomips: "softfloat",
-			},
-		)
-		require.NoError(t, err)
-		require.Equal(t, dets, config.BuildDetails{
-			Ldflags: []string{"overridden"},
-		})
+		require.Equal(t, strings.Fields("go build -o foo ."), line)
 	})
 }
 
@@ -1373,3 +1407,201 @@ func assertContainsError(t *testing.T, err error, s string) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), s)
 }
+
+func TestBuildEnv(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Env = map[string]string{"FOO": "bar"}
+	options := api.Options{
+		Target:  "linux_amd64_v1",
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+	}
+
+	t.Run("simple", func(t *testing.T) {
+		env, err := buildEnv(ctx, config.Build{}, config.BuildDetails{
+			Env: []string{"CGO_ENABLED=0"},
+		}, options, &artifact.Artifact{})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"FOO=bar",
+			"CGO_ENABLED=0",
+			"GOOS=linux",
+			"GOARCH=amd64",
+			"GOARM=",
+			"GOMIPS=",
+			"GOMIPS64=",
+			"GOAMD64=v1",
+			"GOARM64=",
+		}, env)
+	})
+
+	t.Run("clean env", func(t *testing.T) {
+		env, err := buildEnv(ctx, config.Build{CleanEnv: true}, config.BuildDetails{
+			Env: []string{"CGO_ENABLED=0"},
+		}, options, &artifact.Artifact{})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"CGO_ENABLED=0",
+			"GOOS=linux",
+			"GOARCH=amd64",
+			"GOARM=",
+			"GOMIPS=",
+			"GOMIPS64=",
+			"GOAMD64=v1",
+			"GOARM64=",
+		}, env)
+	})
+
+	t.Run("goflags and goexperiment", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		ctx.Env = map[string]string{}
+		env, err := buildEnv(ctx, config.Build{}, config.BuildDetails{
+			Env:          []string{"GOFLAGS=-mod=mod"},
+			Goflags:      []string{"-trimpath", "-buildvcs=false"},
+			Goexperiment: []string{"arenas"},
+		}, options, &artifact.Artifact{})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"GOFLAGS=-mod=mod",
+			"GOFLAGS=-mod=mod -trimpath -buildvcs=false",
+			"GOEXPERIMENT=arenas",
+			"GOOS=linux",
+			"GOARCH=amd64",
+			"GOARM=",
+			"GOMIPS=",
+			"GOMIPS64=",
+			"GOAMD64=v1",
+			"GOARM64=",
+		}, env)
+	})
+
+	t.Run("zig cc", func(t *testing.T) {
+		env, err := buildEnv(ctx, config.Build{ZigCC: true}, config.BuildDetails{
+			Env: []string{"CXX=clang++"},
+		}, options, &artifact.Artifact{})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"FOO=bar",
+			"CGO_ENABLED=1",
+			"CC=zig cc -target x86_64-linux-gnu",
+			"CXX=zig c++ -target x86_64-linux-gnu",
+			"CXX=clang++",
+			"GOOS=linux",
+			"GOARCH=amd64",
+			"GOARM=",
+			"GOMIPS=",
+			"GOMIPS64=",
+			"GOAMD64=v1",
+			"GOARM64=",
+		}, env)
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		ctx.Env = map[string]string{"FOO": "bar"}
+		ctx.Version = "1.2.3"
+		env, err := buildEnv(ctx, config.Build{}, config.BuildDetails{
+			Env: []string{
+				"VERSION={{ .Version }}",
+				"OS={{ .Os }}",
+				"FOOBAR={{ .Env.FOO }}{{ .Env.VERSION }}",
+			},
+		}, options, &artifact.Artifact{Goos: "linux"})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"FOO=bar",
+			"VERSION=1.2.3",
+			"OS=linux",
+			"FOOBAR=bar1.2.3",
+		}, env[:4])
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := buildEnv(ctx, config.Build{}, config.BuildDetails{
+			Env: []string{"FOO={{ .Version }"},
+		}, options, &artifact.Artifact{})
+		require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("zig cc unsupported target", func(t *testing.T) {
+		_, err := buildEnv(ctx, config.Build{ZigCC: true}, config.BuildDetails{}, api.Options{
+			Target: "js_wasm",
+		}, &artifact.Artifact{})
+		require.EqualError(t, err, "unsupported zig target: js_wasm")
+	})
+}
+
+func TestContainerize(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	user := fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+	command := []string{"go", "build", "-o", "dist/foo", "."}
+	env := []string{"CGO_ENABLED=0", "GOOS=linux", "GOPRIVATE=secret", "GOOS=darwin"}
+
+	t.Run("dist inside current dir", func(t *testing.T) {
+		ctx := context.New(config.Project{Dist: "dist"})
+		ctx.Env = map[string]string{"GO_VERSION": "1.19"}
+		cmd, err := containerize(ctx, config.Build{
+			Dir:     "sub",
+			BuildIn: "golang:{{ .Env.GO_VERSION }}",
+		}, command, env)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"docker", "run", "--rm",
+			"-v", folder + ":" + folder,
+			"-w", filepath.Join(folder, "sub"),
+			"--user", user, "-e", "HOME=/tmp",
+			"-e", "CGO_ENABLED",
+			"-e", "GOOS",
+			"-e", "GOPRIVATE",
+			"golang:1.19",
+			"go", "build", "-o", "dist/foo", ".",
+		}, cmd)
+	})
+
+	t.Run("dist outside current dir", func(t *testing.T) {
+		dist := t.TempDir()
+		ctx := context.New(config.Project{Dist: dist})
+		cmd, err := containerize(ctx, config.Build{
+			BuildIn: "golang:1.19",
+		}, command, env)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"docker", "run", "--rm",
+			"-v", folder + ":" + folder,
+			"-v", dist + ":" + dist,
+			"-w", folder,
+			"--user", user, "-e", "HOME=/tmp",
+			"-e", "CGO_ENABLED",
+			"-e", "GOOS",
+			"-e", "GOPRIVATE",
+			"golang:1.19",
+			"go", "build", "-o", "dist/foo", ".",
+		}, cmd)
+	})
+
+	t.Run("invalid image template", func(t *testing.T) {
+		ctx := context.New(config.Project{Dist: "dist"})
+		_, err := containerize(ctx, config.Build{
+			BuildIn: "golang:{{ .Nope }",
+		}, command, env)
+		require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
+func TestBuildGoBuildLineInvalidLdflagsEntry(t *testing.T) {
+	ctx := context.New(config.Project{})
+	_, err := buildGoBuildLine(ctx, config.Build{
+		GoBinary: "go",
+		Command:  "build",
+		BuildDetails: config.BuildDetails{
+			Ldflags: []string{
+				"-s -w",
+				"-X main.version={{ .Version }}",
+				"-X main.commit={{ .Commit }",
+				"-X main.date={{ .Date }}",
+			},
+		},
+	}, api.Options{}, &artifact.Artifact{}, []string{})
+	require.EqualError(t, err, `invalid ldflags: -X main.commit={{ .Commit }: template: tmpl:1: unexpected "}" in operand`)
+}
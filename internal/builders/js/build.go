@@ -0,0 +1,155 @@
+package js
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Builder instances, one for each supported runtime.
+// nolint: gochecknoglobals
+var (
+	Node = &Builder{runtime: "node"}
+	Bun  = &Builder{runtime: "bun"}
+	Deno = &Builder{runtime: "deno"}
+)
+
+// nolint: gochecknoinits
+func init() {
+	api.Register("node", Node)
+	api.Register("bun", Bun)
+	api.Register("deno", Deno)
+}
+
+// Builder is a builder for JavaScript and TypeScript CLIs.
+type Builder struct {
+	runtime string
+}
+
+// WithDefaults sets the defaults for a js build and returns it.
+func (b *Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Tool == "" {
+		build.Tool = defaultTools[b.runtime]
+	}
+	if build.Command == "" {
+		build.Command = defaultCommands[b.runtime]
+	}
+	if build.Main == "" {
+		build.Main = defaultMains[b.runtime]
+	}
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+	build, err := common.WithDefaultTargets(build)
+	if err != nil {
+		return build, err
+	}
+	for _, target := range build.Targets {
+		if _, err := b.Target(target); err != nil {
+			return build, err
+		}
+	}
+	return build, nil
+}
+
+// Build builds a js build.
+func (b *Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t, err := b.Target(options.Target)
+	if err != nil {
+		return err
+	}
+
+	a := common.Artifact(build, options)
+
+	// the tool runs inside build.Dir, so the output path must be absolute.
+	output, err := filepath.Abs(options.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return err
+	}
+
+	env, err := common.Env(ctx, build, a)
+	if err != nil {
+		return err
+	}
+	cmd := []string{build.Tool}
+	if build.Command != "" {
+		cmd = append(cmd, build.Command)
+	}
+	switch b.runtime {
+	case "node":
+		cmd = append(cmd, "--targets", t, "--output", output)
+	case "bun":
+		cmd = append(cmd, "--compile", "--target="+t, "--outfile", output)
+	case "deno":
+		cmd = append(cmd, "--target", t, "--output", output)
+	}
+	flags, err := common.Flags(ctx, env, a, build.Flags)
+	if err != nil {
+		return err
+	}
+	cmd = append(cmd, flags...)
+	cmd = append(cmd, build.Main)
+
+	if err := common.Run(ctx, cmd, env, build.Dir); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
+
+// Target converts a goreleaser build target into the target format of the
+// builder runtime.
+func (b *Builder) Target(target string) (string, error) {
+	return common.Triple(b.runtime, targets[b.runtime], target)
+}
+
+// nolint: gochecknoglobals
+var (
+	defaultTools = map[string]string{
+		"node": "pkg",
+		"bun":  "bun",
+		"deno": "deno",
+	}
+	defaultCommands = map[string]string{
+		"bun":  "build",
+		"deno": "compile",
+	}
+	defaultMains = map[string]string{
+		"node": ".",
+		"bun":  "index.ts",
+		"deno": "main.ts",
+	}
+	targets = map[string]map[string]string{
+		"node": {
+			"darwin_amd64":  "latest-macos-x64",
+			"darwin_arm64":  "latest-macos-arm64",
+			"linux_amd64":   "latest-linux-x64",
+			"linux_arm64":   "latest-linux-arm64",
+			"windows_amd64": "latest-win-x64",
+			"windows_arm64": "latest-win-arm64",
+		},
+		"bun": {
+			"darwin_amd64":  "bun-darwin-x64",
+			"darwin_arm64":  "bun-darwin-arm64",
+			"linux_amd64":   "bun-linux-x64",
+			"linux_arm64":   "bun-linux-arm64",
+			"windows_amd64": "bun-windows-x64",
+		},
+		"deno": {
+			"darwin_amd64":  "x86_64-apple-darwin",
+			"darwin_arm64":  "aarch64-apple-darwin",
+			"linux_amd64":   "x86_64-unknown-linux-gnu",
+			"linux_arm64":   "aarch64-unknown-linux-gnu",
+			"windows_amd64": "x86_64-pc-windows-msvc",
+		},
+	}
+)
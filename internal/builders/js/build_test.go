@@ -0,0 +1,103 @@
+package js
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaults(t *testing.T) {
+	for builder, expected := range map[*Builder]config.Build{
+		Node: {Tool: "pkg", Main: "."},
+		Bun:  {Tool: "bun", Command: "build", Main: "index.ts"},
+		Deno: {Tool: "deno", Command: "compile", Main: "main.ts"},
+	} {
+		t.Run(builder.runtime, func(t *testing.T) {
+			build, err := builder.WithDefaults(config.Build{})
+			require.NoError(t, err)
+			require.Equal(t, expected.Tool, build.Tool)
+			require.Equal(t, expected.Command, build.Command)
+			require.Equal(t, expected.Main, build.Main)
+			require.Equal(t, ".", build.Dir)
+			require.ElementsMatch(t, []string{
+				"linux_amd64_v1",
+				"linux_arm64",
+				"darwin_amd64_v1",
+				"darwin_arm64",
+			}, build.Targets)
+		})
+	}
+
+	t.Run("unsupported target", func(t *testing.T) {
+		_, err := Bun.WithDefaults(config.Build{
+			Targets: []string{"windows_arm64"},
+		})
+		require.EqualError(t, err, "unsupported bun target: windows_arm64")
+	})
+}
+
+func TestBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	tool := testlib.FakeTool(t)
+
+	for builder, expected := range map[*Builder]string{
+		Node: "--targets latest-linux-arm64 --output %s --compress GZip .",
+		Bun:  "build --compile --target=bun-linux-arm64 --outfile %s --compress GZip index.ts",
+		Deno: "compile --target aarch64-unknown-linux-gnu --output %s --compress GZip main.ts",
+	} {
+		t.Run(builder.runtime, func(t *testing.T) {
+			dst := filepath.Join(folder, "dist", builder.runtime, "foo_linux_arm64", "foo")
+			ctx := context.New(config.Project{
+				Dist: filepath.Join(folder, "dist"),
+			})
+			build, err := builder.WithDefaults(config.Build{
+				ID:   "foo",
+				Tool: tool,
+				BuildDetails: config.BuildDetails{
+					Flags: []string{"--compress", "{{ .Env.COMPRESSION }}"},
+					Env: []string{
+						"COMPRESSION=GZip",
+						testlib.FakeToolOutputEnv + "=" + dst,
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, builder.Build(ctx, build, api.Options{
+				Target: "linux_arm64",
+				Name:   "foo",
+				Path:   dst,
+				Goos:   "linux",
+				Goarch: "arm64",
+			}))
+
+			bts, err := os.ReadFile(dst)
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf(expected, dst)+"\n", string(bts))
+
+			bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+			require.Len(t, bins, 1)
+			require.Equal(t, dst, bins[0].Path)
+			require.Equal(t, "foo", bins[0].ExtraOr(artifact.ExtraID, ""))
+		})
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		build, err := Deno.WithDefaults(config.Build{Tool: "false"})
+		require.NoError(t, err)
+		require.Error(t, Deno.Build(ctx, build, api.Options{
+			Target: "linux_amd64_v1",
+			Name:   "foo",
+			Path:   filepath.Join(folder, "dist", "foo"),
+		}))
+	})
+}
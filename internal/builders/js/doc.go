@@ -0,0 +1,3 @@
+// Package js provides Builder implementations for JavaScript and TypeScript
+// CLIs, compiling them into standalone binaries with node (pkg), bun or deno.
+package js
@@ -0,0 +1,83 @@
+package prebuilt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/builders/buildtarget"
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	"github.com/goreleaser/goreleaser/internal/gio"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Default builder instance.
+// nolint: gochecknoglobals
+var Default = &Builder{}
+
+// nolint: gochecknoinits
+func init() {
+	api.Register("prebuilt", Default)
+}
+
+// Builder is the prebuilt builder.
+type Builder struct{}
+
+var (
+	errMissingPath    = errors.New("prebuilt.path is required when using the prebuilt builder")
+	errMissingTargets = errors.New("prebuilt builder has no default targets, please set either goos and goarch or targets")
+)
+
+// WithDefaults sets the defaults for a prebuilt build and returns it.
+//
+// Unlike the go builder, there are no default goos, goarch and etc, as we
+// can't know which binaries were built.
+func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.PreBuilt.Path == "" {
+		return build, errMissingPath
+	}
+	if len(build.Targets) > 0 {
+		return build, nil
+	}
+	targets, err := buildtarget.List(build)
+	if err != nil {
+		return build, err
+	}
+	if len(targets) == 0 {
+		return build, errMissingTargets
+	}
+	build.Targets = targets
+	return build, nil
+}
+
+// Build imports the prebuilt binary for the given target.
+func (*Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	path, err := tmpl.New(ctx).
+		WithBuildOptions(options).
+		Apply(build.PreBuilt.Path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to import prebuilt binary for %s: %w", options.Target, err)
+	}
+
+	log.WithField("path", path).
+		WithField("target", options.Target).
+		Debug("importing prebuilt binary")
+
+	if err := os.MkdirAll(filepath.Dir(options.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to import prebuilt binary for %s: %w", options.Target, err)
+	}
+	if err := gio.Copy(path, options.Path); err != nil {
+		return fmt.Errorf("failed to import prebuilt binary for %s: %w", options.Target, err)
+	}
+
+	ctx.Artifacts.Add(common.Artifact(build, options))
+	return nil
+}
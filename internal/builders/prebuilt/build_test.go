@@ -0,0 +1,132 @@
+package prebuilt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("matrix", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Goos:    []string{"linux", "darwin"},
+			Goarch:  []string{"amd64", "arm64"},
+			Goamd64: []string{"v1"},
+			PreBuilt: config.PreBuiltOptions{
+				Path: "./bin/{{ .Os }}_{{ .Arch }}/foo",
+			},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"linux_amd64_v1",
+			"linux_arm64",
+			"darwin_amd64_v1",
+			"darwin_arm64",
+		}, build.Targets)
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			Targets: []string{"linux_amd64_v1"},
+			PreBuilt: config.PreBuiltOptions{
+				Path: "./bin/foo",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"linux_amd64_v1"}, build.Targets)
+	})
+
+	t.Run("no path", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"linux_amd64_v1"},
+		})
+		require.ErrorIs(t, err, errMissingPath)
+	})
+
+	t.Run("no targets", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			PreBuilt: config.PreBuiltOptions{
+				Path: "./bin/foo",
+			},
+		})
+		require.ErrorIs(t, err, errMissingTargets)
+	})
+}
+
+func TestBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	src := filepath.Join(folder, "prebuilt", "linux_arm64", "foo")
+	require.NoError(t, os.MkdirAll(filepath.Dir(src), 0o755))
+	require.NoError(t, os.WriteFile(src, []byte("fake binary"), 0o755))
+
+	ctx := context.New(config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build := config.Build{
+		ID: "foo",
+		PreBuilt: config.PreBuiltOptions{
+			Path: filepath.Join(folder, "prebuilt", "{{ .Os }}_{{ .Arch }}", "foo"),
+		},
+	}
+	dst := filepath.Join(folder, "dist", "foo_linux_arm64", "foo")
+	require.NoError(t, Default.Build(ctx, build, api.Options{
+		Target: "linux_arm64",
+		Name:   "foo",
+		Path:   dst,
+		Goos:   "linux",
+		Goarch: "arm64",
+	}))
+
+	bts, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "fake binary", string(bts))
+
+	bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+	require.Len(t, bins, 1)
+	require.Equal(t, &artifact.Artifact{
+		Type:   artifact.Binary,
+		Name:   "foo",
+		Path:   dst,
+		Goos:   "linux",
+		Goarch: "arm64",
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "foo",
+			artifact.ExtraExt:    "",
+			artifact.ExtraID:     "foo",
+		},
+	}, bins[0])
+}
+
+func TestBuildMissingBinary(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := context.New(config.Project{})
+	err := Default.Build(ctx, config.Build{
+		PreBuilt: config.PreBuiltOptions{
+			Path: filepath.Join(folder, "{{ .Os }}", "foo"),
+		},
+	}, api.Options{
+		Target: "linux_amd64_v1",
+		Path:   filepath.Join(folder, "dist", "foo"),
+		Goos:   "linux",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to import prebuilt binary for linux_amd64_v1")
+	require.Empty(t, ctx.Artifacts.List())
+}
+
+func TestBuildInvalidTemplate(t *testing.T) {
+	ctx := context.New(config.Project{})
+	err := Default.Build(ctx, config.Build{
+		PreBuilt: config.PreBuiltOptions{
+			Path: "{{ .Os }",
+		},
+	}, api.Options{})
+	require.EqualError(t, err, `template: tmpl:1: unexpected "}" in operand`)
+}
@@ -0,0 +1,3 @@
+// Package prebuilt provides a Builder implementation that imports binaries
+// that were built elsewhere.
+package prebuilt
@@ -0,0 +1,115 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Default builder instance.
+// nolint: gochecknoglobals
+var Default = &Builder{}
+
+// nolint: gochecknoinits
+func init() {
+	api.Register("python", Default)
+}
+
+// Builder is python builder.
+type Builder struct{}
+
+// WithDefaults sets the defaults for a python build and returns it.
+func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Tool == "" {
+		build.Tool = "pyinstaller"
+	}
+	if build.Main == "" {
+		build.Main = "main.py"
+	}
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+	if len(build.Targets) == 0 {
+		build.Targets = []string{hostTarget()}
+	}
+	for _, target := range build.Targets {
+		if err := checkTarget(target); err != nil {
+			return build, err
+		}
+	}
+	return build, nil
+}
+
+// Build builds a python build.
+func (*Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	if err := checkTarget(options.Target); err != nil {
+		return err
+	}
+
+	a := common.Artifact(build, options)
+
+	// pyinstaller runs inside build.Dir, so all paths must be absolute.
+	output, err := filepath.Abs(options.Path)
+	if err != nil {
+		return err
+	}
+	work, err := filepath.Abs(filepath.Join(ctx.Config.Dist, "pyinstaller", build.ID+"_"+options.Target))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(work, 0o755); err != nil {
+		return err
+	}
+
+	env, err := common.Env(ctx, build, a)
+	if err != nil {
+		return err
+	}
+	cmd := append([]string{build.Tool}, strings.Fields(build.Command)...)
+	cmd = append(
+		cmd,
+		"--onefile",
+		"--name", strings.TrimSuffix(filepath.Base(output), options.Ext),
+		"--distpath", filepath.Dir(output),
+		"--workpath", work,
+		"--specpath", work,
+	)
+	flags, err := common.Flags(ctx, env, a, build.Flags)
+	if err != nil {
+		return err
+	}
+	cmd = append(cmd, flags...)
+	cmd = append(cmd, build.Main)
+
+	if err := common.Run(ctx, cmd, env, build.Dir); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
+
+func hostTarget() string {
+	target := runtime.GOOS + "_" + runtime.GOARCH
+	if runtime.GOARCH == "amd64" {
+		target += "_v1"
+	}
+	return target
+}
+
+// checkTarget errors if the given target is not the host platform, as
+// pyinstaller can't cross compile.
+func checkTarget(target string) error {
+	parts := strings.Split(target, "_")
+	if len(parts) < 2 || parts[0] != runtime.GOOS || parts[1] != runtime.GOARCH {
+		return fmt.Errorf("unsupported python target: %s: pyinstaller can only build for the host platform (%s)", target, hostTarget())
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{})
+		require.NoError(t, err)
+		require.Equal(t, "pyinstaller", build.Tool)
+		require.Equal(t, "main.py", build.Main)
+		require.Equal(t, ".", build.Dir)
+		require.Equal(t, []string{hostTarget()}, build.Targets)
+	})
+
+	t.Run("cross compiling", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"plan9_amd64_v1"},
+		})
+		require.EqualError(t, err, fmt.Sprintf(
+			"unsupported python target: plan9_amd64_v1: pyinstaller can only build for the host platform (%s)",
+			hostTarget(),
+		))
+	})
+}
+
+func TestBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	target := hostTarget()
+	dir := filepath.Join(folder, "dist", "foo_"+target)
+	dst := filepath.Join(dir, "foo")
+
+	ctx := context.New(config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build, err := Default.WithDefaults(config.Build{
+		ID:      "foo",
+		Tool:    testlib.FakeTool(t),
+		Command: "run pyinstaller",
+		BuildDetails: config.BuildDetails{
+			Flags: []string{"--log-level={{ .Env.LEVEL }}"},
+			Env: []string{
+				"LEVEL=WARN",
+				testlib.FakeToolOutputEnv + "=" + dst,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, Default.Build(ctx, build, api.Options{
+		Target: target,
+		Name:   "foo",
+		Path:   dst,
+		Goos:   runtime.GOOS,
+		Goarch: runtime.GOARCH,
+	}))
+
+	work := filepath.Join(folder, "dist", "pyinstaller", "foo_"+target)
+	bts, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(
+		"run pyinstaller --onefile --name foo --distpath %s --workpath %s --specpath %s --log-level=WARN main.py\n",
+		dir, work, work,
+	), string(bts))
+
+	bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+	require.Len(t, bins, 1)
+	require.Equal(t, dst, bins[0].Path)
+}
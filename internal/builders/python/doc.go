@@ -0,0 +1,3 @@
+// Package python provides a Builder implementation for python CLIs, using
+// PyInstaller.
+package python
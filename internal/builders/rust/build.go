@@ -0,0 +1,117 @@
+package rust
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	"github.com/goreleaser/goreleaser/internal/gio"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Default builder instance.
+// nolint: gochecknoglobals
+var Default = &Builder{}
+
+// nolint: gochecknoinits
+func init() {
+	api.Register("rust", Default)
+}
+
+// Builder is rust builder.
+type Builder struct{}
+
+// WithDefaults sets the defaults for a rust build and returns it.
+func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Tool == "" {
+		build.Tool = "cargo"
+	}
+	if build.Command == "" {
+		build.Command = "build"
+	}
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+	if len(build.Flags) == 0 {
+		build.Flags = []string{"--release"}
+	}
+	build, err := common.WithDefaultTargets(build)
+	if err != nil {
+		return build, err
+	}
+	for _, target := range build.Targets {
+		if _, err := triple(target); err != nil {
+			return build, err
+		}
+	}
+	return build, nil
+}
+
+// Build builds a rust build.
+func (*Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t, err := triple(options.Target)
+	if err != nil {
+		return err
+	}
+
+	a := common.Artifact(build, options)
+
+	env, err := common.Env(ctx, build, a)
+	if err != nil {
+		return err
+	}
+	cmd := []string{build.Tool, build.Command, "--target=" + t}
+	flags, err := common.Flags(ctx, env, a, build.Flags)
+	if err != nil {
+		return err
+	}
+	cmd = append(cmd, flags...)
+
+	if err := common.Run(ctx, cmd, env, build.Dir); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+
+	profile := "debug"
+	for _, flag := range cmd {
+		if flag == "--release" || flag == "-r" {
+			profile = "release"
+		}
+	}
+	bin := filepath.Join(build.Dir, "target", t, profile, filepath.Base(options.Path))
+	if err := os.MkdirAll(filepath.Dir(options.Path), 0o755); err != nil {
+		return err
+	}
+	if err := gio.Copy(bin, options.Path); err != nil {
+		return fmt.Errorf("failed to copy %s binary: %w", options.Target, err)
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
+
+// triple converts a goreleaser build target into a rust target triple.
+func triple(target string) (string, error) {
+	return common.Triple("rust", triples, target)
+}
+
+// nolint: gochecknoglobals
+var triples = map[string]string{
+	"darwin_amd64":  "x86_64-apple-darwin",
+	"darwin_arm64":  "aarch64-apple-darwin",
+	"freebsd_amd64": "x86_64-unknown-freebsd",
+	"linux_386":     "i686-unknown-linux-gnu",
+	"linux_amd64":   "x86_64-unknown-linux-gnu",
+	"linux_arm64":   "aarch64-unknown-linux-gnu",
+	"linux_arm_6":   "arm-unknown-linux-gnueabihf",
+	"linux_arm_7":   "armv7-unknown-linux-gnueabihf",
+	"linux_ppc64le": "powerpc64le-unknown-linux-gnu",
+	"linux_riscv64": "riscv64gc-unknown-linux-gnu",
+	"linux_s390x":   "s390x-unknown-linux-gnu",
+	"netbsd_amd64":  "x86_64-unknown-netbsd",
+	"windows_386":   "i686-pc-windows-gnu",
+	"windows_amd64": "x86_64-pc-windows-gnu",
+	"windows_arm64": "aarch64-pc-windows-msvc",
+}
@@ -0,0 +1,110 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{})
+		require.NoError(t, err)
+		require.Equal(t, "cargo", build.Tool)
+		require.Equal(t, "build", build.Command)
+		require.Equal(t, ".", build.Dir)
+		require.Equal(t, config.FlagArray{"--release"}, build.Flags)
+		require.ElementsMatch(t, []string{
+			"linux_amd64_v1",
+			"linux_arm64",
+			"darwin_amd64_v1",
+			"darwin_arm64",
+		}, build.Targets)
+	})
+
+	t.Run("unsupported target", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"plan9_amd64_v1"},
+		})
+		require.EqualError(t, err, "unsupported rust target: plan9_amd64_v1")
+	})
+}
+
+func TestTriple(t *testing.T) {
+	for target, expected := range map[string]string{
+		"linux_amd64_v1":   "x86_64-unknown-linux-gnu",
+		"linux_arm_7":      "armv7-unknown-linux-gnueabihf",
+		"darwin_arm64":     "aarch64-apple-darwin",
+		"windows_amd64_v3": "x86_64-pc-windows-gnu",
+	} {
+		t.Run(target, func(t *testing.T) {
+			got, err := triple(target)
+			require.NoError(t, err)
+			require.Equal(t, expected, got)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := triple("linux")
+		require.EqualError(t, err, "linux is not a valid build target")
+	})
+}
+
+func TestBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := context.New(config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build, err := Default.WithDefaults(config.Build{
+		ID:   "foo",
+		Tool: testlib.FakeTool(t),
+		BuildDetails: config.BuildDetails{
+			Flags: []string{"--release", "--features={{ .Os }}"},
+			Env: []string{
+				"PROFILE=release",
+				testlib.FakeToolOutputEnv + "=target/aarch64-unknown-linux-gnu/{{ .Env.PROFILE }}/foo",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	dst := filepath.Join(folder, "dist", "foo_linux_arm64", "foo")
+	require.NoError(t, Default.Build(ctx, build, api.Options{
+		Target: "linux_arm64",
+		Name:   "foo",
+		Path:   dst,
+		Goos:   "linux",
+		Goarch: "arm64",
+	}))
+
+	bts, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "build --target=aarch64-unknown-linux-gnu --release --features=linux\n", string(bts))
+
+	bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+	require.Len(t, bins, 1)
+	require.Equal(t, dst, bins[0].Path)
+	require.Equal(t, "foo", bins[0].ExtraOr(artifact.ExtraID, ""))
+}
+
+func TestBuildFailed(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := context.New(config.Project{})
+	err := Default.Build(ctx, config.Build{
+		Tool:    "false",
+		Command: "build",
+		Dir:     ".",
+	}, api.Options{
+		Target: "linux_amd64_v1",
+		Path:   filepath.Join(folder, "dist", "foo"),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to build for linux_amd64_v1")
+}
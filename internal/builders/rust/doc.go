@@ -0,0 +1,2 @@
+// Package rust provides a Builder implementation for rust, using cargo.
+package rust
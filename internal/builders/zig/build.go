@@ -0,0 +1,123 @@
+package zig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	"github.com/goreleaser/goreleaser/internal/gio"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Default builder instance.
+// nolint: gochecknoglobals
+var Default = &Builder{}
+
+// nolint: gochecknoinits
+func init() {
+	api.Register("zig", Default)
+}
+
+// Builder is zig builder.
+type Builder struct{}
+
+// WithDefaults sets the defaults for a zig build and returns it.
+func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Tool == "" {
+		build.Tool = "zig"
+	}
+	if build.Command == "" {
+		build.Command = "build"
+	}
+	if build.Dir == "" {
+		build.Dir = "."
+	}
+	if len(build.Flags) == 0 {
+		build.Flags = []string{"-Doptimize=ReleaseSafe"}
+	}
+	build, err := common.WithDefaultTargets(build)
+	if err != nil {
+		return build, err
+	}
+	for _, target := range build.Targets {
+		if _, err := Triple(target); err != nil {
+			return build, err
+		}
+	}
+	return build, nil
+}
+
+// Build builds a zig build.
+func (*Builder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	t, err := Triple(options.Target)
+	if err != nil {
+		return err
+	}
+
+	a := common.Artifact(build, options)
+
+	env, err := common.Env(ctx, build, a)
+	if err != nil {
+		return err
+	}
+	cmd := []string{build.Tool, build.Command, "-Dtarget=" + t}
+	flags, err := common.Flags(ctx, env, a, build.Flags)
+	if err != nil {
+		return err
+	}
+	cmd = append(cmd, flags...)
+
+	if err := common.Run(ctx, cmd, env, build.Dir); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+
+	bin := filepath.Join(build.Dir, "zig-out", "bin", filepath.Base(options.Path))
+	if err := os.MkdirAll(filepath.Dir(options.Path), 0o755); err != nil {
+		return err
+	}
+	if err := gio.Copy(bin, options.Path); err != nil {
+		return fmt.Errorf("failed to copy %s binary: %w", options.Target, err)
+	}
+
+	ctx.Artifacts.Add(a)
+	return nil
+}
+
+// CCEnv returns the CC and CXX environment variables needed to use
+// `zig cc` as the C and C++ compilers for the given build target.
+func CCEnv(target string) ([]string, error) {
+	t, err := Triple(target)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		"CC=zig cc -target " + t,
+		"CXX=zig c++ -target " + t,
+	}, nil
+}
+
+// Triple converts a goreleaser build target into a zig target triple.
+func Triple(target string) (string, error) {
+	return common.Triple("zig", triples, target)
+}
+
+// nolint: gochecknoglobals
+var triples = map[string]string{
+	"darwin_amd64":  "x86_64-macos",
+	"darwin_arm64":  "aarch64-macos",
+	"freebsd_amd64": "x86_64-freebsd",
+	"linux_386":     "x86-linux-gnu",
+	"linux_amd64":   "x86_64-linux-gnu",
+	"linux_arm64":   "aarch64-linux-gnu",
+	"linux_arm_6":   "arm-linux-gnueabihf",
+	"linux_arm_7":   "arm-linux-gnueabihf",
+	"linux_ppc64le": "powerpc64le-linux-gnu",
+	"linux_riscv64": "riscv64-linux-gnu",
+	"linux_s390x":   "s390x-linux-gnu",
+	"windows_386":   "x86-windows-gnu",
+	"windows_amd64": "x86_64-windows-gnu",
+	"windows_arm64": "aarch64-windows-gnu",
+}
@@ -0,0 +1,82 @@
+package zig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaults(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{})
+		require.NoError(t, err)
+		require.Equal(t, "zig", build.Tool)
+		require.Equal(t, "build", build.Command)
+		require.Equal(t, ".", build.Dir)
+		require.Equal(t, config.FlagArray{"-Doptimize=ReleaseSafe"}, build.Flags)
+		require.ElementsMatch(t, []string{
+			"linux_amd64_v1",
+			"linux_arm64",
+			"darwin_amd64_v1",
+			"darwin_arm64",
+		}, build.Targets)
+	})
+
+	t.Run("unsupported target", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			Targets: []string{"plan9_amd64_v1"},
+		})
+		require.EqualError(t, err, "unsupported zig target: plan9_amd64_v1")
+	})
+}
+
+func TestCCEnv(t *testing.T) {
+	env, err := CCEnv("linux_arm64")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"CC=zig cc -target aarch64-linux-gnu",
+		"CXX=zig c++ -target aarch64-linux-gnu",
+	}, env)
+
+	_, err = CCEnv("js_wasm")
+	require.EqualError(t, err, "unsupported zig target: js_wasm")
+}
+
+func TestBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := context.New(config.Project{
+		Dist: filepath.Join(folder, "dist"),
+	})
+	build, err := Default.WithDefaults(config.Build{
+		ID:   "foo",
+		Tool: testlib.FakeTool(t),
+		BuildDetails: config.BuildDetails{
+			Env: []string{testlib.FakeToolOutputEnv + "=zig-out/bin/foo"},
+		},
+	})
+	require.NoError(t, err)
+
+	dst := filepath.Join(folder, "dist", "foo_darwin_arm64", "foo")
+	require.NoError(t, Default.Build(ctx, build, api.Options{
+		Target: "darwin_arm64",
+		Name:   "foo",
+		Path:   dst,
+		Goos:   "darwin",
+		Goarch: "arm64",
+	}))
+
+	bts, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "build -Dtarget=aarch64-macos -Doptimize=ReleaseSafe\n", string(bts))
+
+	bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+	require.Len(t, bins, 1)
+	require.Equal(t, dst, bins[0].Path)
+}
@@ -0,0 +1,3 @@
+// Package zig provides a Builder implementation for zig, as well as helpers
+// to use `zig cc` as a C cross compiler for cgo builds.
+package zig
@@ -97,6 +97,19 @@ func truncateReleaseBody(body string) string {
 	return body
 }
 
+// targetCommitish applies the release.target_commitish template, returning
+// the given fallback if it is empty.
+func targetCommitish(ctx *context.Context, fallback string) (string, error) {
+	target, err := tmpl.New(ctx).Apply(ctx.Config.Release.TargetCommitish)
+	if err != nil {
+		return "", fmt.Errorf("templating release target_commitish: %w", err)
+	}
+	if target == "" {
+		return fallback, nil
+	}
+	return target, nil
+}
+
 // ErrNoMilestoneFound is an error when no milestone is found.
 type ErrNoMilestoneFound struct {
 	Title string
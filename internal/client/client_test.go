@@ -175,3 +175,34 @@ func TestClientBlanks(t *testing.T) {
 	repo := Repo{}
 	require.Equal(t, "", repo.String())
 }
+
+func TestTargetCommitish(t *testing.T) {
+	t.Run("fallback", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		target, err := targetCommitish(ctx, "abc")
+		require.NoError(t, err)
+		require.Equal(t, "abc", target)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				TargetCommitish: "{{ .Env.BRANCH }}",
+			},
+		})
+		ctx.Env["BRANCH"] = "release-1.x"
+		target, err := targetCommitish(ctx, "abc")
+		require.NoError(t, err)
+		require.Equal(t, "release-1.x", target)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				TargetCommitish: "{{ .Nope }",
+			},
+		})
+		_, err := targetCommitish(ctx, "abc")
+		require.EqualError(t, err, `templating release target_commitish: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
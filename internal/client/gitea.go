@@ -170,10 +170,14 @@ func (c *giteaClient) createRelease(ctx *context.Context, title, body string) (*
 	owner := releaseConfig.Gitea.Owner
 	repoName := releaseConfig.Gitea.Name
 	tag := ctx.Git.CurrentTag
+	target, err := targetCommitish(ctx, ctx.Git.Commit)
+	if err != nil {
+		return nil, err
+	}
 
 	opts := gitea.CreateReleaseOption{
 		TagName:      tag,
-		Target:       ctx.Git.Commit,
+		Target:       target,
 		Title:        title,
 		Note:         body,
 		IsDraft:      releaseConfig.Draft,
@@ -210,10 +214,14 @@ func (c *giteaClient) updateRelease(ctx *context.Context, title, body string, id
 	owner := releaseConfig.Gitea.Owner
 	repoName := releaseConfig.Gitea.Name
 	tag := ctx.Git.CurrentTag
+	target, err := targetCommitish(ctx, ctx.Git.Commit)
+	if err != nil {
+		return nil, err
+	}
 
 	opts := gitea.EditReleaseOption{
 		TagName:      tag,
-		Target:       ctx.Git.Commit,
+		Target:       target,
 		Title:        title,
 		Note:         body,
 		IsDraft:      &releaseConfig.Draft,
@@ -241,7 +249,7 @@ func (c *giteaClient) CreateRelease(ctx *context.Context, body string) (string,
 
 	title, err := tmpl.New(ctx).Apply(releaseConfig.NameTemplate)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("templating release name: %w", err)
 	}
 
 	release, err = c.getExistingRelease(
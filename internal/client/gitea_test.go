@@ -339,7 +339,7 @@ func (s *GiteaCreateReleaseSuite) TestTemplateError() {
 
 	releaseID, err := s.client.CreateRelease(s.ctx, s.description)
 	require.Empty(t, releaseID)
-	require.Error(t, err)
+	require.ErrorContains(t, err, "templating release name: ")
 }
 
 func (s *GiteaCreateReleaseSuite) TestErrorGettingExisitngRelease() {
@@ -204,7 +204,7 @@ func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string,
 	var release *github.RepositoryRelease
 	title, err := tmpl.New(ctx).Apply(ctx.Config.Release.NameTemplate)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("templating release name: %w", err)
 	}
 
 	// Truncate the release notes if it's too long (github doesn't allow more than 125000 characters)
@@ -217,8 +217,19 @@ func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string,
 		Draft:      github.Bool(ctx.Config.Release.Draft),
 		Prerelease: github.Bool(ctx.PreRelease),
 	}
-	if ctx.Config.Release.DiscussionCategoryName != "" {
-		data.DiscussionCategoryName = github.String(ctx.Config.Release.DiscussionCategoryName)
+	category, err := tmpl.New(ctx).Apply(ctx.Config.Release.DiscussionCategoryName)
+	if err != nil {
+		return "", fmt.Errorf("templating release discussion_category_name: %w", err)
+	}
+	if category != "" {
+		data.DiscussionCategoryName = github.String(category)
+	}
+	target, err := targetCommitish(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	if target != "" {
+		data.TargetCommitish = github.String(target)
 	}
 
 	release, _, err = c.client.Repositories.GetReleaseByTag(
@@ -228,6 +239,11 @@ func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string,
 		data.GetTagName(),
 	)
 	if err != nil {
+		if ctx.Config.Release.UseGitHubReleaseNotes {
+			// GitHub appends the generated notes to the body, only when the
+			// release is created.
+			data.GenerateReleaseNotes = github.Bool(true)
+		}
 		release, _, err = c.client.Repositories.CreateRelease(
 			ctx,
 			ctx.Config.Release.GitHub.Owner,
@@ -245,11 +261,15 @@ func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string,
 		)
 	}
 	if err != nil {
-		log.WithField("url", release.GetHTMLURL()).Info("release updated")
+		return "", err
 	}
+	log.WithFields(log.Fields{
+		"name": release.GetName(),
+		"url":  release.GetHTMLURL(),
+	}).Info("release updated")
 
 	githubReleaseID := strconv.FormatInt(release.GetID(), 10)
-	return githubReleaseID, err
+	return githubReleaseID, nil
 }
 
 func (c *githubClient) ReleaseURLTemplate(ctx *context.Context) (string, error) {
@@ -290,11 +310,56 @@ func (c *githubClient) Upload(
 		return nil
 	}
 	if resp != nil && resp.StatusCode == 422 {
-		return err
+		if !ctx.Config.Release.ReplaceExistingArtifacts {
+			return err
+		}
+		deleted, derr := c.deleteReleaseAsset(ctx, githubReleaseID, artifact.Name)
+		if derr != nil {
+			return derr
+		}
+		if !deleted {
+			return err
+		}
+		return RetriableError{err}
 	}
 	return RetriableError{err}
 }
 
+// deleteReleaseAsset deletes the asset with the given name from the release,
+// returning whether it existed.
+func (c *githubClient) deleteReleaseAsset(ctx *context.Context, releaseID int64, name string) (bool, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		assets, resp, err := c.client.Repositories.ListReleaseAssets(
+			ctx,
+			ctx.Config.Release.GitHub.Owner,
+			ctx.Config.Release.GitHub.Name,
+			releaseID,
+			opts,
+		)
+		if err != nil {
+			return false, err
+		}
+		for _, asset := range assets {
+			if asset.GetName() != name {
+				continue
+			}
+			log.WithField("name", name).Info("deleting existing release asset")
+			_, err := c.client.Repositories.DeleteReleaseAsset(
+				ctx,
+				ctx.Config.Release.GitHub.Owner,
+				ctx.Config.Release.GitHub.Name,
+				asset.GetID(),
+			)
+			return err == nil, err
+		}
+		if resp.NextPage == 0 {
+			return false, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 // getMilestoneByTitle returns a milestone by title.
 func (c *githubClient) getMilestoneByTitle(ctx *context.Context, repo Repo, title string) (*github.Milestone, error) {
 	// The GitHub API/SDK does not provide lookup by title functionality currently.
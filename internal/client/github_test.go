@@ -1,6 +1,8 @@
 package client
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -198,7 +200,219 @@ func TestGitHubCreateReleaseWrongNameTemplate(t *testing.T) {
 
 	str, err := client.CreateRelease(ctx, "")
 	require.Empty(t, str)
-	require.EqualError(t, err, `template: tmpl:1: unclosed action`)
+	require.EqualError(t, err, `templating release name: template: tmpl:1: unclosed action`)
+}
+
+func TestGitHubUploadReplaceExisting(t *testing.T) {
+	for name, replace := range map[string]bool{
+		"replace": true,
+		"fail":    false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var deleted bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/repos/someone/something/releases/1/assets":
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					fmt.Fprint(w, `{"message":"Validation Failed","errors":[{"resource":"ReleaseAsset","code":"already_exists","field":"name"}]}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/someone/something/releases/1/assets":
+					fmt.Fprint(w, `[{"id":2,"name":"other.tar.gz"},{"id":3,"name":"foo.tar.gz"}]`)
+				case r.Method == http.MethodDelete && r.URL.Path == "/repos/someone/something/releases/assets/3":
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			ctx := context.New(config.Project{
+				GitHubURLs: config.GitHubURLs{
+					API:    srv.URL + "/",
+					Upload: srv.URL + "/",
+				},
+				Release: config.Release{
+					GitHub: config.Repo{
+						Owner: "someone",
+						Name:  "something",
+					},
+					ReplaceExistingArtifacts: replace,
+				},
+			})
+			client, err := NewGitHub(ctx, "test-token")
+			require.NoError(t, err)
+
+			file, err := os.CreateTemp(t.TempDir(), "")
+			require.NoError(t, err)
+			defer file.Close()
+
+			err = client.Upload(ctx, "1", &artifact.Artifact{Name: "foo.tar.gz"}, file)
+			require.Error(t, err)
+			require.Equal(t, replace, errors.As(err, &RetriableError{}))
+			require.Equal(t, replace, deleted)
+		})
+	}
+}
+
+func TestGitHubCreateReleaseTargetCommitish(t *testing.T) {
+	var target interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+		target = data["target_commitish"]
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer srv.Close()
+
+	ctx := context.New(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "someone",
+				Name:  "something",
+			},
+			NameTemplate:    "{{ .Tag }}",
+			TargetCommitish: "{{ .Commit }}",
+		},
+	})
+	ctx.Git = context.GitInfo{
+		CurrentTag: "v1.0.0",
+		Commit:     "6d3fa5b",
+	}
+	client, err := NewGitHub(ctx, "test-token")
+	require.NoError(t, err)
+
+	id, err := client.CreateRelease(ctx, "body")
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+	require.Equal(t, "6d3fa5b", target)
+}
+
+func TestGitHubCreateReleaseDiscussionCategory(t *testing.T) {
+	for name, tt := range map[string]struct {
+		category string
+		expected interface{}
+	}{
+		"empty":    {"", nil},
+		"plain":    {"Announcements", "Announcements"},
+		"template": {"{{ .Env.CATEGORY }}", "Releases"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var category interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				data := map[string]interface{}{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+				category = data["discussion_category_name"]
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id":1}`)
+			}))
+			defer srv.Close()
+
+			ctx := context.New(config.Project{
+				GitHubURLs: config.GitHubURLs{
+					API: srv.URL + "/",
+				},
+				Release: config.Release{
+					GitHub: config.Repo{
+						Owner: "someone",
+						Name:  "something",
+					},
+					NameTemplate:           "{{ .Tag }}",
+					DiscussionCategoryName: tt.category,
+				},
+			})
+			ctx.Env["CATEGORY"] = "Releases"
+			ctx.Git.CurrentTag = "v1.0.0"
+			client, err := NewGitHub(ctx, "test-token")
+			require.NoError(t, err)
+
+			_, err = client.CreateRelease(ctx, "body")
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, category)
+		})
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				NameTemplate:           "{{ .Tag }}",
+				DiscussionCategoryName: "{{ .Nope }",
+			},
+		})
+		client, err := NewGitHub(ctx, "test-token")
+		require.NoError(t, err)
+		_, err = client.CreateRelease(ctx, "body")
+		require.EqualError(t, err, `templating release discussion_category_name: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
+func TestGitHubCreateReleaseUseGitHubReleaseNotes(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		exists   bool
+		enabled  bool
+		expected interface{}
+	}{
+		{"disabled", false, false, nil},
+		{"new release", false, true, true},
+		{"existing release", true, true, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var generate interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					if !tt.exists {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					fmt.Fprint(w, `{"id":1,"body":""}`)
+					return
+				}
+				data := map[string]interface{}{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+				generate = data["generate_release_notes"]
+				fmt.Fprint(w, `{"id":1}`)
+			}))
+			defer srv.Close()
+
+			ctx := context.New(config.Project{
+				GitHubURLs: config.GitHubURLs{
+					API: srv.URL + "/",
+				},
+				Release: config.Release{
+					GitHub: config.Repo{
+						Owner: "someone",
+						Name:  "something",
+					},
+					NameTemplate:          "{{ .Tag }}",
+					UseGitHubReleaseNotes: tt.enabled,
+				},
+			})
+			ctx.Git.CurrentTag = "v1.0.0"
+			client, err := NewGitHub(ctx, "test-token")
+			require.NoError(t, err)
+
+			_, err = client.CreateRelease(ctx, "body")
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, generate)
+		})
+	}
 }
 
 func TestGithubGetDefaultBranch(t *testing.T) {
@@ -257,7 +257,7 @@ func (c *gitlabClient) CreateFile(
 func (c *gitlabClient) CreateRelease(ctx *context.Context, body string) (releaseID string, err error) {
 	title, err := tmpl.New(ctx).Apply(ctx.Config.Release.NameTemplate)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("templating release name: %w", err)
 	}
 	gitlabName, err := tmpl.New(ctx).Apply(ctx.Config.Release.GitLab.Name)
 	if err != nil {
@@ -275,6 +275,10 @@ func (c *gitlabClient) CreateRelease(ctx *context.Context, body string) (release
 
 	name := title
 	tagName := ctx.Git.CurrentTag
+	ref, err := targetCommitish(ctx, ctx.Git.Commit)
+	if err != nil {
+		return "", err
+	}
 	release, resp, err := c.client.Releases.GetRelease(projectID, tagName)
 	if err != nil && (resp == nil || (resp.StatusCode != 403 && resp.StatusCode != 404)) {
 		return "", err
@@ -286,7 +290,6 @@ func (c *gitlabClient) CreateRelease(ctx *context.Context, body string) (release
 		}).Debug("get release")
 
 		description := body
-		ref := ctx.Git.Commit
 		gitURL := ctx.Git.URL
 
 		log.WithFields(log.Fields{
@@ -435,6 +438,12 @@ func (c *gitlabClient) Upload(
 	}).Debug("uploaded file")
 
 	name := artifact.Name
+	if ctx.Config.Release.ReplaceExistingArtifacts {
+		if err := c.deleteReleaseLink(projectID, releaseID, name); err != nil {
+			return err
+		}
+	}
+
 	filename := "/" + name
 	releaseLink, _, err := c.client.ReleaseLinks.CreateReleaseLink(
 		projectID,
@@ -443,6 +452,7 @@ func (c *gitlabClient) Upload(
 			Name:     &name,
 			URL:      &linkURL,
 			FilePath: &filename,
+			LinkType: gitlab.LinkType(gitlabLinkType(artifact)),
 		})
 	if err != nil {
 		return RetriableError{err}
@@ -461,6 +471,46 @@ func (c *gitlabClient) Upload(
 	return nil
 }
 
+// deleteReleaseLink deletes the link with the given name from the release,
+// if any.
+func (c *gitlabClient) deleteReleaseLink(projectID, tagName, name string) error {
+	opts := &gitlab.ListReleaseLinksOptions{PerPage: 100}
+	for {
+		links, resp, err := c.client.ReleaseLinks.ListReleaseLinks(projectID, tagName, opts)
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			if link.Name != name {
+				continue
+			}
+			log.WithField("name", name).Info("deleting existing release link")
+			_, _, err := c.client.ReleaseLinks.DeleteReleaseLink(projectID, tagName, link.ID)
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// gitlabLinkType returns the type of the release link of the given artifact,
+// so GitLab can show the packages apart from the other files.
+func gitlabLinkType(a *artifact.Artifact) gitlab.LinkTypeValue {
+	switch a.Type {
+	case artifact.UploadableArchive,
+		artifact.UploadableBinary,
+		artifact.UploadableSourceArchive,
+		artifact.LinuxPackage:
+		return gitlab.PackageLinkType
+	case artifact.UploadableDockerImage:
+		return gitlab.ImageLinkType
+	default:
+		return gitlab.OtherLinkType
+	}
+}
+
 // getMilestoneByTitle returns a milestone by title.
 func (c *gitlabClient) getMilestoneByTitle(repo Repo, title string) (*gitlab.Milestone, error) {
 	opts := &gitlab.ListMilestonesOptions{
@@ -326,6 +326,41 @@ func TestGitLabCreateReleaseReleaseNotExists(t *testing.T) {
 	}
 }
 
+func TestGitLabCreateReleaseTargetCommitish(t *testing.T) {
+	var ref interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method == http.MethodGet && strings.Contains(r.URL.Path, "releases") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "{}")
+			return
+		}
+		if r.Method == http.MethodPost {
+			data := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			ref = data["ref"]
+		}
+		fmt.Fprint(w, "{}")
+	}))
+	defer srv.Close()
+
+	ctx := context.New(config.Project{
+		GitLabURLs: config.GitLabURLs{
+			API: srv.URL,
+		},
+		Release: config.Release{
+			TargetCommitish: "main",
+		},
+	})
+	ctx.Git.Commit = "6d3fa5b"
+	client, err := NewGitLab(ctx, "test-token")
+	require.NoError(t, err)
+
+	_, err = client.CreateRelease(ctx, "body")
+	require.NoError(t, err)
+	require.Equal(t, "main", ref)
+}
+
 func TestGitLabCreateReleaseUnkownHTTPError(t *testing.T) {
 	totalRequests := 0
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -546,3 +581,96 @@ func TestCloseMileston(t *testing.T) {
 	err = client.CloseMilestone(ctx, repo, "never-will-exist")
 	require.Error(t, err)
 }
+
+func TestGitLabUploadLinkType(t *testing.T) {
+	for typ, linkType := range map[artifact.Type]string{
+		artifact.UploadableArchive:     "package",
+		artifact.LinuxPackage:          "package",
+		artifact.UploadableDockerImage: "image",
+		artifact.Checksum:              "other",
+		artifact.Signature:             "other",
+	} {
+		t.Run(typ.String(), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer fmt.Fprint(w, "{}")
+				defer w.WriteHeader(http.StatusOK)
+				defer r.Body.Close()
+
+				if !strings.Contains(r.URL.Path, "assets/links") {
+					_, _ = io.Copy(io.Discard, r.Body)
+					return
+				}
+
+				reqBody := map[string]interface{}{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+				require.Equal(t, linkType, reqBody["link_type"])
+			}))
+			defer srv.Close()
+
+			ctx := context.New(config.Project{
+				ProjectName: "projectname",
+				Release: config.Release{
+					GitLab: config.Repo{
+						Owner: "test",
+						Name:  "test",
+					},
+				},
+				GitLabURLs: config.GitLabURLs{
+					API: srv.URL,
+				},
+			})
+			ctx.Version = "v1.0.0"
+
+			tmpFile, err := os.CreateTemp(t.TempDir(), "")
+			require.NoError(t, err)
+
+			client, err := NewGitLab(ctx, ctx.Token)
+			require.NoError(t, err)
+			require.NoError(t, client.Upload(ctx, "1234", &artifact.Artifact{Name: "test", Path: "some-path", Type: typ}, tmpFile))
+		})
+	}
+}
+
+func TestGitLabUploadReplaceExisting(t *testing.T) {
+	var deleted bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/releases/v1.0.0/assets/links"):
+			fmt.Fprint(w, `[{"id":2,"name":"other.tar.gz"},{"id":3,"name":"foo.tar.gz"}]`)
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/releases/v1.0.0/assets/links/3"):
+			deleted = true
+			fmt.Fprint(w, `{"id":3,"name":"foo.tar.gz"}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/releases/v1.0.0/assets/links"):
+			require.True(t, deleted, "link must be deleted before being created again")
+			fmt.Fprint(w, `{"id":4,"name":"foo.tar.gz"}`)
+		default:
+			_, _ = io.Copy(io.Discard, r.Body)
+			fmt.Fprint(w, "{}")
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.New(config.Project{
+		ProjectName: "projectname",
+		Release: config.Release{
+			GitLab: config.Repo{
+				Owner: "test",
+				Name:  "test",
+			},
+			ReplaceExistingArtifacts: true,
+		},
+		GitLabURLs: config.GitLabURLs{
+			API: srv.URL,
+		},
+	})
+	ctx.Version = "v1.0.0"
+
+	tmpFile, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+
+	client, err := NewGitLab(ctx, ctx.Token)
+	require.NoError(t, err)
+	require.NoError(t, client.Upload(ctx, "v1.0.0", &artifact.Artifact{Name: "foo.tar.gz", Path: "some-path"}, tmpFile))
+	require.True(t, deleted)
+}
@@ -112,6 +112,10 @@ func CheckConfig(ctx *context.Context, upload *config.Upload, kind string) error
 		return misconfigured(kind, upload, "no certificate could be added from the specified trusted_certificates configuration")
 	}
 
+	if (upload.ClientX509Cert == "") != (upload.ClientX509Key == "") {
+		return misconfigured(kind, upload, "'client_x509_cert' and 'client_x509_key' must be set together")
+	}
+
 	return nil
 }
 
@@ -306,25 +310,34 @@ func newUploadRequest(ctx *context.Context, method, target, username, secret str
 }
 
 func getHTTPClient(upload *config.Upload) (*h.Client, error) {
-	if upload.TrustedCerts == "" {
+	if upload.TrustedCerts == "" && upload.ClientX509Cert == "" {
 		return h.DefaultClient, nil
 	}
-	pool, err := x509.SystemCertPool()
-	if err != nil {
-		if runtime.GOOS == "windows" {
-			// on windows ignore errors until golang issues #16736 & #18609 get fixed
-			pool = x509.NewCertPool()
-		} else {
-			return nil, err
+	tlsConfig := &tls.Config{} // nolint: gosec
+	if upload.TrustedCerts != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			if runtime.GOOS == "windows" {
+				// on windows ignore errors until golang issues #16736 & #18609 get fixed
+				pool = x509.NewCertPool()
+			} else {
+				return nil, err
+			}
+		}
+		pool.AppendCertsFromPEM([]byte(upload.TrustedCerts)) // already validated certs checked by CheckConfig
+		tlsConfig.RootCAs = pool
+	}
+	if upload.ClientX509Cert != "" {
+		cert, err := tls.LoadX509KeyPair(upload.ClientX509Cert, upload.ClientX509Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
 		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
-	pool.AppendCertsFromPEM([]byte(upload.TrustedCerts)) // already validated certs checked by CheckConfig
 	return &h.Client{
 		Transport: &h.Transport{
-			Proxy: h.ProxyFromEnvironment,
-			TLSClientConfig: &tls.Config{ // nolint: gosec
-				RootCAs: pool,
-			},
+			Proxy:           h.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
 		},
 	}, nil
 }
@@ -2,10 +2,17 @@ package http
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	h "net/http"
 	"net/http/httptest"
 	"os"
@@ -13,6 +20,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/pkg/config"
@@ -101,6 +109,9 @@ func TestCheckConfig(t *testing.T) {
 		{"mode missing", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe"}, "test"}, true},
 		{"mode invalid", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: "blabla"}, "test"}, true},
 		{"cert invalid", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: ModeBinary, TrustedCerts: "bad cert!"}, "test"}, true},
+		{"client cert without key", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: ModeBinary, ClientX509Cert: "cert.pem"}, "test"}, true},
+		{"client key without cert", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: ModeBinary, ClientX509Key: "key.pem"}, "test"}, true},
+		{"client cert and key", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: ModeBinary, ClientX509Cert: "cert.pem", ClientX509Key: "key.pem"}, "test"}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -603,6 +614,91 @@ func TestUpload(t *testing.T) {
 	}
 }
 
+func TestUploadClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, clientCert := clientCertificate(t, dir)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCert)
+	srv := httptest.NewUnstartedServer(h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		w.WriteHeader(h.StatusCreated)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	file := filepath.Join(dir, "a.tar.gz")
+	require.NoError(t, os.WriteFile(file, []byte("content"), 0o644))
+	ctx := context.New(config.Project{ProjectName: "blah"})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "a.tar.gz",
+		Path: file,
+		Type: artifact.UploadableArchive,
+	})
+
+	upload := func(clientCert, clientKey string) error {
+		return Upload(ctx, []config.Upload{{
+			Name:           "a",
+			Method:         h.MethodPut,
+			Mode:           ModeArchive,
+			Target:         srv.URL + "/",
+			TrustedCerts:   cert(srv),
+			ClientX509Cert: clientCert,
+			ClientX509Key:  clientKey,
+		}}, "test", func(r *h.Response) error {
+			if r.StatusCode != h.StatusCreated {
+				return fmt.Errorf("unexpected http status code: %v", r.StatusCode)
+			}
+			return nil
+		})
+	}
+
+	t.Run("with client certificate", func(t *testing.T) {
+		require.NoError(t, upload(certPath, keyPath))
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		require.Error(t, upload("", ""))
+	})
+
+	t.Run("invalid client certificate", func(t *testing.T) {
+		require.ErrorContains(t, upload(keyPath, certPath), "failed to load client certificate: ")
+	})
+}
+
+// clientCertificate writes a self-signed client certificate and its key to
+// the given directory.
+func clientCertificate(tb testing.TB, dir string) (string, string, *x509.Certificate) {
+	tb.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(tb, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "goreleaser"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(tb, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(tb, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(tb, err)
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(tb, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(tb, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certPath, keyPath, cert
+}
+
 func cert(srv *httptest.Server) string {
 	if srv == nil || srv.Certificate() == nil {
 		return ""
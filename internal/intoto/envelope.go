@@ -0,0 +1,125 @@
+package intoto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/gio"
+	"github.com/goreleaser/goreleaser/internal/logext"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// PayloadType is the DSSE payload type of in-toto statements.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a signature of a DSSE envelope.
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// NewEnvelope wraps the given statement in a DSSE envelope, signed by the
+// given signer, if it has a command.
+func NewEnvelope(ctx *context.Context, statement Statement, signer config.AttestationSigner) (Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, err
+	}
+	env := Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{},
+	}
+	if signer.Cmd == "" {
+		return env, nil
+	}
+	sig, err := sign(ctx, signer, PAE(PayloadType, payload))
+	if err != nil {
+		return env, err
+	}
+	env.Signatures = append(env.Signatures, Signature{
+		KeyID: signer.KeyID,
+		Sig:   base64.StdEncoding.EncodeToString(sig),
+	})
+	return env, nil
+}
+
+// Statement decodes the statement in the envelope payload.
+func (e Envelope) Statement() (Statement, error) {
+	var st Statement
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return st, err
+	}
+	err = json.Unmarshal(payload, &st)
+	return st, err
+}
+
+// PAE is the DSSE pre-authentication encoding of the payload, which is what
+// actually gets signed.
+func PAE(typ string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(typ), typ, len(payload), payload))
+}
+
+// sign runs the signer command over the given data, returning the raw
+// signature it wrote.
+func sign(ctx *context.Context, signer config.AttestationSigner, data []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp(ctx.Config.Dist, "intoto")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	env := ctx.Env.Copy()
+	env["payload"] = filepath.Join(dir, "payload")
+	env["signature"] = filepath.Join(dir, "signature")
+	if err := os.WriteFile(env["payload"], data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write payload: %w", err)
+	}
+
+	// nolint:prealloc
+	var args []string
+	for _, a := range signer.Args {
+		arg, err := tmpl.New(ctx).WithEnv(env).Apply(os.Expand(a, func(k string) string { return env[k] }))
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply signer args template: %w", err)
+		}
+		args = append(args, arg)
+	}
+
+	fields := log.Fields{"cmd": signer.Cmd}
+
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, signer.Cmd, args...)
+	var b bytes.Buffer
+	w := gio.Safe(&b)
+	cmd.Stderr = io.MultiWriter(logext.NewWriter(fields, logext.Error), w)
+	cmd.Stdout = io.MultiWriter(logext.NewWriter(fields, logext.Info), w)
+	cmd.Env = env.Strings()
+	log.WithFields(fields).Info("signing")
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", signer.Cmd, err, b.String())
+	}
+
+	sig, err := os.ReadFile(env["signature"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+	return sig, nil
+}
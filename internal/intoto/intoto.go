@@ -0,0 +1,76 @@
+// Package intoto has the in-toto statement types and helpers shared by the
+// provenance and attestation pipes.
+package intoto
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// StatementType is the type of the in-toto statements.
+const StatementType = "https://in-toto.io/Statement/v0.1"
+
+// Statement is an in-toto statement about the given subjects.
+type Statement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []Subject       `json:"subject"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// Subject is an artifact a statement is about.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Filter returns the filter of the released artifacts statements can be
+// about, narrowed to the given ids, if any.
+func Filter(ids []string) artifact.Filter {
+	filter := artifact.Or(
+		artifact.ByType(artifact.UploadableArchive),
+		artifact.ByType(artifact.UploadableBinary),
+		artifact.ByType(artifact.UploadableSourceArchive),
+		artifact.ByType(artifact.LinuxPackage),
+		artifact.ByType(artifact.SBOM),
+		artifact.ByType(artifact.DebugSymbols),
+	)
+	if len(ids) > 0 {
+		filter = artifact.And(filter, artifact.ByIDs(ids...))
+	}
+	return filter
+}
+
+// SubjectsOf returns the subjects of the given artifacts, with their sha256
+// digests, sorted by name.
+func SubjectsOf(ctx *context.Context, artifacts []*artifact.Artifact) ([]Subject, error) {
+	g := semerrgroup.New(ctx.Parallelism)
+	subjects := make([]Subject, len(artifacts))
+	for i, a := range artifacts {
+		i := i
+		a := a
+		g.Go(func() error {
+			sum, err := a.Checksum("sha256")
+			if err != nil {
+				return err
+			}
+			subjects[i] = Subject{
+				Name:   a.Name,
+				Digest: map[string]string{"sha256": sum},
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	// sort to keep the statement deterministic
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].Name < subjects[j].Name
+	})
+	return subjects, nil
+}
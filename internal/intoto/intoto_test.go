@@ -0,0 +1,87 @@
+package intoto
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectsOf(t *testing.T) {
+	for name, tt := range map[string]struct {
+		ids   []string
+		names []string
+	}{
+		"all": {
+			names: []string{"foo_1.2.3_amd64.deb", "foo_linux_amd64.tar.gz", "foo_windows_amd64.zip"},
+		},
+		"filter by id": {
+			ids:   []string{"archives"},
+			names: []string{"foo_linux_amd64.tar.gz", "foo_windows_amd64.zip"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.New(config.Project{Dist: t.TempDir()})
+			testlib.AddReleaseArtifacts(t, ctx)
+
+			subjects, err := SubjectsOf(ctx, ctx.Artifacts.Filter(Filter(tt.ids)).List())
+			require.NoError(t, err)
+			var names []string
+			for _, s := range subjects {
+				names = append(names, s.Name)
+				require.Equal(t, map[string]string{"sha256": testlib.ReleaseArtifactsSum}, s.Digest)
+			}
+			require.Equal(t, tt.names, names)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		_, err := SubjectsOf(ctx, []*artifact.Artifact{{
+			Name: "nope.tar.gz",
+			Path: filepath.Join(t.TempDir(), "nope.tar.gz"),
+		}})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestNewEnvelope(t *testing.T) {
+	ctx := context.New(config.Project{Dist: t.TempDir()})
+	statement := Statement{
+		Type:          StatementType,
+		PredicateType: "https://example.com/test/v1",
+		Subject:       []Subject{{Name: "foo", Digest: map[string]string{"sha256": "abc"}}},
+		Predicate:     json.RawMessage(`{"foo":"bar"}`),
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		env, err := NewEnvelope(ctx, statement, config.AttestationSigner{})
+		require.NoError(t, err)
+		require.Equal(t, PayloadType, env.PayloadType)
+		require.Empty(t, env.Signatures)
+		st, err := env.Statement()
+		require.NoError(t, err)
+		require.Equal(t, statement, st)
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		env, err := NewEnvelope(ctx, statement, config.AttestationSigner{
+			Cmd:   "sh",
+			Args:  []string{"-c", "printf sig > ${signature}"},
+			KeyID: "my-key",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []Signature{{KeyID: "my-key", Sig: "c2ln"}}, env.Signatures)
+	})
+
+	t.Run("no signature written", func(t *testing.T) {
+		_, err := NewEnvelope(ctx, statement, config.AttestationSigner{Cmd: "true"})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
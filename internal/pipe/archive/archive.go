@@ -4,12 +4,16 @@
 package archive
 
 import (
+	stdctx "context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/goreleaser/goreleaser/internal/archivefiles"
@@ -23,11 +27,21 @@ import (
 )
 
 const (
-	defaultNameTemplateSuffix = `{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}`
+	defaultNameTemplateSuffix = `{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}{{ with .Arm64 }}_{{ . }}{{ end }}`
 	defaultNameTemplate       = "{{ .ProjectName }}_" + defaultNameTemplateSuffix
 	defaultBinaryNameTemplate = "{{ .Binary }}_" + defaultNameTemplateSuffix
 )
 
+// defaultFiles are the globs added to archives that have no files set.
+var defaultFiles = []string{
+	"license*",
+	"LICENSE*",
+	"readme*",
+	"README*",
+	"changelog*",
+	"CHANGELOG*",
+}
+
 // ErrArchiveDifferentBinaryCount happens when an archive uses several builds which have different goos/goarch/etc sets,
 // causing the archives for some platforms to have more binaries than others.
 // GoReleaser breaks in these cases as it will only cause confusion to other users.
@@ -57,14 +71,12 @@ func (Pipe) Default(ctx *context.Context) error {
 		if archive.ID == "" {
 			archive.ID = "default"
 		}
-		if len(archive.Files) == 0 {
-			archive.Files = []config.File{
-				{Source: "license*"},
-				{Source: "LICENSE*"},
-				{Source: "readme*"},
-				{Source: "README*"},
-				{Source: "changelog*"},
-				{Source: "CHANGELOG*"},
+		if len(archive.DefaultFiles) == 0 {
+			archive.DefaultFiles = defaultFiles
+		}
+		if len(archive.Files) == 0 && !archive.DisableDefaultFiles {
+			for _, glob := range archive.DefaultFiles {
+				archive.Files = append(archive.Files, config.File{Source: glob})
 			}
 		}
 		if archive.NameTemplate == "" {
@@ -73,6 +85,25 @@ func (Pipe) Default(ctx *context.Context) error {
 				archive.NameTemplate = defaultBinaryNameTemplate
 			}
 		}
+		if !validCompression(archive.Compression) {
+			return fmt.Errorf("invalid compression in archive %s: %s: should be store, fastest, default or best", archive.ID, archive.Compression)
+		}
+		if archive.Manifest.Enabled {
+			if archive.Manifest.Format == "" {
+				archive.Manifest.Format = "json"
+			}
+			if archive.Manifest.Format != "json" && archive.Manifest.Format != "yaml" {
+				return fmt.Errorf("invalid manifest format in archive %s: %s: should be json or yaml", archive.ID, archive.Manifest.Format)
+			}
+			if archive.Manifest.NameTemplate == "" {
+				archive.Manifest.NameTemplate = "manifest." + archive.Manifest.Format
+			}
+		}
+		for _, override := range archive.FormatOverrides {
+			if override.Goos == "" || (override.Format == "" && override.NameTemplate == "") {
+				return fmt.Errorf("invalid format override in archive %s: goos and either format or name_template are required", archive.ID)
+			}
+		}
 		ids.Inc(archive.ID)
 	}
 	return ids.Validate()
@@ -84,7 +115,10 @@ func (Pipe) Run(ctx *context.Context) error {
 	for i, archive := range ctx.Config.Archives {
 		archive := archive
 		if archive.Meta {
-			return createMeta(ctx, archive)
+			g.Go(func() error {
+				return createMeta(ctx, archive)
+			})
+			continue
 		}
 
 		filter := []artifact.Filter{artifact.Or(
@@ -95,17 +129,29 @@ func (Pipe) Run(ctx *context.Context) error {
 			filter = append(filter, artifact.ByIDs(archive.Builds...))
 		}
 		artifacts := ctx.Artifacts.Filter(artifact.And(filter...)).GroupByPlatform()
-		if err := checkArtifacts(artifacts); err != nil && !archive.AllowDifferentBinaryCount {
-			return fmt.Errorf("invalid archive: %d: %w", i, ErrArchiveDifferentBinaryCount)
+		if len(artifacts) == 0 {
+			log.WithField("id", archive.ID).Warn("no binaries matched, skipping archive")
+			continue
+		}
+		if err := checkArtifacts(artifacts); err != nil {
+			if !archive.AllowDifferentBinaryCount {
+				return fmt.Errorf("invalid archive: %d: %w", i, err)
+			}
+			log.WithField("id", archive.ID).Debug(err.Error())
 		}
 		for group, artifacts := range artifacts {
 			log.Debugf("group %s has %d binaries", group, len(artifacts))
 			artifacts := artifacts
+			archive := withNameTemplateOverride(archive, artifacts[0].Goos)
 			g.Go(func() error {
-				if packageFormat(archive, artifacts[0].Goos) == "binary" {
+				format, err := packageFormat(ctx, archive, artifacts[0])
+				if err != nil {
+					return err
+				}
+				if format == "binary" {
 					return skip(ctx, archive, artifacts)
 				}
-				return create(ctx, archive, artifacts)
+				return create(ctx, archive, artifacts, format)
 			})
 		}
 	}
@@ -120,16 +166,36 @@ func checkArtifacts(artifacts map[string][]*artifact.Artifact) error {
 	if len(lens) <= 1 {
 		return nil
 	}
-	return ErrArchiveDifferentBinaryCount
+	counts := make([]string, 0, len(artifacts))
+	for _, v := range artifacts {
+		counts = append(counts, fmt.Sprintf("%s has %d", platform(v[0]), len(v)))
+	}
+	sort.Strings(counts)
+	return fmt.Errorf("%w: %s", ErrArchiveDifferentBinaryCount, strings.Join(counts, ", "))
+}
+
+// platform returns a human readable description of the artifact's platform,
+// e.g. linux_arm_7.
+func platform(a *artifact.Artifact) string {
+	parts := []string{a.Goos, a.Goarch}
+	for _, p := range []string{a.Goarm, a.Gomips, a.Goamd64, a.Goarm64} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "_")
 }
 
 func createMeta(ctx *context.Context, arch config.Archive) error {
-	return doCreate(ctx, arch, nil, arch.Format, tmpl.New(ctx))
+	format, err := tmpl.New(ctx).Apply(arch.Format)
+	if err != nil {
+		return fmt.Errorf("invalid archive format template: %w", err)
+	}
+	return doCreate(ctx, arch, nil, format, tmpl.New(ctx))
 }
 
-func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Artifact) error {
+func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Artifact, format string) error {
 	template := tmpl.New(ctx).WithArtifact(binaries[0], arch.Replacements)
-	format := packageFormat(arch, binaries[0].Goos)
 	return doCreate(ctx, arch, binaries, format, template)
 }
 
@@ -159,38 +225,94 @@ func doCreate(ctx *context.Context, arch config.Archive, binaries []*artifact.Ar
 	log := log.WithField("archive", archivePath)
 	log.Info("creating")
 
+	// single file formats only ever hold the binary.
+	if isSingleFile(format) && !arch.Meta {
+		arch.Files = nil
+		arch.WrapInDirectory = ""
+		arch.Manifest.Enabled = false
+	}
+
 	wrap, err := template.Apply(wrapFolder(arch))
 	if err != nil {
 		return err
 	}
-	a, err := archive.New(archiveFile, format)
+	actx, cancel := stdctx.WithCancel(ctx)
+	a, err := newArchive(actx, archiveFile, format, arch)
 	if err != nil {
+		cancel()
 		return err
 	}
 	a = NewEnhancedArchive(a, wrap)
+	// the archive is closed explicitly once all files are added, this only
+	// cleans up on the error paths: the context is cancelled first, so
+	// formats that create the archive on close (7z) skip it.
 	defer a.Close()
+	defer cancel()
+	// binaries may be added to the root of the archive, bypassing the wrap
+	// directory, so tools that expect them there can find them.
+	root := a.(EnhancedArchive).withWrap("")
 
-	files, err := archivefiles.Eval(template, arch.Files)
+	files, err := archivefiles.Eval(template, arch.RLCP, arch.Files)
 	if err != nil {
 		return fmt.Errorf("failed to find files to archive: %w", err)
 	}
 	if arch.Meta && len(files) == 0 {
 		return fmt.Errorf("no files found")
 	}
-	for _, f := range files {
-		if err = a.Add(f); err != nil {
-			return fmt.Errorf("failed to add: '%s' -> '%s': %w", f.Source, f.Destination, err)
-		}
+	for i := range files {
+		files[i].Info = withDefaultInfo(files[i].Info, arch.FilesInfo)
 	}
 	bins := []string{}
+	atRoot := map[string]bool{}
 	for _, binary := range binaries {
-		if err := a.Add(config.File{
+		name, err := binaryName(ctx, arch, binary)
+		if err != nil {
+			return err
+		}
+		if arch.StripParentBinaryFolder {
+			name = filepath.Base(name)
+			atRoot[binary.Path] = true
+		}
+		files = append(files, config.File{
 			Source:      binary.Path,
-			Destination: binary.Name,
-		}); err != nil {
-			return fmt.Errorf("failed to add: '%s' -> '%s': %w", binary.Path, binary.Name, err)
+			Destination: name,
+			Info:        arch.BuildsInfo,
+		})
+		bins = append(bins, name)
+		for _, sig := range ctx.Artifacts.Filter(signaturesOf(binary)).List() {
+			dest := signatureName(name, sig)
+			if arch.StripParentBinaryFolder {
+				atRoot[sig.Path] = true
+			}
+			files = append(files, config.File{
+				Source:      sig.Path,
+				Destination: dest,
+				Info:        arch.FilesInfo,
+			})
+		}
+	}
+	if arch.Manifest.Enabled {
+		m, err := writeManifest(ctx, arch, template, files)
+		if err != nil {
+			return err
 		}
-		bins = append(bins, binary.Name)
+		defer os.Remove(m.Source)
+		files = append(files, m)
+	}
+	if arch.Reproducible {
+		files = reproducible(ctx, files)
+	}
+	for _, f := range files {
+		target := a
+		if atRoot[f.Source] {
+			target = root
+		}
+		if err = target.Add(f); err != nil {
+			return fmt.Errorf("failed to add: '%s' -> '%s': %w", f.Source, f.Destination, err)
+		}
+	}
+	if err := a.Close(); err != nil {
+		return fmt.Errorf("failed to close archive %s: %w", archivePath, err)
 	}
 	art := &artifact.Artifact{
 		Type: artifact.UploadableArchive,
@@ -199,17 +321,22 @@ func doCreate(ctx *context.Context, arch config.Archive, binaries []*artifact.Ar
 		Extra: map[string]interface{}{
 			artifact.ExtraBuilds:    binaries,
 			artifact.ExtraID:        arch.ID,
-			artifact.ExtraFormat:    arch.Format,
+			artifact.ExtraFormat:    format,
 			artifact.ExtraWrappedIn: wrap,
 			artifact.ExtraBinaries:  bins,
 		},
 	}
+	if arch.StripParentBinaryFolder {
+		// the binaries are not inside the wrap directory.
+		art.Extra[artifact.ExtraWrappedIn] = ""
+	}
 	if len(binaries) > 0 {
 		art.Goos = binaries[0].Goos
 		art.Goarch = binaries[0].Goarch
 		art.Goarm = binaries[0].Goarm
 		art.Gomips = binaries[0].Gomips
 		art.Goamd64 = binaries[0].Goamd64
+		art.Goarm64 = binaries[0].Goarm64
 		art.Extra[artifact.ExtraReplaces] = binaries[0].Extra[artifact.ExtraReplaces]
 	}
 
@@ -217,6 +344,106 @@ func doCreate(ctx *context.Context, arch config.Archive, binaries []*artifact.Ar
 	return nil
 }
 
+func validCompression(compression string) bool {
+	switch compression {
+	case "", archive.CompressionStore, archive.CompressionFastest, archive.CompressionDefault, archive.CompressionBest:
+		return true
+	}
+	return false
+}
+
+func newArchive(ctx stdctx.Context, w io.Writer, format string, arch config.Archive) (archive.Archive, error) {
+	return archive.NewContext(ctx, w, format, arch.Compression)
+}
+
+func isSingleFile(format string) bool {
+	return format == "gz" || format == "bz2"
+}
+
+// withDefaultInfo fills the unset fields of the given file info with the ones
+// from the defaults.
+func withDefaultInfo(info, defaults config.FileInfo) config.FileInfo {
+	if info.Owner == "" {
+		info.Owner = defaults.Owner
+	}
+	if info.Group == "" {
+		info.Group = defaults.Group
+	}
+	if info.Mode == 0 {
+		info.Mode = defaults.Mode
+	}
+	if info.MTime.IsZero() {
+		info.MTime = defaults.MTime
+	}
+	return info
+}
+
+// reproducible sorts the given files by destination and fills their unset
+// info fields with fixed values, so the same inputs always produce the same
+// archive: owner and group are set to root and the modification time to the
+// commit date.
+func reproducible(ctx *context.Context, files []config.File) []config.File {
+	defaults := config.FileInfo{
+		Owner: "root",
+		Group: "root",
+		MTime: reproducibleTime(ctx),
+	}
+	for i := range files {
+		files[i].Info = withDefaultInfo(files[i].Info, defaults)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Destination < files[j].Destination
+	})
+	return files
+}
+
+// reproducibleTime returns the time used in reproducible archives: the commit
+// date, or the current build date if there is none.
+func reproducibleTime(ctx *context.Context) time.Time {
+	if ctx.Git.CommitDate.IsZero() {
+		return ctx.Date.UTC()
+	}
+	return ctx.Git.CommitDate.UTC()
+}
+
+// binaryName returns the name the given binary should have inside the
+// archive, evaluating the archive's binary_name_template if set.
+func binaryName(ctx *context.Context, arch config.Archive, binary *artifact.Artifact) (string, error) {
+	if arch.BinaryNameTemplate == "" {
+		return binary.Name, nil
+	}
+	name, err := tmpl.New(ctx).
+		WithArtifact(binary, arch.Replacements).
+		Apply(arch.BinaryNameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid binary name template: %w", err)
+	}
+	return name + binary.ExtraOr(artifact.ExtraExt, "").(string), nil
+}
+
+// signaturesOf filters the signatures and certificates created for the given
+// binary by the binary_signs.
+func signaturesOf(binary *artifact.Artifact) artifact.Filter {
+	return artifact.And(
+		artifact.Or(
+			artifact.ByType(artifact.Signature),
+			artifact.ByType(artifact.Certificate),
+		),
+		func(a *artifact.Artifact) bool {
+			return a.ExtraOr(artifact.ExtraSigned, "") == binary.Path
+		},
+	)
+}
+
+// signatureName is the name of the signature inside the archive: the binary
+// name plus the signature extension, so it is found right next to it.
+func signatureName(binary string, sig *artifact.Artifact) string {
+	if ext := filepath.Ext(sig.Name); ext != "" {
+		return binary + ext
+	}
+	return filepath.Join(filepath.Dir(binary), sig.Name)
+}
+
 func wrapFolder(a config.Archive) string {
 	switch a.WrapInDirectory {
 	case "true":
@@ -249,10 +476,11 @@ func skip(ctx *context.Context, archive config.Archive, binaries []*artifact.Art
 			Goarm:   binary.Goarm,
 			Gomips:  binary.Gomips,
 			Goamd64: binary.Goamd64,
+			Goarm64: binary.Goarm64,
 			Extra: map[string]interface{}{
 				artifact.ExtraBuilds:   []*artifact.Artifact{binary},
 				artifact.ExtraID:       archive.ID,
-				artifact.ExtraFormat:   archive.Format,
+				artifact.ExtraFormat:   "binary",
 				artifact.ExtraBinary:   binary.Name,
 				artifact.ExtraReplaces: binaries[0].Extra[artifact.ExtraReplaces],
 			},
@@ -261,13 +489,34 @@ func skip(ctx *context.Context, archive config.Archive, binaries []*artifact.Art
 	return nil
 }
 
-func packageFormat(archive config.Archive, platform string) string {
+// withNameTemplateOverride returns the archive with the name template of the
+// first format override for the given goos that sets one, if any.
+func withNameTemplateOverride(archive config.Archive, goos string) config.Archive {
+	for _, override := range archive.FormatOverrides {
+		if goos == override.Goos && override.NameTemplate != "" {
+			archive.NameTemplate = override.NameTemplate
+			break
+		}
+	}
+	return archive
+}
+
+// packageFormat returns the format of the archive for the given binary,
+// applying the format overrides and evaluating the format template.
+func packageFormat(ctx *context.Context, archive config.Archive, binary *artifact.Artifact) (string, error) {
+	format := archive.Format
 	for _, override := range archive.FormatOverrides {
-		if strings.HasPrefix(platform, override.Goos) {
-			return override.Format
+		if binary.Goos == override.Goos && override.Format != "" {
+			format = override.Format
+			break
 		}
 	}
-	return archive.Format
+	// replacements are not applied, so the template sees the actual platform.
+	format, err := tmpl.New(ctx).WithArtifact(binary, map[string]string{}).Apply(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid archive format template: %w", err)
+	}
+	return format, nil
 }
 
 // NewEnhancedArchive enhances a pre-existing archive.Archive instance
@@ -293,10 +542,10 @@ type EnhancedArchive struct {
 func (d EnhancedArchive) Add(f config.File) error {
 	name := strings.ReplaceAll(filepath.Join(d.wrap, f.Destination), "\\", "/")
 	log.Debugf("adding file: %s as %s", f.Source, name)
-	if _, ok := d.files[f.Destination]; ok {
+	if _, ok := d.files[name]; ok {
 		return fmt.Errorf("file %s already exists in the archive", f.Destination)
 	}
-	d.files[f.Destination] = name
+	d.files[name] = f.Source
 	ff := config.File{
 		Source:      f.Source,
 		Destination: name,
@@ -305,6 +554,13 @@ func (d EnhancedArchive) Add(f config.File) error {
 	return d.a.Add(ff)
 }
 
+// withWrap returns a copy of the archive adding files to the given wrap
+// directory, sharing the underlying archive and its duplicate check.
+func (d EnhancedArchive) withWrap(wrap string) EnhancedArchive {
+	d.wrap = wrap
+	return d
+}
+
 // Close closes the underlying archive.
 func (d EnhancedArchive) Close() error {
 	return d.a.Close()
@@ -3,12 +3,14 @@ package archive
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/testlib"
@@ -279,12 +281,23 @@ func TestRunPipeDifferentBinaryCount(t *testing.T) {
 
 	t.Run("check enabled", func(t *testing.T) {
 		ctx.Config.Archives[0].AllowDifferentBinaryCount = false
-		require.EqualError(t, Pipe{}.Run(ctx), "invalid archive: 0: "+ErrArchiveDifferentBinaryCount.Error())
+		err := Pipe{}.Run(ctx)
+		require.ErrorIs(t, err, ErrArchiveDifferentBinaryCount)
+		require.EqualError(t, err, "invalid archive: 0: "+ErrArchiveDifferentBinaryCount.Error()+": darwin_amd64 has 2, linux_amd64 has 1")
 	})
 
 	t.Run("check disabled", func(t *testing.T) {
 		ctx.Config.Archives[0].AllowDifferentBinaryCount = true
 		require.NoError(t, Pipe{}.Run(ctx))
+
+		archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+		require.Len(t, archives, 2)
+		bins := map[string][]string{}
+		for _, a := range archives {
+			bins[a.Goos] = a.ExtraOr(artifact.ExtraBinaries, []string{}).([]string)
+		}
+		require.Len(t, bins["darwin"], 2)
+		require.Equal(t, []string{"bin/mybin"}, bins["linux"])
 	})
 }
 
@@ -679,6 +692,257 @@ func TestRunPipeWrap(t *testing.T) {
 	}
 }
 
+func TestRunPipeNameTemplateFields(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	bin := filepath.Join(folder, "mybin")
+	require.NoError(t, os.WriteFile(bin, []byte("bin"), 0o755))
+	ctx := context.New(
+		config.Project{
+			ProjectName: "foo",
+			Dist:        dist,
+			Archives: []config.Archive{
+				{
+					Builds:       []string{"default"},
+					NameTemplate: "{{ .ProjectName }}_{{ .Tag }}_{{ .Env.FLAVOR }}_{{ .Timestamp }}_{{ .Os }}_{{ .Arch }}",
+					Format:       "tar.gz",
+					Replacements: map[string]string{
+						"darwin": "macOS",
+						"amd64":  "x86_64",
+					},
+				},
+			},
+		},
+	)
+	ctx.Git.CurrentTag = "v1.2.3"
+	ctx.Env["FLAVOR"] = "pro"
+	ctx.Date = time.Unix(1640995200, 0)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 1)
+	require.Equal(t, "foo_v1.2.3_pro_1640995200_macOS_x86_64.tar.gz", archives[0].Name)
+	require.FileExists(t, filepath.Join(dist, archives[0].Name))
+}
+
+func TestRunPipeStripParentBinaryFolder(t *testing.T) {
+	for name, tt := range map[string]struct {
+		files    []config.File
+		expected []string
+	}{
+		"extra files": {
+			files:    []config.File{{Source: "README.*"}},
+			expected: []string{"foo_linux/README.md", "mybin"},
+		},
+		"extra file named as the binary": {
+			files:    []config.File{{Source: "README.md", NameTemplate: "mybin"}},
+			expected: []string{"foo_linux/mybin", "mybin"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := testlib.Mktmp(t)
+			dist := filepath.Join(folder, "dist")
+			require.NoError(t, os.MkdirAll(filepath.Join(dist, "linuxamd64", "bin"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dist, "linuxamd64", "bin", "mybin"), []byte("bin"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(folder, "README.md"), []byte("readme"), 0o644))
+			ctx := context.New(
+				config.Project{
+					Dist: dist,
+					Archives: []config.Archive{
+						{
+							Builds:                  []string{"default"},
+							NameTemplate:            "foo",
+							WrapInDirectory:         "foo_{{ .Os }}",
+							StripParentBinaryFolder: true,
+							Format:                  "tar.gz",
+							Files:                   tt.files,
+						},
+					},
+				},
+			)
+			ctx.Git.CurrentTag = "v0.0.1"
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Goos:   "linux",
+				Goarch: "amd64",
+				Name:   "bin/mybin",
+				Path:   filepath.Join(dist, "linuxamd64", "bin", "mybin"),
+				Type:   artifact.Binary,
+				Extra: map[string]interface{}{
+					artifact.ExtraBinary: "mybin",
+					artifact.ExtraID:     "default",
+				},
+			})
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+			require.Len(t, archives, 1)
+			require.Equal(t, "", archives[0].ExtraOr(artifact.ExtraWrappedIn, "-"))
+			require.Equal(t, []string{"mybin"}, archives[0].ExtraOr(artifact.ExtraBinaries, []string{}))
+
+			f, err := os.Open(filepath.Join(dist, "foo.tar.gz"))
+			require.NoError(t, err)
+			defer func() { require.NoError(t, f.Close()) }()
+			gr, err := gzip.NewReader(f)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, gr.Close()) }()
+			r := tar.NewReader(gr)
+			var names []string
+			for {
+				h, err := r.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				names = append(names, h.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestRunPipeBinarySignatures(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	bin := filepath.Join(dist, "linuxamd64", "mybin")
+	require.NoError(t, os.MkdirAll(filepath.Dir(bin), 0o755))
+	require.NoError(t, os.WriteFile(bin, []byte("bin"), 0o755))
+	require.NoError(t, os.WriteFile(bin+".sig", []byte("sig"), 0o644))
+	require.NoError(t, os.WriteFile(bin+".pem", []byte("cert"), 0o644))
+	ctx := context.New(
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					Builds:          []string{"default"},
+					NameTemplate:    "foo",
+					WrapInDirectory: "foo",
+					Format:          "tar.gz",
+				},
+			},
+		},
+	)
+	ctx.Git.CurrentTag = "v0.0.1"
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	for _, sig := range []struct {
+		typ  artifact.Type
+		name string
+		path string
+	}{
+		{artifact.Signature, "mybin_linux_amd64.sig", bin + ".sig"},
+		{artifact.Certificate, "mybin_linux_amd64.pem", bin + ".pem"},
+		// signatures of other binaries, or from the signs, are not archived
+		{artifact.Signature, "mybin_darwin_amd64.sig", filepath.Join(dist, "mybin_darwin_amd64.sig")},
+		{artifact.Signature, "checksums.txt.sig", filepath.Join(dist, "checksums.txt.sig")},
+	} {
+		signed := ""
+		if filepath.Dir(sig.path) == filepath.Dir(bin) {
+			signed = bin
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: sig.name,
+			Path: sig.path,
+			Type: sig.typ,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:     "default",
+				artifact.ExtraSigned: signed,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.ElementsMatch(t, []string{
+		"foo/mybin",
+		"foo/mybin.sig",
+		"foo/mybin.pem",
+	}, tarFiles(t, filepath.Join(dist, "foo.tar.gz")))
+}
+
+func TestRunPipeBinaryNameTemplate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	for _, dir := range []string{"linuxamd64", "windowsamd64"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dist, dir), 0o755))
+	}
+	for _, bin := range []string{"linuxamd64/tool", "windowsamd64/tool.exe"} {
+		f, err := os.Create(filepath.Join(dist, bin))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+	ctx := context.New(
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					Builds:             []string{"default"},
+					NameTemplate:       "foo_{{ .Os }}",
+					BinaryNameTemplate: `{{ .Binary }}{{ if ne .Os "windows" }}-{{ .Os }}{{ end }}`,
+					Format:             "tar.gz",
+				},
+			},
+		},
+	)
+	ctx.Git.CurrentTag = "v0.0.1"
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Name:   "tool",
+		Path:   filepath.Join(dist, "linuxamd64", "tool"),
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "tool",
+			artifact.ExtraID:     "default",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "windows",
+		Goarch: "amd64",
+		Name:   "tool.exe",
+		Path:   filepath.Join(dist, "windowsamd64", "tool.exe"),
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "tool",
+			artifact.ExtraExt:    ".exe",
+			artifact.ExtraID:     "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	for name, expected := range map[string]string{
+		"foo_linux.tar.gz":   "tool-linux",
+		"foo_windows.tar.gz": "tool.exe",
+	} {
+		require.Equal(t, []string{expected}, tarFiles(t, filepath.Join(dist, name)))
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx.Config.Archives[0].NameTemplate = "bar_{{ .Os }}"
+		ctx.Config.Archives[0].BinaryNameTemplate = "{{ .Binary }"
+		require.EqualError(t, Pipe{}.Run(ctx), `invalid binary name template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
 func TestDefault(t *testing.T) {
 	ctx := &context.Context{
 		Config: config.Project{
@@ -691,6 +955,23 @@ func TestDefault(t *testing.T) {
 	require.NotEmpty(t, ctx.Config.Archives[0].Files)
 }
 
+func TestDefaultFiles(t *testing.T) {
+	ctx := context.New(config.Project{
+		Archives: []config.Archive{
+			{ID: "builtin"},
+			{ID: "custom", DefaultFiles: []string{"NOTICE*", "docs/*"}},
+			{ID: "explicit", DefaultFiles: []string{"NOTICE*"}, Files: []config.File{{Source: "foo"}}},
+			{ID: "disabled", DisableDefaultFiles: true},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Len(t, ctx.Config.Archives[0].Files, len(defaultFiles))
+	require.Equal(t, defaultFiles, ctx.Config.Archives[0].DefaultFiles)
+	require.Equal(t, []config.File{{Source: "NOTICE*"}, {Source: "docs/*"}}, ctx.Config.Archives[1].Files)
+	require.Equal(t, []config.File{{Source: "foo"}}, ctx.Config.Archives[2].Files)
+	require.Empty(t, ctx.Config.Archives[3].Files)
+}
+
 func TestDefaultSet(t *testing.T) {
 	ctx := &context.Context{
 		Config: config.Project{
@@ -743,8 +1024,162 @@ func TestFormatFor(t *testing.T) {
 			},
 		},
 	}
-	require.Equal(t, "zip", packageFormat(ctx.Config.Archives[0], "windows"))
-	require.Equal(t, "tar.gz", packageFormat(ctx.Config.Archives[0], "linux"))
+	for goos, format := range map[string]string{
+		"windows": "zip",
+		"linux":   "tar.gz",
+		"win":     "tar.gz",
+	} {
+		result, err := packageFormat(ctx, ctx.Config.Archives[0], &artifact.Artifact{Goos: goos})
+		require.NoError(t, err)
+		require.Equal(t, format, result)
+	}
+}
+
+func TestFormatForTemplate(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Snapshot = true
+	arch := config.Archive{
+		Format: `{{ if .IsSnapshot }}binary{{ else if eq .Os "windows" }}zip{{ else }}tar.gz{{ end }}`,
+		Replacements: map[string]string{
+			"windows": "Windows",
+		},
+	}
+	format, err := packageFormat(ctx, arch, &artifact.Artifact{Goos: "windows"})
+	require.NoError(t, err)
+	require.Equal(t, "binary", format)
+
+	ctx.Snapshot = false
+	format, err = packageFormat(ctx, arch, &artifact.Artifact{Goos: "windows"})
+	require.NoError(t, err)
+	require.Equal(t, "zip", format)
+
+	format, err = packageFormat(ctx, arch, &artifact.Artifact{Goos: "linux"})
+	require.NoError(t, err)
+	require.Equal(t, "tar.gz", format)
+
+	_, err = packageFormat(ctx, config.Archive{Format: "{{ .Nope }"}, &artifact.Artifact{Goos: "linux"})
+	require.EqualError(t, err, `invalid archive format template: template: tmpl:1: unexpected "}" in operand`)
+}
+
+func TestRunPipeFormatTemplate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Archives: []config.Archive{
+			{
+				Builds:       []string{"default"},
+				NameTemplate: "foo_{{ .Os }}",
+				Format:       `{{ if eq .Os "windows" }}zip{{ else }}tar.gz{{ end }}`,
+			},
+		},
+	})
+	for _, goos := range []string{"linux", "windows"} {
+		createFakeBinary(t, dist, goos+"amd64", "mybin")
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   goos,
+			Goarch: "amd64",
+			Name:   "mybin",
+			Path:   filepath.Join(dist, goos+"amd64", "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	formats := map[string]string{}
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List() {
+		formats[a.Name] = a.Format()
+	}
+	require.Equal(t, map[string]string{
+		"foo_linux.tar.gz": "tar.gz",
+		"foo_windows.zip":  "zip",
+	}, formats)
+}
+
+func TestDefaultInvalidFormatOverride(t *testing.T) {
+	for _, override := range []config.FormatOverride{
+		{Format: "zip"},
+		{Goos: "windows"},
+	} {
+		ctx := context.New(config.Project{
+			Archives: []config.Archive{
+				{
+					ID:              "foo",
+					FormatOverrides: []config.FormatOverride{override},
+				},
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "invalid format override in archive foo: goos and either format or name_template are required")
+	}
+}
+
+func TestRunPipeBinaryNameTemplateOverride(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	createFakeBinary(t, dist, "linuxamd64", "mybin")
+	createFakeBinary(t, dist, "windowsamd64", "mybin.exe")
+	createFakeBinary(t, dist, "darwinamd64", "mybin")
+	ctx := context.New(config.Project{
+		Dist:        dist,
+		ProjectName: "foobar",
+		Archives: []config.Archive{
+			{
+				Builds:       []string{"default"},
+				Format:       "binary",
+				NameTemplate: "{{ .Binary }}_{{ .Os }}_{{ .Arch }}",
+				FormatOverrides: []config.FormatOverride{
+					{
+						Goos:         "linux",
+						NameTemplate: "{{ .Binary }}_{{ .Os }}_{{ .Arch }}-musl",
+					},
+					{
+						Goos:         "darwin",
+						Format:       "tar.gz",
+						NameTemplate: "{{ .Binary }}_macos",
+					},
+				},
+			},
+		},
+	})
+	for _, goos := range []string{"linux", "windows", "darwin"} {
+		ext := ""
+		if goos == "windows" {
+			ext = ".exe"
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   goos,
+			Goarch: "amd64",
+			Name:   "mybin" + ext,
+			Path:   filepath.Join(dist, goos+"amd64", "mybin"+ext),
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraExt:    ext,
+				artifact.ExtraID:     "default",
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	var names []string
+	for _, a := range ctx.Artifacts.Filter(artifact.Or(
+		artifact.ByType(artifact.UploadableBinary),
+		artifact.ByType(artifact.UploadableArchive),
+	)).List() {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"mybin_linux_amd64-musl",
+		"mybin_windows_amd64.exe",
+		"mybin_macos.tar.gz",
+	}, names)
 }
 
 func TestBinaryOverride(t *testing.T) {
@@ -1042,9 +1477,341 @@ func TestInvalidFormat(t *testing.T) {
 				ID:           "foo",
 				NameTemplate: "foo",
 				Meta:         true,
+				Format:       "rar",
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), "invalid archive format: rar")
+}
+
+func TestRunPipeMultipleArchives(t *testing.T) {
+	dist := t.TempDir()
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        dist,
+		Archives: []config.Archive{
+			{
+				ID:           "meta",
+				NameTemplate: "meta",
+				Format:       "tar.gz",
+				Meta:         true,
+				Files:        []config.File{{Source: "testdata/a/a.txt"}},
+			},
+			{
+				ID:           "cli",
+				Builds:       []string{"cli"},
+				NameTemplate: "cli_{{ .Os }}",
+				Format:       "tar.gz",
+				Files:        []config.File{{Source: "testdata/a/a.txt"}},
+			},
+			{
+				ID:           "server",
+				Builds:       []string{"server"},
+				NameTemplate: "server_{{ .Os }}",
+				Format:       "binary",
+			},
+			{
+				ID:     "typo",
+				Builds: []string{"srever"},
+				Format: "tar.gz",
+			},
+		},
+	})
+	for _, id := range []string{"cli", "server"} {
+		bin := filepath.Join(t.TempDir(), id)
+		require.NoError(t, os.WriteFile(bin, []byte(id), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   "linux",
+			Goarch: "amd64",
+			Name:   id,
+			Path:   bin,
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:     id,
+				artifact.ExtraBinary: id,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	names := make([]string, 0, len(archives))
+	for _, a := range archives {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{"meta.tar.gz", "cli_linux.tar.gz"}, names)
+	require.Equal(t, []string{"testdata/a/a.txt", "cli"}, tarFiles(t, filepath.Join(dist, "cli_linux.tar.gz")))
+
+	binaries := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableBinary)).List()
+	require.Len(t, binaries, 1)
+	require.Equal(t, "server_linux", binaries[0].Name)
+}
+
+func TestRunPipeFilesAndBuildsInfo(t *testing.T) {
+	dist := t.TempDir()
+	mtime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	bin := filepath.Join(t.TempDir(), "mybin")
+	require.NoError(t, os.WriteFile(bin, []byte("bin"), 0o600))
+
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Archives: []config.Archive{
+			{
+				NameTemplate: "foo",
+				Format:       "tar.gz",
+				Files: []config.File{
+					{Source: "testdata/a/a.txt"},
+					{Source: "testdata/a/b/a.txt", Info: config.FileInfo{Owner: "carlos", Mode: 0o600}},
+				},
+				FilesInfo: config.FileInfo{
+					Owner: "root",
+					Group: "root",
+					Mode:  0o644,
+					MTime: mtime,
+				},
+				BuildsInfo: config.FileInfo{
+					Owner: "root",
+					Group: "wheel",
+					Mode:  0o755,
+					MTime: mtime,
+				},
+			},
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	f, err := os.Open(filepath.Join(dist, "foo.tar.gz"))
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	defer gr.Close()
+	r := tar.NewReader(gr)
+
+	type info struct {
+		owner, group string
+		mode         int64
+		mtime        time.Time
+	}
+	infos := map[string]info{}
+	for {
+		next, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		infos[next.Name] = info{next.Uname, next.Gname, next.Mode, next.ModTime.UTC()}
+	}
+	require.Equal(t, map[string]info{
+		"testdata/a/a.txt":   {"root", "root", 0o644, mtime},
+		"testdata/a/b/a.txt": {"carlos", "root", 0o600, mtime},
+		"mybin":              {"root", "wheel", 0o755, mtime},
+	}, infos)
+}
+
+func TestRunPipeReproducible(t *testing.T) {
+	commitDate := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	bin := filepath.Join(t.TempDir(), "mybin")
+	require.NoError(t, os.WriteFile(bin, []byte("bin"), 0o755))
+
+	run := func(t *testing.T, format string) []byte {
+		t.Helper()
+		dist := t.TempDir()
+		ctx := context.New(config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					NameTemplate: "foo",
+					Format:       format,
+					Reproducible: true,
+					Files: []config.File{
+						{Source: "testdata/a/a.txt"},
+						{Source: "testdata/a/b/a.txt"},
+					},
+				},
+			},
+		})
+		ctx.Git.CommitDate = commitDate
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   "linux",
+			Goarch: "amd64",
+			Name:   "mybin",
+			Path:   bin,
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "default",
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+		bts, err := os.ReadFile(filepath.Join(dist, "foo."+format))
+		require.NoError(t, err)
+		return bts
+	}
+
+	for _, format := range []string{"tar.gz", "zip"} {
+		t.Run(format, func(t *testing.T) {
+			require.Equal(t, run(t, format), run(t, format))
+		})
+	}
+
+	t.Run("entries", func(t *testing.T) {
+		gr, err := gzip.NewReader(bytes.NewReader(run(t, "tar.gz")))
+		require.NoError(t, err)
+		defer gr.Close()
+		r := tar.NewReader(gr)
+		var names []string
+		for {
+			next, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			require.Equal(t, "root", next.Uname)
+			require.Equal(t, "root", next.Gname)
+			require.Equal(t, 0, next.Uid)
+			require.Equal(t, 0, next.Gid)
+			require.Equal(t, commitDate, next.ModTime.UTC())
+			names = append(names, next.Name)
+		}
+		require.Equal(t, []string{"mybin", "testdata/a/a.txt", "testdata/a/b/a.txt"}, names)
+	})
+}
+
+func TestDefaultInvalidCompression(t *testing.T) {
+	ctx := context.New(config.Project{
+		Archives: []config.Archive{
+			{
+				ID:          "foo",
+				Format:      "zip",
+				Compression: "ultra",
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "invalid compression in archive foo: ultra: should be store, fastest, default or best")
+}
+
+func TestRunPipeCompression(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "mybin")
+	require.NoError(t, os.WriteFile(bin, bytes.Repeat([]byte("goreleaser "), 10000), 0o755))
+
+	for _, format := range []string{"zip", "tar.gz", "gz", "tar.zst"} {
+		t.Run(format, func(t *testing.T) {
+			sizes := map[string]int64{}
+			for _, compression := range []string{"store", "best"} {
+				dist := t.TempDir()
+				ctx := context.New(config.Project{
+					Dist: dist,
+					Archives: []config.Archive{
+						{
+							NameTemplate: "foo",
+							Format:       format,
+							Compression:  compression,
+						},
+					},
+				})
+				ctx.Artifacts.Add(&artifact.Artifact{
+					Goos:   "linux",
+					Goarch: "amd64",
+					Name:   "mybin",
+					Path:   bin,
+					Type:   artifact.Binary,
+					Extra: map[string]interface{}{
+						artifact.ExtraID: "default",
+					},
+				})
+				require.NoError(t, Pipe{}.Default(ctx))
+				require.NoError(t, Pipe{}.Run(ctx))
+				info, err := os.Stat(filepath.Join(dist, "foo."+format))
+				require.NoError(t, err)
+				sizes[compression] = info.Size()
+			}
+			require.Less(t, sizes["best"], sizes["store"])
+		})
+	}
+}
+
+func TestRunPipeCloseError(t *testing.T) {
+	t.Setenv("PATH", "")
+	bin := filepath.Join(t.TempDir(), "mybin")
+	require.NoError(t, os.WriteFile(bin, []byte("bin"), 0o755))
+	dist := t.TempDir()
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Archives: []config.Archive{
+			{
+				NameTemplate: "foo",
 				Format:       "7z",
 			},
 		},
 	})
-	require.EqualError(t, Pipe{}.Run(ctx), "invalid archive format: 7z")
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   bin,
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "default",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(
+		t,
+		Pipe{}.Run(ctx),
+		"failed to close archive "+filepath.Join(dist, "foo.7z")+": 7z: could not find any of [7z 7za 7zz] in $PATH",
+	)
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List())
+}
+
+func TestRunPipeSingleFileFormats(t *testing.T) {
+	for _, format := range []string{"gz", "bz2"} {
+		t.Run(format, func(t *testing.T) {
+			folder := testlib.Mktmp(t)
+			dist := filepath.Join(folder, "dist")
+			require.NoError(t, os.WriteFile(filepath.Join(folder, "README.md"), []byte("readme"), 0o644))
+			bin := filepath.Join(folder, "mybin")
+			require.NoError(t, os.WriteFile(bin, []byte("bin"), 0o755))
+
+			ctx := context.New(config.Project{
+				Dist: dist,
+				Archives: []config.Archive{
+					{
+						NameTemplate:    "foo",
+						Format:          format,
+						WrapInDirectory: "true",
+					},
+				},
+			})
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Goos:   "linux",
+				Goarch: "amd64",
+				Name:   "mybin",
+				Path:   bin,
+				Type:   artifact.Binary,
+				Extra: map[string]interface{}{
+					artifact.ExtraID: "default",
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+			require.Len(t, archives, 1)
+			require.Equal(t, "foo."+format, archives[0].Name)
+			require.Equal(t, []string{"mybin"}, archives[0].ExtraOr(artifact.ExtraBinaries, []string{}))
+		})
+	}
 }
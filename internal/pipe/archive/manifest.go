@@ -0,0 +1,100 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/internal/yaml"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+type manifest struct {
+	ProjectName string         `json:"project_name" yaml:"project_name"`
+	Version     string         `json:"version" yaml:"version"`
+	Tag         string         `json:"tag" yaml:"tag"`
+	Commit      string         `json:"commit" yaml:"commit"`
+	Date        time.Time      `json:"date" yaml:"date"`
+	Files       []manifestFile `json:"files" yaml:"files"`
+}
+
+type manifestFile struct {
+	Path   string `json:"path" yaml:"path"`
+	Size   int64  `json:"size" yaml:"size"`
+	SHA256 string `json:"sha256" yaml:"sha256"`
+}
+
+// writeManifest writes the manifest of the given files to a temporary file,
+// and returns the config.File to add it to the archive.
+// The caller is responsible for removing the temporary file.
+func writeManifest(ctx *context.Context, arch config.Archive, template *tmpl.Template, files []config.File) (config.File, error) {
+	name, err := template.Apply(arch.Manifest.NameTemplate)
+	if err != nil {
+		return config.File{}, fmt.Errorf("invalid manifest name template: %w", err)
+	}
+
+	date := ctx.Date.UTC()
+	if arch.Reproducible {
+		date = reproducibleTime(ctx)
+	}
+	m := manifest{
+		ProjectName: ctx.Config.ProjectName,
+		Version:     ctx.Version,
+		Tag:         ctx.Git.CurrentTag,
+		Commit:      ctx.Git.FullCommit,
+		Date:        date,
+		Files:       []manifestFile{},
+	}
+	for _, f := range files {
+		info, err := os.Stat(f.Source)
+		if err != nil {
+			return config.File{}, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		sum, err := artifact.Artifact{Path: f.Source}.Checksum("sha256")
+		if err != nil {
+			return config.File{}, err
+		}
+		m.Files = append(m.Files, manifestFile{
+			Path:   f.Destination,
+			Size:   info.Size(),
+			SHA256: sum,
+		})
+	}
+
+	var bts []byte
+	switch arch.Manifest.Format {
+	case "yaml":
+		bts, err = yaml.Marshal(m)
+	default:
+		bts, err = json.MarshalIndent(m, "", "  ")
+	}
+	if err != nil {
+		return config.File{}, err
+	}
+
+	tmp, err := os.CreateTemp("", "goreleaser-manifest-*")
+	if err != nil {
+		return config.File{}, err
+	}
+	defer tmp.Close()
+	if _, err := tmp.Write(bts); err != nil {
+		return config.File{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return config.File{}, err
+	}
+	return config.File{
+		Source:      tmp.Name(),
+		Destination: name,
+		Info: withDefaultInfo(config.FileInfo{
+			Mode: 0o644,
+		}, arch.FilesInfo),
+	}, nil
+}
@@ -0,0 +1,159 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	"github.com/goreleaser/goreleaser/internal/yaml"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPipeManifest(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			folder := testlib.Mktmp(t)
+			dist := filepath.Join(folder, "dist")
+			require.NoError(t, os.Mkdir(dist, 0o755))
+			createFakeBinary(t, dist, "linuxamd64", "mybin")
+			require.NoError(t, os.WriteFile(filepath.Join(folder, "README.md"), []byte("readme"), 0o644))
+
+			ctx := context.New(config.Project{
+				ProjectName: "foo",
+				Dist:        dist,
+				Archives: []config.Archive{
+					{
+						NameTemplate:    "foo",
+						WrapInDirectory: "true",
+						Format:          "tar.gz",
+						Files:           []config.File{{Source: "README.md"}},
+						Manifest: config.ArchiveManifest{
+							Enabled: true,
+							Format:  format,
+						},
+					},
+				},
+			})
+			ctx.Version = "1.0.0"
+			ctx.Git.CurrentTag = "v1.0.0"
+			ctx.Git.FullCommit = "aaaaaaaa"
+			ctx.Date = time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Goos:   "linux",
+				Goarch: "amd64",
+				Name:   "mybin",
+				Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+				Type:   artifact.Binary,
+				Extra: map[string]interface{}{
+					artifact.ExtraBinary: "mybin",
+					artifact.ExtraID:     "default",
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.Equal(t, "manifest."+format, ctx.Config.Archives[0].Manifest.NameTemplate)
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			f, err := os.Open(filepath.Join(dist, "foo.tar.gz"))
+			require.NoError(t, err)
+			defer f.Close()
+			gr, err := gzip.NewReader(f)
+			require.NoError(t, err)
+			defer gr.Close()
+			r := tar.NewReader(gr)
+			var bts []byte
+			for {
+				next, err := r.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				if next.Name == "foo/manifest."+format {
+					bts, err = io.ReadAll(r)
+					require.NoError(t, err)
+				}
+			}
+			require.NotEmpty(t, bts, "manifest not found in the archive")
+
+			var m manifest
+			if format == "json" {
+				require.NoError(t, json.Unmarshal(bts, &m))
+			} else {
+				require.NoError(t, yaml.Unmarshal(bts, &m))
+			}
+			require.Equal(t, manifest{
+				ProjectName: "foo",
+				Version:     "1.0.0",
+				Tag:         "v1.0.0",
+				Commit:      "aaaaaaaa",
+				Date:        ctx.Date,
+				Files: []manifestFile{
+					{
+						Path:   "README.md",
+						Size:   6,
+						SHA256: "711a6108ba2ce6ca93dd47d6817f2361db10d8ab6eec89460b2dfc2c325efabe",
+					},
+					{
+						Path:   "mybin",
+						Size:   0,
+						SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					},
+				},
+			}, m)
+		})
+	}
+}
+
+func TestDefaultInvalidManifestFormat(t *testing.T) {
+	ctx := context.New(config.Project{
+		Archives: []config.Archive{
+			{
+				ID: "foo",
+				Manifest: config.ArchiveManifest{
+					Enabled: true,
+					Format:  "toml",
+				},
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "invalid manifest format in archive foo: toml: should be json or yaml")
+}
+
+func TestRunPipeManifestInvalidNameTemplate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	createFakeBinary(t, dist, "linuxamd64", "mybin")
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Archives: []config.Archive{
+			{
+				NameTemplate: "foo",
+				Format:       "tar.gz",
+				Manifest: config.ArchiveManifest{
+					Enabled:      true,
+					NameTemplate: "{{ .Nope }",
+				},
+			},
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "default",
+		},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), `invalid manifest name template: template: tmpl:1: unexpected "}" in operand`)
+}
@@ -0,0 +1,126 @@
+// Package attestation wraps the digests of the released artifacts in in-toto
+// statements with custom predicates, inside DSSE envelopes.
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/ids"
+	"github.com/goreleaser/goreleaser/internal/intoto"
+	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Pipe for attestations.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "attestations" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Attestations) == 0 }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("attestations")
+	for i := range ctx.Config.Attestations {
+		cfg := &ctx.Config.Attestations[i]
+		if cfg.ID == "" {
+			cfg.ID = "default"
+		}
+		if cfg.NameTemplate == "" {
+			cfg.NameTemplate = "{{ .ProjectName }}_{{ .Version }}_" + cfg.ID + ".intoto.json"
+		}
+		if cfg.PredicateType == "" {
+			return fmt.Errorf("attestation %s: predicate_type is required", cfg.ID)
+		}
+		ids.Inc(cfg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.New(ctx.Parallelism)
+	for _, cfg := range ctx.Config.Attestations {
+		cfg := cfg
+		g.Go(func() error {
+			return attest(ctx, cfg)
+		})
+	}
+	return g.Wait()
+}
+
+func attest(ctx *context.Context, cfg config.Attestation) error {
+	artifacts := ctx.Artifacts.Filter(intoto.Filter(cfg.IDs)).List()
+	if len(artifacts) == 0 {
+		log.WithField("id", cfg.ID).Warn("no artifacts to attest, skipping")
+		return nil
+	}
+
+	predicate, err := predicateOf(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	subjects, err := intoto.SubjectsOf(ctx, artifacts)
+	if err != nil {
+		return err
+	}
+	env, err := intoto.NewEnvelope(ctx, intoto.Statement{
+		Type:          intoto.StatementType,
+		PredicateType: cfg.PredicateType,
+		Subject:       subjects,
+		Predicate:     predicate,
+	}, cfg.Signer)
+	if err != nil {
+		return fmt.Errorf("attestation %s: %w", cfg.ID, err)
+	}
+
+	name, err := tmpl.New(ctx).Apply(cfg.NameTemplate)
+	if err != nil {
+		return fmt.Errorf("attestation %s: failed to apply name template: %w", cfg.ID, err)
+	}
+	bts, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(ctx.Config.Dist, name)
+	log.WithField("file", path).Info("writing attestation")
+	if err := os.WriteFile(path, append(bts, '\n'), 0o644); err != nil {
+		return fmt.Errorf("attestation %s: failed to write: %w", cfg.ID, err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Attestation,
+		Name: name,
+		Path: path,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: cfg.ID,
+		},
+	})
+	return nil
+}
+
+// predicateOf reads and templates the predicate file, which must contain a
+// JSON document. An empty object is used if there is no predicate file.
+func predicateOf(ctx *context.Context, cfg config.Attestation) (json.RawMessage, error) {
+	if cfg.PredicateFile == "" {
+		return json.RawMessage("{}"), nil
+	}
+	bts, err := os.ReadFile(cfg.PredicateFile)
+	if err != nil {
+		return nil, fmt.Errorf("attestation %s: failed to read predicate: %w", cfg.ID, err)
+	}
+	predicate, err := tmpl.New(ctx).Apply(string(bts))
+	if err != nil {
+		return nil, fmt.Errorf("attestation %s: failed to apply predicate template: %w", cfg.ID, err)
+	}
+	if !json.Valid([]byte(predicate)) {
+		return nil, fmt.Errorf("attestation %s: predicate is not valid JSON", cfg.ID)
+	}
+	return json.RawMessage(predicate), nil
+}
@@ -0,0 +1,226 @@
+package attestation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/intoto"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	require.False(t, Pipe{}.Skip(context.New(config.Project{
+		Attestations: []config.Attestation{{}},
+	})))
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Attestations: []config.Attestation{
+				{PredicateType: "https://example.com/test/v1"},
+				{ID: "vuln", PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1"},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "default", ctx.Config.Attestations[0].ID)
+		require.Equal(t, "{{ .ProjectName }}_{{ .Version }}_default.intoto.json", ctx.Config.Attestations[0].NameTemplate)
+		require.Equal(t, "{{ .ProjectName }}_{{ .Version }}_vuln.intoto.json", ctx.Config.Attestations[1].NameTemplate)
+	})
+
+	t.Run("missing predicate type", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Attestations: []config.Attestation{{}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "attestation default: predicate_type is required")
+	})
+
+	t.Run("duplicated ids", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Attestations: []config.Attestation{
+				{PredicateType: "https://example.com/test/v1"},
+				{PredicateType: "https://example.com/test/v1"},
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "found 2 attestations with the ID 'default', please fix your config")
+	})
+}
+
+func TestRunUnsigned(t *testing.T) {
+	predicateFile := filepath.Join(t.TempDir(), "predicate.json")
+	require.NoError(t, os.WriteFile(predicateFile, []byte(`{"version": "{{ .Version }}"}`), 0o644))
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        t.TempDir(),
+		Attestations: []config.Attestation{{
+			PredicateType: "https://example.com/test/v1",
+			PredicateFile: predicateFile,
+		}},
+	})
+	ctx.Version = "1.2.3"
+	testlib.AddReleaseArtifacts(t, ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	attestations := ctx.Artifacts.Filter(artifact.ByType(artifact.Attestation)).List()
+	require.Len(t, attestations, 1)
+	require.Equal(t, "foo_1.2.3_default.intoto.json", attestations[0].Name)
+	require.Equal(t, "default", attestations[0].ExtraOr(artifact.ExtraID, ""))
+
+	env, st := readEnvelope(t, attestations[0].Path)
+	require.Equal(t, intoto.PayloadType, env.PayloadType)
+	require.Empty(t, env.Signatures)
+	require.Equal(t, intoto.StatementType, st.Type)
+	require.Equal(t, "https://example.com/test/v1", st.PredicateType)
+	require.Equal(t, []intoto.Subject{
+		{Name: "foo_1.2.3_amd64.deb", Digest: map[string]string{"sha256": testlib.ReleaseArtifactsSum}},
+		{Name: "foo_linux_amd64.tar.gz", Digest: map[string]string{"sha256": testlib.ReleaseArtifactsSum}},
+		{Name: "foo_windows_amd64.zip", Digest: map[string]string{"sha256": testlib.ReleaseArtifactsSum}},
+	}, st.Subject)
+	require.JSONEq(t, `{"version": "1.2.3"}`, string(st.Predicate))
+}
+
+func TestRunSigned(t *testing.T) {
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        t.TempDir(),
+		Attestations: []config.Attestation{{
+			ID:            "signed",
+			IDs:           []string{"packages"},
+			PredicateType: "https://example.com/test/v1",
+			Signer: config.AttestationSigner{
+				Cmd:   "sh",
+				Args:  []string{"-c", "cp ${payload} ${signature}"},
+				KeyID: "my-key",
+			},
+		}},
+	})
+	ctx.Version = "1.2.3"
+	testlib.AddReleaseArtifacts(t, ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	attestations := ctx.Artifacts.Filter(artifact.ByType(artifact.Attestation)).List()
+	require.Len(t, attestations, 1)
+	env, st := readEnvelope(t, attestations[0].Path)
+	require.Equal(t, []intoto.Subject{
+		{Name: "foo_1.2.3_amd64.deb", Digest: map[string]string{"sha256": testlib.ReleaseArtifactsSum}},
+	}, st.Subject)
+	require.JSONEq(t, `{}`, string(st.Predicate))
+
+	require.Len(t, env.Signatures, 1)
+	require.Equal(t, "my-key", env.Signatures[0].KeyID)
+	sig, err := base64.StdEncoding.DecodeString(env.Signatures[0].Sig)
+	require.NoError(t, err)
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	require.NoError(t, err)
+	require.Equal(t, string(intoto.PAE(intoto.PayloadType, payload)), string(sig))
+}
+
+func TestRunNoArtifacts(t *testing.T) {
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        t.TempDir(),
+		Attestations: []config.Attestation{{
+			IDs:           []string{"nope"},
+			PredicateType: "https://example.com/test/v1",
+		}},
+	})
+	testlib.AddReleaseArtifacts(t, ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Attestation)).List())
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		attestation config.Attestation
+		predicate   string
+		err         string
+	}{
+		"signer fails": {
+			attestation: config.Attestation{
+				Signer: config.AttestationSigner{
+					Cmd:  "sh",
+					Args: []string{"-c", "echo oops; exit 1"},
+				},
+			},
+			err: "attestation default: sh failed: exit status 1: oops\n",
+		},
+		"invalid predicate": {
+			predicate: `{"foo":`,
+			err:       "attestation default: predicate is not valid JSON",
+		},
+		"invalid predicate template": {
+			predicate: `{{ .Nope }`,
+			err:       `attestation default: failed to apply predicate template: template: tmpl:1: unexpected "}" in operand`,
+		},
+		"invalid name template": {
+			attestation: config.Attestation{NameTemplate: "{{ .Nope }"},
+			err:         `attestation default: failed to apply name template: template: tmpl:1: unexpected "}" in operand`,
+		},
+		"invalid signer args template": {
+			attestation: config.Attestation{
+				Signer: config.AttestationSigner{
+					Cmd:  "sh",
+					Args: []string{"{{ .Nope }"},
+				},
+			},
+			err: `attestation default: failed to apply signer args template: template: tmpl:1: unexpected "}" in operand`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := tt.attestation
+			cfg.PredicateType = "https://example.com/test/v1"
+			if tt.predicate != "" {
+				cfg.PredicateFile = filepath.Join(t.TempDir(), "predicate.json")
+				require.NoError(t, os.WriteFile(cfg.PredicateFile, []byte(tt.predicate), 0o644))
+			}
+			ctx := context.New(config.Project{
+				ProjectName:  "foo",
+				Dist:         t.TempDir(),
+				Attestations: []config.Attestation{cfg},
+			})
+			testlib.AddReleaseArtifacts(t, ctx)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.EqualError(t, Pipe{}.Run(ctx), tt.err)
+		})
+	}
+}
+
+func TestRunMissingPredicateFile(t *testing.T) {
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        t.TempDir(),
+		Attestations: []config.Attestation{{
+			PredicateType: "https://example.com/test/v1",
+			PredicateFile: filepath.Join(t.TempDir(), "nope.json"),
+		}},
+	})
+	testlib.AddReleaseArtifacts(t, ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorIs(t, Pipe{}.Run(ctx), os.ErrNotExist)
+}
+
+func readEnvelope(tb testing.TB, path string) (intoto.Envelope, intoto.Statement) {
+	tb.Helper()
+	bts, err := os.ReadFile(path)
+	require.NoError(tb, err)
+	var env intoto.Envelope
+	require.NoError(tb, json.Unmarshal(bts, &env))
+	st, err := env.Statement()
+	require.NoError(tb, err)
+	return env, st
+}
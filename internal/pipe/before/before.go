@@ -23,10 +23,22 @@ func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Before.Hooks
 
 // Run executes the hooks.
 func (Pipe) Run(ctx *context.Context) error {
-	tmpl := tmpl.New(ctx)
-	/* #nosec */
 	for _, step := range ctx.Config.Before.Hooks {
-		s, err := tmpl.Apply(step)
+		env := ctx.Env.Strings()
+		for _, rawEnv := range step.Env {
+			e, err := tmpl.New(ctx).Apply(rawEnv)
+			if err != nil {
+				return err
+			}
+			env = append(env, e)
+		}
+
+		dir, err := tmpl.New(ctx).Apply(step.Dir)
+		if err != nil {
+			return err
+		}
+
+		s, err := tmpl.New(ctx).WithEnvS(env).Apply(step.Cmd)
 		if err != nil {
 			return err
 		}
@@ -35,18 +47,24 @@ func (Pipe) Run(ctx *context.Context) error {
 			return err
 		}
 
-		cmd := exec.Command(args[0], args[1:]...)
-		cmd.Env = ctx.Env.Strings()
+		/* #nosec */
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Env = env
+		cmd.Dir = dir
 
 		var b bytes.Buffer
 		w := gio.Safe(&b)
-		fields := log.Fields{"hook": step}
-		cmd.Stderr = io.MultiWriter(logext.NewWriter(fields, logext.Error), w)
-		cmd.Stdout = io.MultiWriter(logext.NewWriter(fields, logext.Info), w)
+		fields := log.Fields{"hook": step.Cmd}
+		cmd.Stderr = io.MultiWriter(logext.NewConditionalWriter(fields, logext.Error, step.Output), w)
+		cmd.Stdout = io.MultiWriter(logext.NewConditionalWriter(fields, logext.Info, step.Output), w)
 
 		log.WithFields(fields).Info("running")
 		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("hook failed: %s: %w; output: %s", step, err, b.String())
+			if step.AllowFailure {
+				log.WithFields(fields).WithError(err).Warn("hook failed, ignoring")
+				continue
+			}
+			return fmt.Errorf("hook failed: %s: %w; output: %s", step.Cmd, err, b.String())
 		}
 	}
 	return nil
@@ -0,0 +1,129 @@
+// Package bitbucket uploads the artifacts and release notes to the downloads
+// section of a Bitbucket Cloud repository.
+package bitbucket
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const (
+	defaultAPIURL                   = "https://api.bitbucket.org/2.0"
+	defaultReleaseNotesNameTemplate = "{{ .ProjectName }}_{{ .Version }}_release_notes.md"
+
+	usernameEnv = "BITBUCKET_USERNAME"
+	tokenEnv    = "BITBUCKET_TOKEN"
+)
+
+// Pipe for bitbucket downloads.
+type Pipe struct{}
+
+func (Pipe) String() string { return "bitbucket downloads" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return ctx.Config.Bitbucket.Repo.Owner == "" || ctx.Config.Bitbucket.Repo.Name == ""
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Bitbucket
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultAPIURL
+	}
+	if cfg.ReleaseNotesNameTemplate == "" {
+		cfg.ReleaseNotesNameTemplate = defaultReleaseNotesNameTemplate
+	}
+	return nil
+}
+
+// Publish the artifacts and release notes.
+func (Pipe) Publish(ctx *context.Context) error {
+	cfg := ctx.Config.Bitbucket
+	username, token := ctx.Env[usernameEnv], ctx.Env[tokenEnv]
+	if username == "" || token == "" {
+		return fmt.Errorf("%s and %s are required to upload to bitbucket", usernameEnv, tokenEnv)
+	}
+	url := fmt.Sprintf(
+		"%s/repositories/%s/%s/downloads",
+		strings.TrimSuffix(cfg.APIURL, "/"),
+		cfg.Repo.Owner,
+		cfg.Repo.Name,
+	)
+
+	filter := artifact.ByReleaseUploadableTypes()
+	if len(cfg.IDs) > 0 {
+		filter = artifact.And(filter, artifact.ByIDs(cfg.IDs...))
+	}
+
+	g := semerrgroup.New(ctx.Parallelism)
+	for _, a := range ctx.Artifacts.Filter(filter).List() {
+		a := a
+		g.Go(func() error {
+			f, err := os.Open(a.Path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return upload(ctx, url, username, token, a.Name, f)
+		})
+	}
+	if ctx.ReleaseNotes != "" {
+		g.Go(func() error {
+			name, err := tmpl.New(ctx).Apply(cfg.ReleaseNotesNameTemplate)
+			if err != nil {
+				return fmt.Errorf("failed to apply release notes name template: %w", err)
+			}
+			return upload(ctx, url, username, token, name, strings.NewReader(ctx.ReleaseNotes))
+		})
+	}
+	return g.Wait()
+}
+
+func upload(ctx *context.Context, url, username, token, name string, r io.Reader) error {
+	// the multipart body is streamed, so big artifacts aren't held in memory.
+	body, w := io.Pipe()
+	defer body.Close()
+	mw := multipart.NewWriter(w)
+	go func() {
+		w.CloseWithError(writeForm(mw, name, r))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, token)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	log.WithField("file", name).Info("uploading")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload %s: %s: %s", name, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func writeForm(w *multipart.Writer, name string, r io.Reader) error {
+	part, err := w.CreateFormFile("files", name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,167 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	require.True(t, Pipe{}.Skip(context.New(config.Project{
+		Bitbucket: config.Bitbucket{
+			Repo: config.Repo{Name: "bar"},
+		},
+	})))
+	require.False(t, Pipe{}.Skip(context.New(config.Project{
+		Bitbucket: config.Bitbucket{
+			Repo: config.Repo{Owner: "foo", Name: "bar"},
+		},
+	})))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultAPIURL, ctx.Config.Bitbucket.APIURL)
+	require.Equal(t, defaultReleaseNotesNameTemplate, ctx.Config.Bitbucket.ReleaseNotesNameTemplate)
+}
+
+func TestPublish(t *testing.T) {
+	var lock sync.Mutex
+	uploads := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/foo/bar/downloads" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "user" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f, h, err := r.FormFile("files")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		bts, err := io.ReadAll(f)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		lock.Lock()
+		uploads[h.Filename] = string(bts)
+		lock.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	dist := t.TempDir()
+	newCtx := func(tb testing.TB, ids ...string) *context.Context {
+		tb.Helper()
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Dist:        dist,
+			Env:         []string{"BITBUCKET_USERNAME=user", "BITBUCKET_TOKEN=secret"},
+			Bitbucket: config.Bitbucket{
+				Repo:   config.Repo{Owner: "foo", Name: "bar"},
+				IDs:    ids,
+				APIURL: srv.URL + "/",
+			},
+		})
+		ctx.Env = map[string]string{"BITBUCKET_USERNAME": "user", "BITBUCKET_TOKEN": "secret"}
+		ctx.Version = "1.2.3"
+		ctx.ReleaseNotes = "## Changelog\n\n* foo\n"
+		for _, a := range []struct {
+			name, id string
+			typ      artifact.Type
+		}{
+			{"foo_linux_amd64.tar.gz", "foo", artifact.UploadableArchive},
+			{"foo_1.2.3_amd64.deb", "pkgs", artifact.LinuxPackage},
+			{"checksums.txt", "", artifact.Checksum},
+			{"foo", "foo", artifact.Binary},
+		} {
+			path := filepath.Join(dist, a.name)
+			require.NoError(tb, os.WriteFile(path, []byte(a.name), 0o644))
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: a.name,
+				Path: path,
+				Type: a.typ,
+				Extra: map[string]interface{}{
+					artifact.ExtraID: a.id,
+				},
+			})
+		}
+		require.NoError(tb, Pipe{}.Default(ctx))
+		return ctx
+	}
+
+	t.Run("all", func(t *testing.T) {
+		uploads = map[string]string{}
+		require.NoError(t, Pipe{}.Publish(newCtx(t)))
+		require.Equal(t, map[string]string{
+			"foo_linux_amd64.tar.gz":     "foo_linux_amd64.tar.gz",
+			"foo_1.2.3_amd64.deb":        "foo_1.2.3_amd64.deb",
+			"checksums.txt":              "checksums.txt",
+			"foo_1.2.3_release_notes.md": "## Changelog\n\n* foo\n",
+		}, uploads)
+	})
+
+	t.Run("filter by id", func(t *testing.T) {
+		uploads = map[string]string{}
+		require.NoError(t, Pipe{}.Publish(newCtx(t, "pkgs")))
+		require.Equal(t, map[string]string{
+			"foo_1.2.3_amd64.deb":        "foo_1.2.3_amd64.deb",
+			"checksums.txt":              "checksums.txt",
+			"foo_1.2.3_release_notes.md": "## Changelog\n\n* foo\n",
+		}, uploads)
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		ctx := newCtx(t)
+		delete(ctx.Env, "BITBUCKET_TOKEN")
+		require.EqualError(t, Pipe{}.Publish(ctx), "BITBUCKET_USERNAME and BITBUCKET_TOKEN are required to upload to bitbucket")
+	})
+
+	t.Run("wrong credentials", func(t *testing.T) {
+		ctx := newCtx(t, "pkgs")
+		ctx.ReleaseNotes = ""
+		ctx.Env["BITBUCKET_TOKEN"] = "nope"
+		require.ErrorContains(t, Pipe{}.Publish(ctx), ": 401 Unauthorized")
+	})
+
+	t.Run("unreadable artifact", func(t *testing.T) {
+		ctx := newCtx(t, "nope")
+		ctx.ReleaseNotes = ""
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: "dir.tar.gz",
+			Path: t.TempDir(),
+			Type: artifact.UploadableArchive,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "nope",
+			},
+		})
+		require.ErrorContains(t, Pipe{}.Publish(ctx), "failed to upload dir.tar.gz")
+	})
+
+	t.Run("invalid release notes name template", func(t *testing.T) {
+		ctx := newCtx(t, "nope")
+		ctx.Config.Bitbucket.ReleaseNotesNameTemplate = "{{ .Nope }"
+		require.EqualError(t, Pipe{}.Publish(ctx), `failed to apply release notes name template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
@@ -26,6 +26,9 @@ func (Pipe) Default(ctx *context.Context) error {
 		if blob.Folder == "" {
 			blob.Folder = "{{ .ProjectName }}/{{ .Tag }}"
 		}
+		if blob.ACL != "" && blob.Provider != "s3" && blob.Provider != "gs" {
+			return fmt.Errorf("acl is not supported by the %s provider", blob.Provider)
+		}
 	}
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
 	"github.com/stretchr/testify/require"
@@ -114,6 +116,78 @@ func TestDefaultsWithProvider(t *testing.T) {
 	require.Nil(t, Pipe{}.Default(ctx))
 }
 
+func TestDefaultsACL(t *testing.T) {
+	for provider, ok := range map[string]bool{
+		"s3":     true,
+		"gs":     true,
+		"azblob": false,
+	} {
+		t.Run(provider, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				Blobs: []config.Blob{
+					{
+						Bucket:   "foo",
+						Provider: provider,
+						ACL:      "public-read",
+					},
+				},
+			})
+			err := Pipe{}.Default(ctx)
+			if ok {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, "acl is not supported by the "+provider+" provider")
+		})
+	}
+}
+
+func TestWriterOptions(t *testing.T) {
+	asFunc := func(input *s3manager.UploadInput, writer *storage.Writer) func(interface{}) bool {
+		return func(i interface{}) bool {
+			switch p := i.(type) {
+			case **s3manager.UploadInput:
+				*p = input
+				return input != nil
+			case **storage.Writer:
+				*p = writer
+				return writer != nil
+			}
+			return false
+		}
+	}
+
+	t.Run("headers", func(t *testing.T) {
+		u := &productionUploader{cacheControl: "max-age=9999, public"}
+		opts := u.writerOptions("foo/bar/file.tar.gz")
+		require.Equal(t, "attachment; filename=file.tar.gz", opts.ContentDisposition)
+		require.Equal(t, "max-age=9999, public", opts.CacheControl)
+	})
+
+	t.Run("s3 acl", func(t *testing.T) {
+		u := &productionUploader{acl: "public-read"}
+		input := &s3manager.UploadInput{}
+		require.NoError(t, u.writerOptions("file").BeforeWrite(asFunc(input, nil)))
+		require.Equal(t, "public-read", *input.ACL)
+	})
+
+	t.Run("gs acl", func(t *testing.T) {
+		u := &productionUploader{acl: "publicRead"}
+		writer := &storage.Writer{}
+		require.NoError(t, u.writerOptions("file").BeforeWrite(asFunc(nil, writer)))
+		require.Equal(t, "publicRead", writer.PredefinedACL)
+	})
+
+	t.Run("no acl", func(t *testing.T) {
+		u := &productionUploader{}
+		input := &s3manager.UploadInput{}
+		writer := &storage.Writer{}
+		require.NoError(t, u.writerOptions("file").BeforeWrite(asFunc(input, writer)))
+		require.Nil(t, input.ACL)
+		require.Empty(t, writer.PredefinedACL)
+	})
+}
+
 func TestURL(t *testing.T) {
 	t.Run("s3 with opts", func(t *testing.T) {
 		url, err := urlFor(context.New(config.Project{}), config.Blob{
@@ -8,7 +8,10 @@ import (
 	"path"
 	"strings"
 
+	"cloud.google.com/go/storage"
 	"github.com/apex/log"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/extrafiles"
 	"github.com/goreleaser/goreleaser/internal/semerrgroup"
@@ -75,21 +78,15 @@ func doUpload(ctx *context.Context, conf config.Blob) error {
 		return err
 	}
 
-	filter := artifact.Or(
-		artifact.ByType(artifact.UploadableArchive),
-		artifact.ByType(artifact.UploadableBinary),
-		artifact.ByType(artifact.UploadableSourceArchive),
-		artifact.ByType(artifact.Checksum),
-		artifact.ByType(artifact.Signature),
-		artifact.ByType(artifact.Certificate),
-		artifact.ByType(artifact.LinuxPackage),
-		artifact.ByType(artifact.SBOM),
-	)
+	filter := artifact.ByReleaseUploadableTypes()
 	if len(conf.IDs) > 0 {
 		filter = artifact.And(filter, artifact.ByIDs(conf.IDs...))
 	}
 
-	up := &productionUploader{}
+	up := &productionUploader{
+		acl:          conf.ACL,
+		cacheControl: strings.Join(conf.CacheControl, ", "),
+	}
 	if err := up.Open(ctx, bucketURL); err != nil {
 		return handleError(err, bucketURL)
 	}
@@ -199,7 +196,9 @@ type uploader interface {
 
 // productionUploader actually do upload to.
 type productionUploader struct {
-	bucket *blob.Bucket
+	bucket       *blob.Bucket
+	acl          string
+	cacheControl string
 }
 
 func (u *productionUploader) Close() error {
@@ -222,13 +221,32 @@ func (u *productionUploader) Open(ctx *context.Context, bucket string) error {
 	return nil
 }
 
+func (u *productionUploader) writerOptions(filepath string) *blob.WriterOptions {
+	return &blob.WriterOptions{
+		ContentDisposition: "attachment; filename=" + path.Base(filepath),
+		CacheControl:       u.cacheControl,
+		BeforeWrite: func(asFunc func(interface{}) bool) error {
+			if u.acl == "" {
+				return nil
+			}
+			// s3 takes a canned ACL, gcs a predefined ACL.
+			var input *s3manager.UploadInput
+			if asFunc(&input) {
+				input.ACL = aws.String(u.acl)
+			}
+			var writer *storage.Writer
+			if asFunc(&writer) {
+				writer.PredefinedACL = u.acl
+			}
+			return nil
+		},
+	}
+}
+
 func (u *productionUploader) Upload(ctx *context.Context, filepath string, data []byte) error {
 	log.WithField("path", filepath).Info("uploading")
 
-	opts := &blob.WriterOptions{
-		ContentDisposition: "attachment; filename=" + path.Base(filepath),
-	}
-	w, err := u.bucket.NewWriter(ctx, filepath, opts)
+	w, err := u.bucket.NewWriter(ctx, filepath, u.writerOptions(filepath))
 	if err != nil {
 		return err
 	}
@@ -4,12 +4,16 @@ package build
 
 import (
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/apex/log"
 	"github.com/caarlos0/go-shellwords"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/builders/common"
 	"github.com/goreleaser/goreleaser/internal/ids"
 	"github.com/goreleaser/goreleaser/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/internal/shell"
@@ -20,6 +24,11 @@ import (
 
 	// langs to init.
 	_ "github.com/goreleaser/goreleaser/internal/builders/golang"
+	_ "github.com/goreleaser/goreleaser/internal/builders/js"
+	_ "github.com/goreleaser/goreleaser/internal/builders/prebuilt"
+	_ "github.com/goreleaser/goreleaser/internal/builders/python"
+	_ "github.com/goreleaser/goreleaser/internal/builders/rust"
+	_ "github.com/goreleaser/goreleaser/internal/builders/zig"
 )
 
 // Pipe for build.
@@ -31,21 +40,36 @@ func (Pipe) String() string {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
+	// all builds share the same group, so parallelism applies to the total
+	// amount of targets being built at once.
+	g := semerrgroup.New(ctx.Parallelism)
 	for _, build := range ctx.Config.Builds {
-		if build.Skip {
+		skip, err := tmpl.New(ctx).Apply(build.Skip)
+		if err != nil {
+			return fmt.Errorf("invalid skip template for build %s: %w", build.ID, err)
+		}
+		if skip == "true" {
 			log.WithField("id", build.ID).Info("skip is set")
 			continue
 		}
 		log.WithField("build", build).Debug("building")
-		if err := runPipeOnBuild(ctx, build); err != nil {
+		if err := scheduleBuild(ctx, g, build); err != nil {
 			return err
 		}
 	}
-	return nil
+	return g.Wait()
 }
 
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
+	if len(ctx.Config.Builds) == 0 {
+		ctx.Config.Builds = []config.Build{ctx.Config.SingleBuild}
+	}
+	builds, err := expandMains(ctx.Config.Builds)
+	if err != nil {
+		return err
+	}
+	ctx.Config.Builds = builds
 	ids := ids.New("builds")
 	for i, build := range ctx.Config.Builds {
 		build, err := buildWithDefaults(ctx, build)
@@ -55,14 +79,71 @@ func (Pipe) Default(ctx *context.Context) error {
 		ctx.Config.Builds[i] = build
 		ids.Inc(ctx.Config.Builds[i].ID)
 	}
-	if len(ctx.Config.Builds) == 0 {
-		build, err := buildWithDefaults(ctx, ctx.Config.SingleBuild)
+	return ids.Validate()
+}
+
+// expandMains replaces go builds whose main is a glob (e.g. `./cmd/*`) with
+// one build for each main package it matches.
+// The id and binary of each build default to the name of its directory.
+func expandMains(builds []config.Build) ([]config.Build, error) {
+	var result []config.Build
+	for _, build := range builds {
+		if (build.Builder != "" && build.Builder != "go") ||
+			!strings.ContainsAny(build.Main, "*?[") {
+			result = append(result, build)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(build.Dir, build.Main))
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("invalid main: %s: %w", build.Main, err)
+		}
+		var found bool
+		for _, match := range matches {
+			isMain, err := isMainPackage(match)
+			if err != nil {
+				return nil, err
+			}
+			if !isMain {
+				continue
+			}
+			main, err := filepath.Rel(filepath.Join(build.Dir, "."), match)
+			if err != nil {
+				return nil, err
+			}
+			name := filepath.Base(match)
+			expanded := build
+			if expanded.ID == "" {
+				expanded.ID = name
+			}
+			if expanded.Binary == "" {
+				expanded.Binary = name
+			}
+			expanded.Main = "./" + filepath.ToSlash(main)
+			log.WithField("main", expanded.Main).Debug("found main package")
+			result = append(result, expanded)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("no main packages found matching %s", build.Main)
 		}
-		ctx.Config.Builds = []config.Build{build}
 	}
-	return ids.Validate()
+	return result, nil
+}
+
+func isMainPackage(dir string) (bool, error) {
+	stat, err := os.Stat(dir)
+	if err != nil {
+		return false, err
+	}
+	if !stat.IsDir() {
+		return false, nil
+	}
+	packs, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse dir: %s: %w", dir, err)
+	}
+	_, ok := packs["main"]
+	return ok, nil
 }
 
 func buildWithDefaults(ctx *context.Context, build config.Build) (config.Build, error) {
@@ -75,14 +156,44 @@ func buildWithDefaults(ctx *context.Context, build config.Build) (config.Build,
 	if build.ID == "" {
 		build.ID = ctx.Config.ProjectName
 	}
+	if build.GoBinary != "" {
+		gobin, err := tmpl.New(ctx).Apply(build.GoBinary)
+		if err != nil {
+			return build, err
+		}
+		build.GoBinary = gobin
+	}
 	for k, v := range build.Env {
 		build.Env[k] = os.ExpandEnv(v)
 	}
+	for _, o := range build.BuildDetailsOverrides {
+		for k, v := range o.Env {
+			o.Env[k] = os.ExpandEnv(v)
+		}
+	}
 	return builders.For(build.Builder).WithDefaults(build)
 }
 
 func runPipeOnBuild(ctx *context.Context, build config.Build) error {
 	g := semerrgroup.New(ctx.Parallelism)
+	if err := scheduleBuild(ctx, g, build); err != nil {
+		return err
+	}
+	return g.Wait()
+}
+
+// scheduleBuild adds the build of each target of the given build to the
+// given group.
+func scheduleBuild(ctx *context.Context, g semerrgroup.Group, build config.Build) error {
+	var sources string
+	if ctx.Incremental {
+		hash, err := sourceHash(ctx, build)
+		if err != nil {
+			return err
+		}
+		sources = hash
+	}
+
 	for _, target := range build.Targets {
 		target := target
 		build := build
@@ -91,50 +202,83 @@ func runPipeOnBuild(ctx *context.Context, build config.Build) error {
 			if err != nil {
 				return err
 			}
+			// hooks and builder share the build with the target overrides
+			// applied.
+			build, err := common.WithOverrides(ctx, build, *opts)
+			if err != nil {
+				return err
+			}
 
-			if err := runHook(ctx, *opts, build.Env, build.Hooks.Pre); err != nil {
+			if err := runHook(ctx, build, *opts, nil, build.Hooks.Pre); err != nil {
 				return fmt.Errorf("pre hook failed: %w", err)
 			}
-			if err := doBuild(ctx, build, *opts); err != nil {
+			if err := doIncrementalBuild(ctx, build, *opts, sources); err != nil {
 				return err
 			}
 			if !ctx.SkipPostBuildHooks {
-				if err := runHook(ctx, *opts, build.Env, build.Hooks.Post); err != nil {
+				if err := runHook(ctx, build, *opts, builtArtifact(ctx, *opts), build.Hooks.Post); err != nil {
 					return fmt.Errorf("post hook failed: %w", err)
 				}
 			}
 			return nil
 		})
 	}
+	return nil
+}
 
-	return g.Wait()
+// builtArtifact returns the binary artifact built with the given options, if
+// any.
+func builtArtifact(ctx *context.Context, opts builders.Options) *artifact.Artifact {
+	arts := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.Binary),
+		func(a *artifact.Artifact) bool {
+			return a.Path == opts.Path
+		},
+	)).List()
+	if len(arts) == 0 {
+		return nil
+	}
+	return arts[0]
 }
 
-func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hooks config.Hooks) error {
+func runHook(ctx *context.Context, build config.Build, opts builders.Options, built *artifact.Artifact, hooks config.Hooks) error {
 	if len(hooks) == 0 {
 		return nil
 	}
 
+	newTmpl := func() *tmpl.Template {
+		t := tmpl.New(ctx).WithBuildOptions(opts)
+		if built != nil {
+			// post hooks also have the built binary available.
+			t = t.WithArtifact(built, map[string]string{})
+		}
+		return t
+	}
+
 	for _, hook := range hooks {
 		var env []string
 
 		env = append(env, ctx.Env.Strings()...)
-		env = append(env, buildEnv...)
+		env = append(env, build.Env...)
 
 		for _, rawEnv := range hook.Env {
-			e, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(rawEnv)
+			e, err := newTmpl().Apply(rawEnv)
 			if err != nil {
 				return err
 			}
 			env = append(env, e)
 		}
 
-		dir, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(hook.Dir)
+		dir, err := newTmpl().Apply(hook.Dir)
 		if err != nil {
 			return err
 		}
+		if dir == "" {
+			// hooks run in the same directory as the build by default.
+			dir = build.Dir
+		}
 
-		sh, err := tmpl.New(ctx).WithBuildOptions(opts).
+		sh, err := newTmpl().
 			WithEnvS(env).
 			Apply(hook.Cmd)
 		if err != nil {
@@ -148,6 +292,10 @@ func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hoo
 		}
 
 		if err := shell.Run(ctx, dir, cmd, env, hook.Output); err != nil {
+			if hook.AllowFailure {
+				log.WithError(err).Warn("hook failed, ignoring")
+				continue
+			}
 			return err
 		}
 	}
@@ -159,8 +307,35 @@ func doBuild(ctx *context.Context, build config.Build, opts builders.Options) er
 	return builders.For(build.Builder).Build(ctx, build, opts)
 }
 
+// doIncrementalBuild builds the given target, unless incremental builds are
+// enabled and nothing changed since the previous run.
+func doIncrementalBuild(ctx *context.Context, build config.Build, opts builders.Options, sources string) error {
+	if !ctx.Incremental {
+		return doBuild(ctx, build, opts)
+	}
+	hash, err := targetHash(ctx, build, opts, sources)
+	if err != nil {
+		return err
+	}
+	restored, err := restoreBuild(ctx, build, opts, hash)
+	if err != nil {
+		return err
+	}
+	if restored {
+		return nil
+	}
+	if err := doBuild(ctx, build, opts); err != nil {
+		return err
+	}
+	return cacheBuild(ctx, build, opts, hash)
+}
+
 func buildOptionsForTarget(ctx *context.Context, build config.Build, target string) (*builders.Options, error) {
-	ext := extFor(target, build.Flags)
+	flags := build.Flags
+	if build.Buildmode != "" {
+		flags = append(config.FlagArray{"-buildmode=" + build.Buildmode}, flags...)
+	}
+	ext := extFor(target, flags)
 	parts := strings.Split(target, "_")
 	if len(parts) < 2 {
 		return nil, fmt.Errorf("%s is not a valid build target", target)
@@ -172,7 +347,10 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 	var gomips string
 	var goarm string
 	var goamd64 string
-	if strings.HasPrefix(goarch, "arm") && len(parts) > 2 {
+	var goarm64 string
+	if goarch == "arm64" && len(parts) > 2 {
+		goarm64 = parts[2]
+	} else if strings.HasPrefix(goarch, "arm") && len(parts) > 2 {
 		goarm = parts[2]
 	}
 	if strings.HasPrefix(goarch, "mips") && len(parts) > 2 {
@@ -190,6 +368,7 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 		Goarm:   goarm,
 		Gomips:  gomips,
 		Goamd64: goamd64,
+		Goarm64: goarm64,
 	}
 
 	binary, err := tmpl.New(ctx).WithBuildOptions(buildOpts).Apply(build.Binary)
@@ -198,8 +377,18 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 	}
 
 	build.Binary = binary
-	name := build.Binary + ext
+	name := build.Binary
+	if !strings.HasSuffix(name, ext) {
+		// e.g. `libfoo.so` on c-shared builds already has its extension.
+		name += ext
+	}
 	dir := fmt.Sprintf("%s_%s", build.ID, target)
+	if build.DistDirTemplate != "" {
+		dir, err = tmpl.New(ctx).WithBuildOptions(buildOpts).Apply(build.DistDirTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dist_dir_template: %w", err)
+		}
+	}
 	if build.NoUniqueDistDir {
 		dir = ""
 	}
@@ -216,15 +405,25 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 }
 
 func extFor(target string, flags config.FlagArray) string {
-	if strings.Contains(target, "windows") {
-		for _, s := range flags {
-			if s == "-buildmode=c-shared" {
+	windows := strings.Contains(target, "windows")
+	for _, s := range flags {
+		switch s {
+		case "-buildmode=c-shared":
+			if windows {
 				return ".dll"
 			}
-			if s == "-buildmode=c-archive" {
+			if strings.HasPrefix(target, "darwin") {
+				return ".dylib"
+			}
+			return ".so"
+		case "-buildmode=c-archive":
+			if windows {
 				return ".lib"
 			}
+			return ".a"
 		}
+	}
+	if windows {
 		return ".exe"
 	}
 	if target == "js_wasm" {
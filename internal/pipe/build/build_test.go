@@ -2,9 +2,12 @@ package build
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/testlib"
@@ -72,8 +75,8 @@ func TestBuild(t *testing.T) {
 				Binary:  "testing.v{{.Version}}",
 				BuildDetails: config.BuildDetails{
 					Flags: []string{"-n"},
+					Env:   []string{"BLAH=1"},
 				},
-				Env: []string{"BLAH=1"},
 			},
 		},
 	}
@@ -245,8 +248,10 @@ func TestDefaultExpandEnv(t *testing.T) {
 		Config: config.Project{
 			Builds: []config.Build{
 				{
-					Env: []string{
-						"XFOO=bar_$XBAR",
+					BuildDetails: config.BuildDetails{
+						Env: []string{
+							"XFOO=bar_$XBAR",
+						},
 					},
 				},
 			},
@@ -257,6 +262,30 @@ func TestDefaultExpandEnv(t *testing.T) {
 	require.Equal(t, "XFOO=bar_FOOBAR", env)
 }
 
+func TestDefaultGoBinaryTemplate(t *testing.T) {
+	ctx := context.New(config.Project{
+		Env: []string{"GO_BIN=go"},
+		Builds: []config.Build{
+			{
+				GoBinary: "{{ .Env.GO_BIN }}",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "go", ctx.Config.Builds[0].GoBinary)
+}
+
+func TestDefaultInvalidGoBinaryTemplate(t *testing.T) {
+	ctx := context.New(config.Project{
+		Builds: []config.Build{
+			{
+				GoBinary: "{{ .Env.GO_BIN }",
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `template: tmpl:1: unexpected "}" in operand`)
+}
+
 func TestDefaultEmptyBuild(t *testing.T) {
 	ctx := &context.Context{
 		Config: config.Project{
@@ -281,6 +310,80 @@ func TestDefaultEmptyBuild(t *testing.T) {
 	require.Equal(t, "-s -w -X main.version={{.Version}} -X main.commit={{.Commit}} -X main.date={{.Date}} -X main.builtBy=goreleaser", build.Ldflags[0])
 }
 
+func TestDefaultExpandMains(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	for name, pkg := range map[string]string{
+		"foo":      "main",
+		"bar":      "main",
+		"internal": "internal",
+	} {
+		dir := filepath.Join(folder, "cmd", name)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package "+pkg+"\n"), 0o644))
+	}
+
+	t.Run("expand", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Builds: []config.Build{
+				{Main: "./cmd/*"},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Len(t, ctx.Config.Builds, 2)
+		require.Equal(t, "bar", ctx.Config.Builds[0].ID)
+		require.Equal(t, "bar", ctx.Config.Builds[0].Binary)
+		require.Equal(t, "./cmd/bar", ctx.Config.Builds[0].Main)
+		require.Equal(t, "foo", ctx.Config.Builds[1].ID)
+		require.Equal(t, "foo", ctx.Config.Builds[1].Binary)
+		require.Equal(t, "./cmd/foo", ctx.Config.Builds[1].Main)
+	})
+
+	t.Run("keeps binary", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Builds: []config.Build{
+				{Main: "./cmd/*", Binary: "{{ .ProjectName }}_bin"},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Len(t, ctx.Config.Builds, 2)
+		require.Equal(t, "bar", ctx.Config.Builds[0].ID)
+		require.Equal(t, "{{ .ProjectName }}_bin", ctx.Config.Builds[0].Binary)
+		require.Equal(t, "foo", ctx.Config.Builds[1].ID)
+		require.Equal(t, "{{ .ProjectName }}_bin", ctx.Config.Builds[1].Binary)
+	})
+
+	t.Run("keeps id", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Builds: []config.Build{
+				{Main: "./cmd/*", ID: "mine"},
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "found 2 builds with the ID 'mine', please fix your config")
+	})
+
+	t.Run("single build", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			SingleBuild: config.Build{Main: "./cmd/*"},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Len(t, ctx.Config.Builds, 2)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Builds: []config.Build{
+				{Main: "./nope/*"},
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "no main packages found matching ./nope/*")
+	})
+}
+
 func TestDefaultBuildID(t *testing.T) {
 	ctx := &context.Context{
 		Config: config.Project{
@@ -413,16 +516,49 @@ func TestSkipBuild(t *testing.T) {
 		Dist: folder,
 		Builds: []config.Build{
 			{
-				Skip: true,
+				Skip: "true",
+			},
+		},
+	}
+	ctx := context.New(config)
+	ctx.Git.CurrentTag = "2.4.5"
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Len(t, ctx.Artifacts.List(), 0)
+}
+
+func TestSkipBuildTemplate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	config := config.Project{
+		Dist: folder,
+		Builds: []config.Build{
+			{
+				Skip: "{{ .IsSnapshot }}",
 			},
 		},
 	}
 	ctx := context.New(config)
 	ctx.Git.CurrentTag = "2.4.5"
+	ctx.Snapshot = true
 	require.NoError(t, Pipe{}.Run(ctx))
 	require.Len(t, ctx.Artifacts.List(), 0)
 }
 
+func TestSkipBuildInvalidTemplate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	config := config.Project{
+		Dist: folder,
+		Builds: []config.Build{
+			{
+				ID:   "foo",
+				Skip: "{{ .IsSnapshot }",
+			},
+		},
+	}
+	ctx := context.New(config)
+	ctx.Git.CurrentTag = "2.4.5"
+	require.EqualError(t, Pipe{}.Run(ctx), `invalid skip template for build foo: template: tmpl:1: unexpected "}" in operand`)
+}
+
 func TestExtWindows(t *testing.T) {
 	require.Equal(t, ".exe", extFor("windows_amd64", config.FlagArray{}))
 	require.Equal(t, ".exe", extFor("windows_386", config.FlagArray{}))
@@ -433,6 +569,15 @@ func TestExtWindows(t *testing.T) {
 	require.Equal(t, ".lib", extFor("windows_386", config.FlagArray{"-tags=dev", "-v", "-buildmode=c-archive"}))
 }
 
+func TestExtBuildmode(t *testing.T) {
+	require.Equal(t, ".so", extFor("linux_amd64", config.FlagArray{"-buildmode=c-shared"}))
+	require.Equal(t, ".dylib", extFor("darwin_arm64", config.FlagArray{"-buildmode=c-shared"}))
+	require.Equal(t, ".a", extFor("linux_amd64", config.FlagArray{"-buildmode=c-archive"}))
+	require.Equal(t, ".a", extFor("darwin_arm64", config.FlagArray{"-v", "-buildmode=c-archive"}))
+	require.Empty(t, extFor("linux_amd64", config.FlagArray{"-buildmode=pie"}))
+	require.Equal(t, ".exe", extFor("windows_amd64", config.FlagArray{"-buildmode=pie"}))
+}
+
 func TestExtWasm(t *testing.T) {
 	require.Equal(t, ".wasm", extFor("js_wasm", config.FlagArray{}))
 }
@@ -528,6 +673,76 @@ func TestPipeOnBuild_hooksRunPerTarget(t *testing.T) {
 	require.FileExists(t, filepath.Join(tmpDir, "post-hook-windows_amd64"))
 }
 
+func TestPipeOnBuild_hooksSeeOverridesEnv(t *testing.T) {
+	tmpDir := testlib.Mktmp(t)
+	build := config.Build{
+		Builder: "fake",
+		Binary:  "testing",
+		Targets: []string{
+			"linux_amd64",
+			"darwin_amd64",
+		},
+		BuildDetails: config.BuildDetails{
+			Env: []string{"HOOK_OS=default"},
+		},
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos:   "linux",
+				Goarch: "amd64",
+				BuildDetails: config.BuildDetails{
+					Env: []string{"HOOK_OS=linux"},
+				},
+			},
+		},
+		Hooks: config.BuildHookConfig{
+			Pre: []config.Hook{
+				{Cmd: "touch pre-hook-{{.Env.HOOK_OS}}", Dir: tmpDir},
+			},
+		},
+	}
+	ctx := context.New(config.Project{
+		Builds: []config.Build{
+			build,
+		},
+	})
+	require.NoError(t, runPipeOnBuild(ctx, build))
+	require.FileExists(t, filepath.Join(tmpDir, "pre-hook-linux"))
+	require.FileExists(t, filepath.Join(tmpDir, "pre-hook-default"))
+}
+
+func TestPipeOnBuild_overridesEnvOnOtherBuilders(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	build := config.Build{
+		ID:      "foo",
+		Builder: "rust",
+		Binary:  "foo",
+		Tool:    testlib.FakeTool(t),
+		Targets: []string{"linux_arm64"},
+		BuildDetails: config.BuildDetails{
+			Env: []string{"PROFILE=debug"},
+		},
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos:   "linux",
+				Goarch: "arm64",
+				BuildDetails: config.BuildDetails{
+					Env: []string{
+						"PROFILE=release",
+						testlib.FakeToolOutputEnv + "=target/aarch64-unknown-linux-gnu/{{ .Env.PROFILE }}/foo",
+					},
+				},
+			},
+		},
+	}
+	ctx := context.New(config.Project{
+		Dist:   filepath.Join(folder, "dist"),
+		Builds: []config.Build{build},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, runPipeOnBuild(ctx, ctx.Config.Builds[0]))
+	require.FileExists(t, filepath.Join(folder, "dist", "foo_linux_arm64", "foo"))
+}
+
 func TestPipeOnBuild_invalidBinaryTpl(t *testing.T) {
 	build := config.Build{
 		Builder: "fake",
@@ -572,6 +787,46 @@ func TestBuildOptionsForTarget(t *testing.T) {
 				Goamd64: "v1",
 			},
 		},
+		{
+			name: "c-shared buildmode",
+			build: config.Build{
+				ID:        "testid",
+				Binary:    "libtest",
+				Buildmode: "c-shared",
+				Targets: []string{
+					"linux_amd64",
+				},
+			},
+			expectedOpts: &api.Options{
+				Name:    "libtest.so",
+				Path:    filepath.Join(tmpDir, "testid_linux_amd64_v1", "libtest.so"),
+				Ext:     ".so",
+				Target:  "linux_amd64_v1",
+				Goos:    "linux",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+			},
+		},
+		{
+			name: "c-shared buildmode with extension",
+			build: config.Build{
+				ID:        "testid",
+				Binary:    "libtest.so",
+				Buildmode: "c-shared",
+				Targets: []string{
+					"linux_amd64",
+				},
+			},
+			expectedOpts: &api.Options{
+				Name:    "libtest.so",
+				Path:    filepath.Join(tmpDir, "testid_linux_amd64_v1", "libtest.so"),
+				Ext:     ".so",
+				Target:  "linux_amd64_v1",
+				Goos:    "linux",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+			},
+		},
 		{
 			name: "binary name with Os and Arch template variables",
 			build: config.Build{
@@ -645,6 +900,24 @@ func TestBuildOptionsForTarget(t *testing.T) {
 				Gomips: "softfloat",
 			},
 		},
+		{
+			name: "with goarm64",
+			build: config.Build{
+				ID:     "testid",
+				Binary: "testbinary",
+				Targets: []string{
+					"linux_arm64_v8.2",
+				},
+			},
+			expectedOpts: &api.Options{
+				Name:    "testbinary",
+				Path:    filepath.Join(tmpDir, "testid_linux_arm64_v8.2", "testbinary"),
+				Target:  "linux_arm64_v8.2",
+				Goos:    "linux",
+				Goarch:  "arm64",
+				Goarm64: "v8.2",
+			},
+		},
 		{
 			name: "with goamd64",
 			build: config.Build{
@@ -663,6 +936,37 @@ func TestBuildOptionsForTarget(t *testing.T) {
 				Goamd64: "v3",
 			},
 		},
+		{
+			name: "dist dir template",
+			build: config.Build{
+				ID:              "testid",
+				Binary:          "testbinary",
+				DistDirTemplate: "{{ .Os }}/{{ .Target }}",
+				Targets: []string{
+					"linux_amd64",
+				},
+			},
+			expectedOpts: &api.Options{
+				Name:    "testbinary",
+				Path:    filepath.Join(tmpDir, "linux", "linux_amd64_v1", "testbinary"),
+				Target:  "linux_amd64_v1",
+				Goos:    "linux",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+			},
+		},
+		{
+			name: "invalid dist dir template",
+			build: config.Build{
+				ID:              "testid",
+				Binary:          "testbinary",
+				DistDirTemplate: "{{ .Os }",
+				Targets: []string{
+					"linux_amd64",
+				},
+			},
+			expectedErr: `invalid dist_dir_template: template: tmpl:1: unexpected "}" in operand`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -705,6 +1009,114 @@ func TestRunHookFailWithLogs(t *testing.T) {
 	}
 	ctx := context.New(config)
 	ctx.Git.CurrentTag = "2.4.5"
-	require.EqualError(t, Pipe{}.Run(ctx), "pre hook failed: failed to run 'sh -c echo foo; exit 1': exit status 1")
+	require.EqualError(t, Pipe{}.Run(ctx), "pre hook failed: failed to run 'sh -c echo foo; exit 1': exit status 1: foo")
 	require.Empty(t, ctx.Artifacts.List())
 }
+
+func TestRunPostHookWithArtifact(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := context.New(config.Project{})
+	opts := api.Options{
+		Target: "linux_amd64_v1",
+		Name:   "bin",
+		Path:   filepath.Join(folder, "dist", "bin"),
+		Goos:   "linux",
+		Goarch: "amd64",
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Binary,
+		Name: "bin",
+		Path: filepath.Join(folder, "dist", "other"),
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "other",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:   artifact.Binary,
+		Name:   "bin",
+		Path:   opts.Path,
+		Goos:   "linux",
+		Goarch: "amd64",
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "bin",
+		},
+	})
+
+	built := builtArtifact(ctx, opts)
+	require.NotNil(t, built)
+	require.Equal(t, opts.Path, built.Path)
+	require.Nil(t, builtArtifact(ctx, api.Options{Path: "nope"}))
+
+	require.NoError(t, runHook(ctx, config.Build{}, opts, built, config.Hooks{
+		{Cmd: "touch {{ .Binary }}_{{ .Target }}_{{ .ArtifactName }}", Dir: folder},
+	}))
+	require.FileExists(t, filepath.Join(folder, "bin_linux_amd64_v1_bin"))
+}
+
+func TestRunHookInheritsBuildDir(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	require.NoError(t, os.Mkdir(filepath.Join(folder, "service"), 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(folder, "other"), 0o755))
+	ctx := context.New(config.Project{})
+	build := config.Build{Dir: "service"}
+
+	require.NoError(t, runHook(ctx, build, api.Options{}, nil, config.Hooks{
+		{Cmd: "touch from-build-dir"},
+		{Cmd: "touch from-hook-dir", Dir: "other"},
+	}))
+	require.FileExists(t, filepath.Join(folder, "service", "from-build-dir"))
+	require.FileExists(t, filepath.Join(folder, "other", "from-hook-dir"))
+}
+
+func TestRunHookAllowFailure(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	ctx := context.New(config.Project{})
+
+	require.NoError(t, runHook(ctx, config.Build{}, api.Options{}, nil, config.Hooks{
+		{Cmd: "sh -c 'exit 1'", AllowFailure: true},
+		{Cmd: "touch after-failure"},
+	}))
+	require.FileExists(t, filepath.Join(folder, "after-failure"))
+
+	require.EqualError(t, runHook(ctx, config.Build{}, api.Options{}, nil, config.Hooks{
+		{Cmd: "sh -c 'exit 1'"},
+	}), "failed to run 'sh -c exit 1': exit status 1")
+}
+
+type barrierBuilder struct {
+	running sync.WaitGroup
+}
+
+func (*barrierBuilder) WithDefaults(build config.Build) (config.Build, error) {
+	return build, nil
+}
+
+// Build only returns once all the expected builds are running at once.
+func (b *barrierBuilder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	b.running.Done()
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(5 * time.Second):
+		return fmt.Errorf("%s did not build concurrently with the other builds", build.ID)
+	}
+}
+
+func TestRunPipeBuildsConcurrently(t *testing.T) {
+	builder := &barrierBuilder{}
+	builder.running.Add(2)
+	api.Register("barrier", builder)
+	ctx := context.New(config.Project{
+		Builds: []config.Build{
+			{ID: "foo", Builder: "barrier", Binary: "foo", Targets: []string{"linux_amd64"}},
+			{ID: "bar", Builder: "barrier", Binary: "bar", Targets: []string{"linux_amd64"}},
+		},
+	})
+	ctx.Parallelism = 2
+	require.NoError(t, Pipe{}.Run(ctx))
+}
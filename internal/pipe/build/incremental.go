@@ -0,0 +1,195 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/builders/common"
+	"github.com/goreleaser/goreleaser/internal/gio"
+	"github.com/goreleaser/goreleaser/internal/pipe/dist"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const (
+	cacheHashFile   = "hash"
+	cacheBinaryFile = "binary"
+)
+
+// sourceHash returns a hash of all the files inside the build dir, ignoring
+// the dist folder and the .git directory.
+func sourceHash(ctx *context.Context, build config.Build) (string, error) {
+	dir := build.Dir
+	if dir == "" {
+		dir = "."
+	}
+	distDir, err := filepath.Abs(ctx.Config.Dist)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if info.Name() == ".git" || abs == distDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fmt.Fprintf(h, "%s\x00%o\x00", filepath.ToSlash(path), info.Mode())
+		_, err = io.Copy(h, f)
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("failed to hash sources: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// targetHash returns a hash of the sources and of everything that might
+// change the output of the given build target, including the evaluated
+// templates and the go toolchain version.
+func targetHash(ctx *context.Context, build config.Build, opts builders.Options, sources string) (string, error) {
+	env := ctx.Env.Strings()
+	sort.Strings(env)
+	flags, err := evaluatedFlags(ctx, build, opts)
+	if err != nil {
+		return "", err
+	}
+	toolchain, err := toolchainVersion(ctx, build)
+	if err != nil {
+		return "", err
+	}
+	bts, err := json.Marshal(struct {
+		Sources   string
+		Build     config.Build
+		Options   builders.Options
+		Env       []string
+		Flags     [][]string
+		Toolchain string
+	}{sources, build, opts, env, flags, toolchain})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bts)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// evaluatedFlags templates the build env and flags the same way the builders
+// do, so e.g. a new version or commit in the ldflags changes the hash.
+func evaluatedFlags(ctx *context.Context, build config.Build, opts builders.Options) ([][]string, error) {
+	a := common.Artifact(build, opts)
+	hostEnv := builders.HostEnv(ctx, build)
+	sort.Strings(hostEnv)
+	env, err := common.TemplateEnv(ctx, hostEnv, build.Env, a)
+	if err != nil {
+		return nil, err
+	}
+	result := [][]string{env}
+	for _, flags := range [][]string{
+		build.Flags,
+		build.Ldflags,
+		build.Asmflags,
+		build.Gcflags,
+		build.Tags,
+	} {
+		evaluated, err := common.Flags(ctx, env, a, flags)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, evaluated)
+	}
+	return result, nil
+}
+
+// toolchainVersion returns the output of `go version` for go builds, so
+// upgrading go does not reuse binaries built by the previous version.
+func toolchainVersion(ctx *context.Context, build config.Build) (string, error) {
+	if build.Builder != "go" || build.BuildIn != "" {
+		return "", nil
+	}
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, build.GoBinary, "version")
+	cmd.Dir = build.Dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the go version: %w: %s", err, string(out))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func cachePath(ctx *context.Context, build config.Build, opts builders.Options) string {
+	return filepath.Join(ctx.Config.Dist, dist.CacheDir, "builds", build.ID+"_"+opts.Target)
+}
+
+// restoreBuild copies the binary of a previous build of the given target
+// into place, if its hash matches the given one.
+func restoreBuild(ctx *context.Context, build config.Build, opts builders.Options, hash string) (bool, error) {
+	dir := cachePath(ctx, build, opts)
+	previous, err := os.ReadFile(filepath.Join(dir, cacheHashFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if string(previous) != hash {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.Path), 0o755); err != nil {
+		return false, err
+	}
+	if err := gio.Copy(filepath.Join(dir, cacheBinaryFile), opts.Path); err != nil {
+		return false, err
+	}
+
+	log.WithField("target", opts.Target).Info("nothing changed, reusing previous build")
+	ctx.Artifacts.Add(common.Artifact(build, opts))
+	return true, nil
+}
+
+// cacheBuild stores the binary built for the given target, so it can be
+// reused by the next run if nothing changes.
+func cacheBuild(ctx *context.Context, build config.Build, opts builders.Options, hash string) error {
+	built := builtArtifact(ctx, opts)
+	if built == nil {
+		return nil
+	}
+	dir := cachePath(ctx, build, opts)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := gio.Copy(built.Path, filepath.Join(dir, cacheBinaryFile)); err != nil {
+		return err
+	}
+	// the hash is written last, so a partially written cache is never used.
+	return os.WriteFile(filepath.Join(dir, cacheHashFile), []byte(hash), 0o644)
+}
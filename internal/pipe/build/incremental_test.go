@@ -0,0 +1,140 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	api "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+type countingBuilder struct {
+	builds int32
+}
+
+func (*countingBuilder) WithDefaults(build config.Build) (config.Build, error) {
+	return build, nil
+}
+
+func (c *countingBuilder) Build(ctx *context.Context, build config.Build, options api.Options) error {
+	atomic.AddInt32(&c.builds, 1)
+	if err := os.MkdirAll(filepath.Dir(options.Path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(options.Path, []byte("foo"), 0o755); err != nil {
+		return err
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Binary,
+		Name: options.Name,
+		Path: options.Path,
+	})
+	return nil
+}
+
+func TestIncrementalBuild(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	builder := &countingBuilder{}
+	api.Register("counting", builder)
+
+	require.NoError(t, os.WriteFile("main.go", []byte("package main"), 0o644))
+
+	newCtx := func() *context.Context {
+		ctx := context.New(config.Project{
+			Dist: filepath.Join(folder, "dist"),
+			Builds: []config.Build{
+				{
+					ID:      "foo",
+					Builder: "counting",
+					Binary:  "foo",
+					Targets: []string{"linux_amd64", "darwin_arm64"},
+				},
+			},
+		})
+		ctx.Incremental = true
+		return ctx
+	}
+
+	ctx := newCtx()
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, int32(2), builder.builds)
+
+	t.Run("nothing changed", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(filepath.Join(folder, "dist", "foo_linux_amd64")))
+		ctx := newCtx()
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, int32(2), builder.builds)
+		require.FileExists(t, filepath.Join(folder, "dist", "foo_linux_amd64", "foo"))
+
+		bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+		require.Len(t, bins, 2)
+		for _, bin := range bins {
+			require.Equal(t, "foo", bin.ExtraOr(artifact.ExtraID, ""))
+		}
+	})
+
+	t.Run("sources changed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("main.go", []byte("package main\n"), 0o644))
+		require.NoError(t, Pipe{}.Run(newCtx()))
+		require.Equal(t, int32(4), builder.builds)
+	})
+
+	t.Run("settings changed", func(t *testing.T) {
+		ctx := newCtx()
+		ctx.Config.Builds[0].Targets = []string{"linux_amd64"}
+		ctx.Config.Builds[0].Env = []string{"FOO=bar"}
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, int32(5), builder.builds)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := newCtx()
+		ctx.Incremental = false
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, int32(7), builder.builds)
+	})
+
+	t.Run("templates changed", func(t *testing.T) {
+		newVersionCtx := func(version string) *context.Context {
+			ctx := newCtx()
+			ctx.Version = version
+			ctx.Config.Builds[0].Ldflags = []string{"-X main.version={{ .Version }}"}
+			return ctx
+		}
+		require.NoError(t, Pipe{}.Run(newVersionCtx("1.0.0")))
+		require.Equal(t, int32(9), builder.builds)
+		require.NoError(t, Pipe{}.Run(newVersionCtx("1.0.0")))
+		require.Equal(t, int32(9), builder.builds)
+		require.NoError(t, Pipe{}.Run(newVersionCtx("1.1.0")))
+		require.Equal(t, int32(11), builder.builds)
+	})
+}
+
+func TestToolchainVersion(t *testing.T) {
+	gobin := filepath.Join(t.TempDir(), "go")
+	require.NoError(t, os.WriteFile(gobin, []byte("#!/bin/sh\necho go version go1.99.0 linux/amd64\n"), 0o755))
+	ctx := context.New(config.Project{})
+
+	t.Run("go", func(t *testing.T) {
+		version, err := toolchainVersion(ctx, config.Build{Builder: "go", GoBinary: gobin})
+		require.NoError(t, err)
+		require.Equal(t, "go version go1.99.0 linux/amd64", version)
+	})
+
+	t.Run("other builder", func(t *testing.T) {
+		version, err := toolchainVersion(ctx, config.Build{Builder: "rust", GoBinary: gobin})
+		require.NoError(t, err)
+		require.Empty(t, version)
+	})
+
+	t.Run("broken go", func(t *testing.T) {
+		_, err := toolchainVersion(ctx, config.Build{Builder: "go", GoBinary: filepath.Join(t.TempDir(), "nope")})
+		require.ErrorContains(t, err, "failed to get the go version")
+	})
+}
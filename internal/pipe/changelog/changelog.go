@@ -43,13 +43,18 @@ func (Pipe) Skip(ctx *context.Context) bool { return ctx.Config.Changelog.Skip |
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	notes, err := loadContent(ctx, ctx.ReleaseNotesFile, ctx.ReleaseNotesTmpl)
+	notesFile, err := releaseNotesFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	notes, err := loadContent(ctx, notesFile, ctx.ReleaseNotesTmpl)
 	if err != nil {
 		return err
 	}
 	ctx.ReleaseNotes = notes
 
-	if ctx.ReleaseNotesFile != "" || ctx.ReleaseNotesTmpl != "" {
+	if notesFile != "" || ctx.ReleaseNotesTmpl != "" {
 		return nil
 	}
 
@@ -320,6 +325,19 @@ func newSCMChangeloger(ctx *context.Context) (changeloger, error) {
 	}, nil
 }
 
+// releaseNotesFile returns the release notes file given with the
+// --release-notes flag, falling back to the changelog.release_notes one.
+func releaseNotesFile(ctx *context.Context) (string, error) {
+	if ctx.ReleaseNotesFile != "" || ctx.ReleaseNotesTmpl != "" || ctx.Config.Changelog.ReleaseNotes == "" {
+		return ctx.ReleaseNotesFile, nil
+	}
+	path, err := tmpl.New(ctx).Apply(ctx.Config.Changelog.ReleaseNotes)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply release_notes template: %w", err)
+	}
+	return path, nil
+}
+
 func loadContent(ctx *context.Context, fileName, tmplName string) (string, error) {
 	if tmplName != "" {
 		log.Debugf("loading template %q", tmplName)
@@ -63,6 +63,37 @@ func TestTemplatedChangelogProvidedViaFlagResultIsEmpty(t *testing.T) {
 	require.Equal(t, "\n\n", ctx.ReleaseNotes)
 }
 
+func TestChangelogProvidedViaConfig(t *testing.T) {
+	ctx := context.New(config.Project{
+		Changelog: config.Changelog{
+			ReleaseNotes: "testdata/{{ .Env.NOTES }}.md",
+		},
+	})
+	ctx.Env["NOTES"] = "changes"
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "c0ff33 coffeee\n", ctx.ReleaseNotes)
+}
+
+func TestChangelogProvidedViaFlagOverridesConfig(t *testing.T) {
+	ctx := context.New(config.Project{
+		Changelog: config.Changelog{
+			ReleaseNotes: "testdata/changes-empty.md",
+		},
+	})
+	ctx.ReleaseNotesFile = "testdata/changes.md"
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "c0ff33 coffeee\n", ctx.ReleaseNotes)
+}
+
+func TestChangelogProvidedViaConfigInvalidTemplate(t *testing.T) {
+	ctx := context.New(config.Project{
+		Changelog: config.Changelog{
+			ReleaseNotes: "{{ .Nope }",
+		},
+	})
+	require.EqualError(t, Pipe{}.Run(ctx), `failed to apply release_notes template: template: tmpl:1: unexpected "}" in operand`)
+}
+
 func TestChangelogProvidedViaFlagDoesntExist(t *testing.T) {
 	ctx := context.New(config.Project{})
 	ctx.ReleaseNotesFile = "testdata/changes.nope"
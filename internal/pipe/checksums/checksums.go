@@ -16,6 +16,7 @@ import (
 	"github.com/goreleaser/goreleaser/internal/extrafiles"
 	"github.com/goreleaser/goreleaser/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
 
@@ -27,8 +28,10 @@ var (
 // Pipe for checksums.
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "calculating checksums" }
-func (Pipe) Skip(ctx *context.Context) bool { return ctx.Config.Checksum.Disable }
+func (Pipe) String() string { return "calculating checksums" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return ctx.Config.Checksum.Disable && !ctx.Config.Checksum.Sidecar && len(ctx.Config.Checksum.Outputs) == 0
+}
 
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
@@ -38,67 +41,184 @@ func (Pipe) Default(ctx *context.Context) error {
 	if ctx.Config.Checksum.Algorithm == "" {
 		ctx.Config.Checksum.Algorithm = "sha256"
 	}
+	for _, output := range ctx.Config.Checksum.Outputs {
+		if output.NameTemplate == "" {
+			return errors.New("checksum.outputs: name_template is required")
+		}
+	}
+	if !artifact.ValidChecksumAlgorithm(ctx.Config.Checksum.Algorithm) {
+		return fmt.Errorf("invalid checksum algorithm: %s", ctx.Config.Checksum.Algorithm)
+	}
 	return nil
 }
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	filename, err := tmpl.New(ctx).Apply(ctx.Config.Checksum.NameTemplate)
+	main := set{
+		ids:        ctx.Config.Checksum.IDs,
+		extraFiles: true,
+		main:       true,
+	}
+	if ctx.Config.Checksum.Disable {
+		log.Debug("checksum.disable is set, not creating the checksums file")
+	} else if err := create(ctx, ctx.Config.Checksum.NameTemplate, main); err != nil {
+		return err
+	}
+	for _, output := range ctx.Config.Checksum.Outputs {
+		if err := create(ctx, output.NameTemplate, set{
+			ids:  output.IDs,
+			goos: output.Goos,
+		}); err != nil {
+			return err
+		}
+	}
+	if ctx.Config.Checksum.Sidecar {
+		return sidecars(ctx, main)
+	}
+	return nil
+}
+
+// set describes which artifacts go into a checksums file.
+type set struct {
+	ids        []string
+	goos       []string
+	extraFiles bool
+	main       bool
+}
+
+func create(ctx *context.Context, nameTemplate string, set set) error {
+	filename, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"Algorithm": ctx.Config.Checksum.Algorithm,
+	}).Apply(nameTemplate)
 	if err != nil {
 		return err
 	}
 	filepath := filepath.Join(ctx.Config.Dist, filename)
-	if err := refresh(ctx, filepath); err != nil {
+	if err := refresh(ctx, filepath, set); err != nil {
 		if errors.Is(err, errNoArtifacts) {
+			log.WithField("file", filename).Debug("no artifacts to checksum")
 			return nil
 		}
 		return err
 	}
-	ctx.Artifacts.Add(&artifact.Artifact{
-		Type: artifact.Checksum,
-		Path: filepath,
-		Name: filename,
-		Extra: map[string]interface{}{
-			artifact.ExtraRefresh: func() error {
-				log.WithField("file", filename).Info("refreshing checksums")
-				return refresh(ctx, filepath)
-			},
+	extra := map[string]interface{}{
+		artifact.ExtraRefresh: func() error {
+			log.WithField("file", filename).Info("refreshing checksums")
+			return refresh(ctx, filepath, set)
 		},
+	}
+	if set.main {
+		extra[artifact.ExtraChecksumsMain] = true
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:  artifact.Checksum,
+		Path:  filepath,
+		Name:  filename,
+		Extra: extra,
 	})
 	return nil
 }
 
-func refresh(ctx *context.Context, filepath string) error {
-	lock.Lock()
-	defer lock.Unlock()
+// sidecars writes a checksum file next to each artifact, adding them to the
+// artifact list.
+func sidecars(ctx *context.Context, set set) error {
+	artifactList, err := checksummable(ctx, set)
+	if errors.Is(err, errNoArtifacts) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	algorithm := ctx.Config.Checksum.Algorithm
+	g := semerrgroup.New(ctx.Parallelism)
+	for _, art := range artifactList {
+		art := art
+		name := art.Name + "." + algorithm
+		path := filepath.Join(ctx.Config.Dist, name)
+		g.Go(func() error {
+			if err := writeSidecar(algorithm, art, path); err != nil {
+				return err
+			}
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Type: artifact.Checksum,
+				Path: path,
+				Name: name,
+				Extra: map[string]interface{}{
+					artifact.ExtraRefresh: func() error {
+						log.WithField("file", name).Debug("refreshing checksum")
+						return writeSidecar(algorithm, art, path)
+					},
+				},
+			})
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func writeSidecar(algorithm string, art *artifact.Artifact, path string) error {
+	sumLine, err := checksums(algorithm, art)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sumLine), 0o644)
+}
+
+func checksummable(ctx *context.Context, set set) ([]*artifact.Artifact, error) {
 	filter := artifact.Or(
 		artifact.ByType(artifact.UploadableArchive),
 		artifact.ByType(artifact.UploadableBinary),
 		artifact.ByType(artifact.UploadableSourceArchive),
 		artifact.ByType(artifact.LinuxPackage),
 		artifact.ByType(artifact.SBOM),
+		artifact.ByType(artifact.DebugSymbols),
+		artifact.ByType(artifact.Provenance),
+		artifact.ByType(artifact.Attestation),
 	)
-	if len(ctx.Config.Checksum.IDs) > 0 {
-		filter = artifact.And(filter, artifact.ByIDs(ctx.Config.Checksum.IDs...))
+	if len(set.ids) > 0 {
+		filter = artifact.And(filter, artifact.ByIDs(set.ids...))
+	}
+	if len(set.goos) > 0 {
+		var goos []artifact.Filter
+		for _, g := range set.goos {
+			goos = append(goos, artifact.ByGoos(g))
+		}
+		filter = artifact.And(filter, artifact.Or(goos...))
 	}
 
 	artifactList := ctx.Artifacts.Filter(filter).List()
 
-	extraFiles, err := extrafiles.Find(ctx, ctx.Config.Checksum.ExtraFiles)
-	if err != nil {
-		return err
-	}
+	if set.extraFiles {
+		files := ctx.Config.Checksum.ExtraFiles
+		if ctx.Config.Checksum.ReleaseExtraFiles {
+			files = append(append([]config.ExtraFile{}, files...), ctx.Config.Release.ExtraFiles...)
+		}
+		extraFiles, err := extrafiles.Find(ctx, files)
+		if err != nil {
+			return nil, err
+		}
 
-	for name, path := range extraFiles {
-		artifactList = append(artifactList, &artifact.Artifact{
-			Name: name,
-			Path: path,
-			Type: artifact.UploadableFile,
-		})
+		for name, path := range extraFiles {
+			artifactList = append(artifactList, &artifact.Artifact{
+				Name: name,
+				Path: path,
+				Type: artifact.UploadableFile,
+			})
+		}
 	}
 
 	if len(artifactList) == 0 {
-		return errNoArtifacts
+		return nil, errNoArtifacts
+	}
+	return artifactList, nil
+}
+
+func refresh(ctx *context.Context, filepath string, set set) error {
+	lock.Lock()
+	defer lock.Unlock()
+	artifactList, err := checksummable(ctx, set)
+	if err != nil {
+		return err
 	}
 
 	g := semerrgroup.New(ctx.Parallelism)
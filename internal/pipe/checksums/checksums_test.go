@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/pkg/config"
@@ -20,6 +21,8 @@ func TestPipe(t *testing.T) {
 	const binary = "binary"
 	const archive = binary + ".tar.gz"
 	const linuxPackage = binary + ".rpm"
+	const provenance = binary + ".intoto.jsonl"
+	const attestation = binary + "_default.intoto.json"
 	const checksums = binary + "_bar_checksums.txt"
 	const sum = "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc  "
 
@@ -30,8 +33,10 @@ func TestPipe(t *testing.T) {
 		"default": {
 			want: strings.Join([]string{
 				sum + binary,
+				sum + provenance,
 				sum + linuxPackage,
 				sum + archive,
+				sum + attestation,
 			}, "\n") + "\n",
 		},
 		"select ids": {
@@ -41,6 +46,7 @@ func TestPipe(t *testing.T) {
 			},
 			want: strings.Join([]string{
 				sum + binary,
+				sum + provenance,
 				sum + archive,
 			}, "\n") + "\n",
 		},
@@ -87,6 +93,19 @@ func TestPipe(t *testing.T) {
 					artifact.ExtraID: "id-3",
 				},
 			})
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: provenance,
+				Path: file,
+				Type: artifact.Provenance,
+			})
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: attestation,
+				Path: file,
+				Type: artifact.Attestation,
+				Extra: map[string]interface{}{
+					artifact.ExtraID: "default",
+				},
+			})
 			require.NoError(t, Pipe{}.Run(ctx))
 			var artifacts []string
 			for _, a := range ctx.Artifacts.List() {
@@ -101,6 +120,155 @@ func TestPipe(t *testing.T) {
 	}
 }
 
+func TestPipeOutputs(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "binary")
+	require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+	ctx := context.New(
+		config.Project{
+			Dist:        folder,
+			ProjectName: "binary",
+			Checksum: config.Checksum{
+				NameTemplate: "checksums.txt",
+				Algorithm:    "sha256",
+				Outputs: []config.ChecksumOutput{
+					{
+						NameTemplate: "{{ .ProjectName }}_windows_checksums.txt",
+						Goos:         []string{"windows"},
+					},
+					{
+						NameTemplate: "unix_checksums.txt",
+						Goos:         []string{"linux", "darwin"},
+						IDs:          []string{"foo"},
+					},
+					{
+						NameTemplate: "empty_checksums.txt",
+						Goos:         []string{"freebsd"},
+					},
+				},
+			},
+		},
+	)
+	for _, a := range []struct{ name, goos, id string }{
+		{"binary_windows.zip", "windows", "foo"},
+		{"binary_linux.tar.gz", "linux", "foo"},
+		{"binary_darwin.tar.gz", "darwin", "bar"},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: a.name,
+			Path: file,
+			Goos: a.goos,
+			Type: artifact.UploadableArchive,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: a.id,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	var names []string
+	for _, a := range ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List() {
+		names = append(names, a.Name)
+		require.NoError(t, a.Refresh())
+		require.Equal(t, a.Name == "checksums.txt", a.ExtraOr(artifact.ExtraChecksumsMain, false), a.Name)
+	}
+	require.ElementsMatch(t, []string{"checksums.txt", "binary_windows_checksums.txt", "unix_checksums.txt"}, names)
+
+	const sum = "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc  "
+	for name, expected := range map[string]string{
+		"checksums.txt":                sum + "binary_darwin.tar.gz\n" + sum + "binary_linux.tar.gz\n" + sum + "binary_windows.zip\n",
+		"binary_windows_checksums.txt": sum + "binary_windows.zip\n",
+		"unix_checksums.txt":           sum + "binary_linux.tar.gz\n",
+	} {
+		bts, err := os.ReadFile(filepath.Join(folder, name))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(bts), name)
+	}
+}
+
+func TestPipeSidecar(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "binary")
+	require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+	ctx := context.New(
+		config.Project{
+			Dist:        folder,
+			ProjectName: "binary",
+			Checksum: config.Checksum{
+				NameTemplate: "checksums.txt",
+				Algorithm:    "sha256",
+				Sidecar:      true,
+			},
+		},
+	)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "binary",
+		Path: file,
+		Type: artifact.UploadableBinary,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "binary.tar.gz",
+		Path: file,
+		Type: artifact.UploadableArchive,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	sums := ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List()
+	var names []string
+	for _, a := range sums {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{"checksums.txt", "binary.sha256", "binary.tar.gz.sha256"}, names)
+
+	const sum = "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc  "
+	bts, err := os.ReadFile(filepath.Join(folder, "binary.tar.gz.sha256"))
+	require.NoError(t, err)
+	require.Equal(t, sum+"binary.tar.gz\n", string(bts))
+
+	require.NoError(t, os.WriteFile(file, []byte("some other string"), 0o644))
+	for _, a := range sums {
+		require.NoError(t, a.Refresh())
+	}
+	bts, err = os.ReadFile(filepath.Join(folder, "binary.sha256"))
+	require.NoError(t, err)
+	require.Equal(t, "94870326db59631f737f8392e49d18608d69018b1da3a79517a25623cd959c4c  binary\n", string(bts))
+}
+
+func TestPipeNameTemplate(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "binary")
+	require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+	for template, expected := range map[string]string{
+		"{{ toupper .Algorithm }}SUMS-{{ .Tag }}.txt":       "SHA512SUMS-v1.2.3.txt",
+		"{{ .Env.PREFIX }}-{{ .Version }}.{{ .Algorithm }}": "myproj-1.2.3.sha512",
+		"checksums-{{ .Timestamp }}.txt":                    "checksums-1654041600.txt",
+	} {
+		t.Run(expected, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				Dist: folder,
+				Checksum: config.Checksum{
+					NameTemplate: template,
+					Algorithm:    "sha512",
+				},
+			})
+			ctx.Git.CurrentTag = "v1.2.3"
+			ctx.Version = "1.2.3"
+			ctx.Date = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+			ctx.Env = map[string]string{"PREFIX": "myproj"}
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: "binary",
+				Path: file,
+				Type: artifact.UploadableBinary,
+			})
+			require.NoError(t, Pipe{}.Run(ctx))
+			checks := ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List()
+			require.Len(t, checks, 1)
+			require.Equal(t, expected, checks[0].Name)
+			require.FileExists(t, filepath.Join(folder, expected))
+		})
+	}
+}
+
 func TestRefreshModifying(t *testing.T) {
 	const binary = "binary"
 	folder := t.TempDir()
@@ -257,6 +425,40 @@ func TestDefaultSet(t *testing.T) {
 	require.Equal(t, "checksums.txt", ctx.Config.Checksum.NameTemplate)
 }
 
+func TestDefaultOutputWithoutNameTemplate(t *testing.T) {
+	ctx := context.New(config.Project{
+		Checksum: config.Checksum{
+			Outputs: []config.ChecksumOutput{
+				{Goos: []string{"windows"}},
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "checksum.outputs: name_template is required")
+}
+
+func TestDefaultAlgorithm(t *testing.T) {
+	for _, algo := range []string{"sha256", "sha512", "sha1", "blake2b", "crc32"} {
+		t.Run(algo, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				Checksum: config.Checksum{
+					Algorithm: algo,
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.Equal(t, algo, ctx.Config.Checksum.Algorithm)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Checksum: config.Checksum{
+				Algorithm: "sha3",
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "invalid checksum algorithm: sha3")
+	})
+}
+
 func TestPipeCheckSumsWithExtraFiles(t *testing.T) {
 	const binary = "binary"
 	const checksums = "checksums.txt"
@@ -294,6 +496,14 @@ func TestPipeCheckSumsWithExtraFiles(t *testing.T) {
 				extraFileBar,
 			},
 		},
+		"one extra file with name template": {
+			extraFiles: []config.ExtraFile{
+				{Glob: extraFileFooRelPath, NameTemplate: "{{ .ProjectName }}_installer.txt"},
+			},
+			want: []string{
+				binary + "_installer.txt",
+			},
+		},
 		"one extra file with no builds": {
 			extraFiles: []config.ExtraFile{
 				{Glob: extraFileFooRelPath},
@@ -348,6 +558,50 @@ func TestPipeCheckSumsWithExtraFiles(t *testing.T) {
 	}
 }
 
+func TestPipeCheckSumsWithReleaseExtraFiles(t *testing.T) {
+	for name, include := range map[string]bool{
+		"included": true,
+		"ignored":  false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			file := filepath.Join(folder, "binary")
+			require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+			ctx := context.New(config.Project{
+				Dist:        folder,
+				ProjectName: "binary",
+				Checksum: config.Checksum{
+					Algorithm:         "sha256",
+					NameTemplate:      "checksums.txt",
+					ExtraFiles:        []config.ExtraFile{{Glob: "./testdata/foo.txt"}},
+					ReleaseExtraFiles: include,
+				},
+				Release: config.Release{
+					ExtraFiles: []config.ExtraFile{
+						{Glob: "./testdata/foo.txt", NameTemplate: "manual.txt"},
+					},
+				},
+			})
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: "binary",
+				Path: file,
+				Type: artifact.UploadableBinary,
+			})
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			bts, err := os.ReadFile(filepath.Join(folder, "checksums.txt"))
+			require.NoError(t, err)
+			require.Contains(t, string(bts), "  foo.txt\n")
+			if include {
+				require.Contains(t, string(bts), "  manual.txt\n")
+			} else {
+				require.NotContains(t, string(bts), "manual.txt")
+			}
+			require.Len(t, ctx.Config.Checksum.ExtraFiles, 1)
+		})
+	}
+}
+
 func TestExtraFilesNoMatch(t *testing.T) {
 	dir := t.TempDir()
 	ctx := context.New(
@@ -385,6 +639,53 @@ func TestSkip(t *testing.T) {
 	t.Run("dont skip", func(t *testing.T) {
 		require.False(t, Pipe{}.Skip(context.New(config.Project{})))
 	})
+
+	t.Run("dont skip with sidecar", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Checksum: config.Checksum{
+				Disable: true,
+				Sidecar: true,
+			},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip with outputs", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Checksum: config.Checksum{
+				Disable: true,
+				Outputs: []config.ChecksumOutput{{NameTemplate: "foo.txt"}},
+			},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestPipeDisabledWithSidecar(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "binary")
+	require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+	ctx := context.New(
+		config.Project{
+			Dist: folder,
+			Checksum: config.Checksum{
+				NameTemplate: "checksums.txt",
+				Algorithm:    "sha256",
+				Disable:      true,
+				Sidecar:      true,
+			},
+		},
+	)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "binary",
+		Path: file,
+		Type: artifact.UploadableBinary,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	sums := ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List()
+	require.Len(t, sums, 1)
+	require.Equal(t, "binary.sha256", sums[0].Name)
+	require.NoFileExists(t, filepath.Join(folder, "checksums.txt"))
 }
 
 // TODO: add tests for LinuxPackage and UploadableSourceArchive
@@ -0,0 +1,108 @@
+// Package debugsymbols splits the debug symbols out of the built binaries,
+// so they can be published separately.
+package debugsymbols
+
+import (
+	"debug/elf"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const defaultNameTemplate = `{{ .Binary }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}{{ with .Arm64 }}_{{ . }}{{ end }}.debug`
+
+// Pipe for debug symbols.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "debug symbols" }
+func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.DebugSymbols.Enabled }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.DebugSymbols
+	if cfg.Objcopy == "" {
+		cfg.Objcopy = "objcopy"
+	}
+	if cfg.NameTemplate == "" {
+		cfg.NameTemplate = defaultNameTemplate
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	filter := artifact.ByType(artifact.Binary)
+	if len(ctx.Config.DebugSymbols.IDs) > 0 {
+		filter = artifact.And(filter, artifact.ByIDs(ctx.Config.DebugSymbols.IDs...))
+	}
+	g := semerrgroup.New(ctx.Parallelism)
+	for _, bin := range ctx.Artifacts.Filter(filter).List() {
+		bin := bin
+		if !isELF(bin.Path) {
+			log.WithField("binary", bin.Path).Debug("not an ELF binary, skipping")
+			continue
+		}
+		g.Go(func() error {
+			return split(ctx, bin)
+		})
+	}
+	return g.Wait()
+}
+
+// split moves the debug symbols of the given binary into their own file,
+// strips the binary, and links it to the debug file.
+func split(ctx *context.Context, bin *artifact.Artifact) error {
+	name, err := tmpl.New(ctx).WithArtifact(bin, map[string]string{}).Apply(ctx.Config.DebugSymbols.NameTemplate)
+	if err != nil {
+		return err
+	}
+	path, err := filepath.Abs(filepath.Join(ctx.Config.Dist, name))
+	if err != nil {
+		return err
+	}
+
+	objcopy := ctx.Config.DebugSymbols.Objcopy
+	log.WithField("binary", bin.Path).Info("splitting debug symbols")
+	for _, args := range [][]string{
+		{"--only-keep-debug", bin.Path, path},
+		{"--strip-all", bin.Path},
+		{"--add-gnu-debuglink=" + path, bin.Path},
+	} {
+		/* #nosec */
+		cmd := exec.CommandContext(ctx, objcopy, args...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to split debug symbols of %s: %w: %s", bin.Path, err, string(out))
+		}
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.DebugSymbols,
+		Name:    name,
+		Path:    path,
+		Goos:    bin.Goos,
+		Goarch:  bin.Goarch,
+		Goamd64: bin.Goamd64,
+		Goarm64: bin.Goarm64,
+		Goarm:   bin.Goarm,
+		Gomips:  bin.Gomips,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: bin.ID(),
+		},
+	})
+	return nil
+}
+
+func isELF(path string) bool {
+	f, err := elf.Open(path)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
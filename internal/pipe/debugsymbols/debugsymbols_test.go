@@ -0,0 +1,197 @@
+package debugsymbols
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/gio"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	require.False(t, Pipe{}.Skip(context.New(config.Project{
+		DebugSymbols: config.DebugSymbols{Enabled: true},
+	})))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "objcopy", ctx.Config.DebugSymbols.Objcopy)
+	require.Equal(t, defaultNameTemplate, ctx.Config.DebugSymbols.NameTemplate)
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs an ELF binary to work with")
+	}
+	for name, tt := range map[string]struct {
+		ids     []string
+		symbols []string
+	}{
+		"all": {
+			symbols: []string{"bar_linux_amd64.debug", "foo_linux_amd64.debug"},
+		},
+		"filtered": {
+			ids:     []string{"foo"},
+			symbols: []string{"foo_linux_amd64.debug"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			ctx := context.New(config.Project{
+				Dist: filepath.Join(folder, "dist"),
+				DebugSymbols: config.DebugSymbols{
+					Enabled: true,
+					IDs:     tt.ids,
+					Objcopy: fakeObjcopy(t, filepath.Join(folder, "calls")),
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			addBinaries(t, ctx, folder)
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			var names []string
+			for _, symbols := range ctx.Artifacts.Filter(artifact.ByType(artifact.DebugSymbols)).List() {
+				names = append(names, symbols.Name)
+				require.Equal(t, filepath.Join(ctx.Config.Dist, symbols.Name), symbols.Path)
+				require.Equal(t, "linux", symbols.Goos)
+				require.FileExists(t, symbols.Path)
+			}
+			require.ElementsMatch(t, tt.symbols, names)
+		})
+	}
+}
+
+func TestRunObjcopyCalls(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs an ELF binary to work with")
+	}
+	folder := t.TempDir()
+	calls := filepath.Join(folder, "calls")
+	ctx := context.New(config.Project{
+		Dist: filepath.Join(folder, "dist"),
+		DebugSymbols: config.DebugSymbols{
+			Enabled: true,
+			IDs:     []string{"foo"},
+			Objcopy: fakeObjcopy(t, calls),
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bin := filepath.Join(folder, "foo_linux")
+	debug := filepath.Join(folder, "dist", "foo_linux_amd64.debug")
+	bts, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"--only-keep-debug " + bin + " " + debug,
+		"--strip-all " + bin,
+		"--add-gnu-debuglink=" + debug + " " + bin,
+	}, strings.Split(strings.TrimSpace(string(bts)), "\n"))
+
+	symbols := ctx.Artifacts.Filter(artifact.ByType(artifact.DebugSymbols)).List()
+	require.Len(t, symbols, 1)
+	require.Equal(t, "foo", symbols[0].ID())
+}
+
+func TestRunErrors(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs an ELF binary to work with")
+	}
+	for name, tt := range map[string]struct {
+		objcopy      string
+		nameTemplate string
+		err          string
+	}{
+		"objcopy fails": {
+			objcopy: "false",
+			err:     "failed to split debug symbols of ",
+		},
+		"invalid name template": {
+			nameTemplate: "{{ .Nope }",
+			err:          `template: tmpl:1: unexpected "}" in operand`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			ctx := context.New(config.Project{
+				Dist: filepath.Join(folder, "dist"),
+				DebugSymbols: config.DebugSymbols{
+					Enabled:      true,
+					IDs:          []string{"bar"},
+					Objcopy:      tt.objcopy,
+					NameTemplate: tt.nameTemplate,
+				},
+			})
+			if ctx.Config.DebugSymbols.Objcopy == "" {
+				ctx.Config.DebugSymbols.Objcopy = fakeObjcopy(t, filepath.Join(folder, "calls"))
+			}
+			require.NoError(t, Pipe{}.Default(ctx))
+			addBinaries(t, ctx, folder)
+			require.ErrorContains(t, Pipe{}.Run(ctx), tt.err)
+		})
+	}
+}
+
+// fakeObjcopy creates a fake objcopy binary, which logs its arguments to the
+// given calls file and writes the debug symbols file when asked for it.
+func fakeObjcopy(tb testing.TB, calls string) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "fakeobjcopy")
+	require.NoError(tb, os.WriteFile(path, []byte(`#!/bin/sh
+echo "$@" >> `+calls+`
+if [ "$1" = "--only-keep-debug" ]; then
+  echo debug > "$3"
+fi
+`), 0o755))
+	return path
+}
+
+// addBinaries adds two linux binaries, copies of the test binary, and a
+// darwin one, which is not an ELF file, to the given folder and context.
+func addBinaries(tb testing.TB, ctx *context.Context, folder string) {
+	tb.Helper()
+	self, err := os.Executable()
+	require.NoError(tb, err)
+	require.NoError(tb, os.MkdirAll(ctx.Config.Dist, 0o755))
+	for _, bin := range []struct {
+		id, goos string
+		elf      bool
+	}{
+		{"foo", "linux", true},
+		{"bar", "linux", true},
+		{"foo", "darwin", false},
+	} {
+		path := filepath.Join(folder, bin.id+"_"+bin.goos)
+		if bin.elf {
+			require.NoError(tb, gio.Copy(self, path))
+		} else {
+			require.NoError(tb, os.WriteFile(path, []byte("not elf"), 0o755))
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type:    artifact.Binary,
+			Name:    bin.id,
+			Path:    path,
+			Goos:    bin.goos,
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Extra: map[string]interface{}{
+				artifact.ExtraID:     bin.id,
+				artifact.ExtraBinary: bin.id,
+			},
+		})
+	}
+}
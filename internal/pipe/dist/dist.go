@@ -5,11 +5,16 @@ package dist
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/apex/log"
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
 
+// CacheDir is the directory inside dist in which incremental builds are
+// cached. It is kept between runs when incremental builds are enabled.
+const CacheDir = ".cache"
+
 // Pipe for dist.
 type Pipe struct{}
 
@@ -26,13 +31,16 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 	}
 	if ctx.RmDist {
 		log.Info("--rm-dist is set, cleaning it up")
+		if ctx.Incremental {
+			return cleanKeepingCache(ctx)
+		}
 		err = os.RemoveAll(ctx.Config.Dist)
 		if err == nil {
 			err = mkdir(ctx)
 		}
 		return err
 	}
-	files, err := os.ReadDir(ctx.Config.Dist)
+	files, err := distFiles(ctx)
 	if err != nil {
 		return
 	}
@@ -47,6 +55,35 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 	return mkdir(ctx)
 }
 
+// distFiles lists the files in dist, ignoring the incremental builds cache if
+// it is being used.
+func distFiles(ctx *context.Context) ([]os.DirEntry, error) {
+	files, err := os.ReadDir(ctx.Config.Dist)
+	if err != nil || !ctx.Incremental {
+		return files, err
+	}
+	var result []os.DirEntry
+	for _, f := range files {
+		if f.Name() != CacheDir {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func cleanKeepingCache(ctx *context.Context) error {
+	files, err := distFiles(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.RemoveAll(filepath.Join(ctx.Config.Dist, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func mkdir(ctx *context.Context) error {
 	// #nosec
 	return os.MkdirAll(ctx.Config.Dist, 0o755)
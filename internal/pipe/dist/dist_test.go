@@ -44,6 +44,29 @@ func TestPopulatedDistExists(t *testing.T) {
 	require.False(t, os.IsExist(err))
 }
 
+func TestIncrementalKeepsCache(t *testing.T) {
+	folder := t.TempDir()
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, CacheDir), 0o755))
+	ctx := &context.Context{
+		Config: config.Project{
+			Dist: dist,
+		},
+		Incremental: true,
+	}
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	f, err := os.Create(filepath.Join(dist, "mybin"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Error(t, Pipe{}.Run(ctx))
+
+	ctx.RmDist = true
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.DirExists(t, filepath.Join(dist, CacheDir))
+	require.NoFileExists(t, filepath.Join(dist, "mybin"))
+}
+
 func TestEmptyDistExists(t *testing.T) {
 	folder := t.TempDir()
 	dist := filepath.Join(folder, "dist")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"regexp"
 	"sync"
 
 	"github.com/apex/log"
@@ -34,17 +35,21 @@ func registerImager(use string, impl imager) {
 // imager is something that can build and push docker images.
 type imager interface {
 	Build(ctx *context.Context, root string, images, flags []string) error
-	Push(ctx *context.Context, image string, flags []string) error
+	Push(ctx *context.Context, image string, flags []string) (digest string, err error)
 }
 
 // manifester is something that can create and push docker manifests.
 type manifester interface {
 	Create(ctx *context.Context, manifest string, images, flags []string) error
-	Push(ctx *context.Context, manifest string, flags []string) error
+	Push(ctx *context.Context, manifest string, flags []string) (digest string, err error)
 }
 
-// nolint: unparam
 func runCommand(ctx *context.Context, dir, binary string, args ...string) error {
+	_, err := runCommandWithOutput(ctx, dir, binary, args...)
+	return err
+}
+
+func runCommandWithOutput(ctx *context.Context, dir, binary string, args ...string) ([]byte, error) {
 	fields := log.Fields{
 		"cmd": append([]string{binary}, args[0]),
 		"cwd": dir,
@@ -62,7 +67,15 @@ func runCommand(ctx *context.Context, dir, binary string, args ...string) error
 
 	log.WithFields(fields).WithField("args", args[1:]).Debug("running")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%w: %s", err, b.String())
+		return nil, fmt.Errorf("%w: %s", err, b.String())
 	}
-	return nil
+	return b.Bytes(), nil
+}
+
+var digestRe = regexp.MustCompile(`sha256:[a-f0-9]{64}`)
+
+// digestOf finds the image or manifest digest in the output of a push
+// command, returning an empty string if there is none.
+func digestOf(out []byte) string {
+	return string(digestRe.Find(out))
 }
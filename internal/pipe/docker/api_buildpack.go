@@ -10,7 +10,7 @@ import (
 // deprected: should not be used anymore.
 type buildPackImager struct{}
 
-func (i buildPackImager) Push(ctx *context.Context, image string, flags []string) error {
+func (i buildPackImager) Push(ctx *context.Context, image string, flags []string) (string, error) {
 	return dockerImager{}.Push(ctx, image, flags)
 }
 
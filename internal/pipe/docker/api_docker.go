@@ -31,24 +31,26 @@ func (m dockerManifester) Create(ctx *context.Context, manifest string, images,
 	return nil
 }
 
-func (m dockerManifester) Push(ctx *context.Context, manifest string, flags []string) error {
+func (m dockerManifester) Push(ctx *context.Context, manifest string, flags []string) (string, error) {
 	args := []string{"manifest", "push", manifest}
 	args = append(args, flags...)
-	if err := runCommand(ctx, ".", "docker", args...); err != nil {
-		return fmt.Errorf("failed to push %s: %w", manifest, err)
+	out, err := runCommandWithOutput(ctx, ".", "docker", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to push %s: %w", manifest, err)
 	}
-	return nil
+	return digestOf(out), nil
 }
 
 type dockerImager struct {
 	buildx bool
 }
 
-func (i dockerImager) Push(ctx *context.Context, image string, flags []string) error {
-	if err := runCommand(ctx, ".", "docker", "push", image); err != nil {
-		return fmt.Errorf("failed to push %s: %w", image, err)
+func (i dockerImager) Push(ctx *context.Context, image string, flags []string) (string, error) {
+	out, err := runCommandWithOutput(ctx, ".", "docker", "push", image)
+	if err != nil {
+		return "", fmt.Errorf("failed to push %s: %w", image, err)
 	}
-	return nil
+	return digestOf(out), nil
 }
 
 func (i dockerImager) Build(ctx *context.Context, root string, images, flags []string) error {
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/apex/log"
 	"github.com/goreleaser/goreleaser/internal/artifact"
@@ -25,8 +26,14 @@ const (
 	useBuildx     = "buildx"
 	useDocker     = "docker"
 	useBuildPacks = "buildpacks" // deprecated: should not be used anymore
+
+	defaultSaveNameTemplate = "{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ if not (eq .Amd64 \"v1\") }}{{ .Amd64 }}{{ end }}{{ with .Arm64 }}_{{ . }}{{ end }}{{ with .ID }}_{{ . }}{{ end }}_docker"
 )
 
+// saveLock guards the creation of the docker save tarballs, so dockers
+// saving to the same path fail instead of overwriting each other.
+var saveLock sync.Mutex
+
 // Pipe for docker.
 type Pipe struct{}
 
@@ -51,9 +58,15 @@ func (Pipe) Default(ctx *context.Context) error {
 		if docker.Goamd64 == "" {
 			docker.Goamd64 = "v1"
 		}
+		if docker.Gomips == "" {
+			docker.Gomips = "hardfloat"
+		}
 		if docker.Dockerfile == "" {
 			docker.Dockerfile = "Dockerfile"
 		}
+		if docker.Save.Enabled && docker.Save.NameTemplate == "" {
+			docker.Save.NameTemplate = defaultSaveNameTemplate
+		}
 		if docker.Use == useBuildPacks {
 			deprecate.Notice(ctx, "dockers.use: buildpacks")
 		}
@@ -104,25 +117,7 @@ func (Pipe) Run(ctx *context.Context) error {
 		docker := docker
 		g.Go(func() error {
 			log.WithField("docker", docker).Debug("looking for artifacts matching")
-			filters := []artifact.Filter{
-				artifact.ByGoos(docker.Goos),
-				artifact.ByGoarch(docker.Goarch),
-				artifact.Or(
-					artifact.ByType(artifact.Binary),
-					artifact.ByType(artifact.LinuxPackage),
-				),
-			}
-			// TODO: properly test this
-			switch docker.Goarch {
-			case "amd64":
-				filters = append(filters, artifact.ByGoamd64(docker.Goamd64))
-			case "arm":
-				filters = append(filters, artifact.ByGoarm(docker.Goarm))
-			}
-			if len(docker.IDs) > 0 {
-				filters = append(filters, artifact.ByIDs(docker.IDs...))
-			}
-			artifacts := ctx.Artifacts.Filter(artifact.And(filters...))
+			artifacts := ctx.Artifacts.Filter(filterFor(docker))
 			log.WithField("artifacts", artifacts.Paths()).Debug("found artifacts")
 			return process(ctx, docker, artifacts.List())
 		})
@@ -136,6 +131,33 @@ func (Pipe) Run(ctx *context.Context) error {
 	return nil
 }
 
+// filterFor returns the filter of the artifacts to be used in the given
+// docker image.
+func filterFor(docker config.Docker) artifact.Filter {
+	filters := []artifact.Filter{
+		artifact.ByGoos(docker.Goos),
+		artifact.ByGoarch(docker.Goarch),
+		artifact.Or(
+			artifact.ByType(artifact.Binary),
+			artifact.ByType(artifact.LinuxPackage),
+		),
+	}
+	switch docker.Goarch {
+	case "amd64":
+		filters = append(filters, artifact.ByGoamd64(docker.Goamd64))
+	case "arm":
+		filters = append(filters, artifact.ByGoarm(docker.Goarm))
+	case "arm64":
+		filters = append(filters, artifact.ByGoarm64(docker.Goarm64))
+	case "mips", "mipsle", "mips64", "mips64le":
+		filters = append(filters, artifact.ByGomips(docker.Gomips))
+	}
+	if len(docker.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(docker.IDs...))
+	}
+	return artifact.And(filters...)
+}
+
 func process(ctx *context.Context, docker config.Docker, artifacts []*artifact.Artifact) error {
 	tmp, err := os.MkdirTemp(ctx.Config.Dist, "goreleaserdocker")
 	if err != nil {
@@ -187,6 +209,12 @@ func process(ctx *context.Context, docker config.Docker, artifacts []*artifact.A
 		return err
 	}
 
+	if docker.Save.Enabled {
+		if err := save(ctx, docker, images); err != nil {
+			return err
+		}
+	}
+
 	if strings.TrimSpace(docker.SkipPush) == "true" {
 		return pipe.Skip("docker.skip_push is set")
 	}
@@ -212,6 +240,76 @@ func process(ctx *context.Context, docker config.Docker, artifacts []*artifact.A
 	return nil
 }
 
+// save exports the given images into a tarball inside the dist folder and
+// adds it as an uploadable artifact.
+func save(ctx *context.Context, docker config.Docker, images []string) error {
+	name, err := saveName(ctx, docker)
+	if err != nil {
+		return err
+	}
+	path, err := filepath.Abs(filepath.Join(ctx.Config.Dist, name))
+	if err != nil {
+		return err
+	}
+	if err := reserveSavePath(path); err != nil {
+		return err
+	}
+	log.WithField("images", images).WithField("path", path).Info("saving docker images")
+	args := append([]string{"save", "-o", path}, images...)
+	if err := runCommand(ctx, ctx.Config.Dist, "docker", args...); err != nil {
+		return fmt.Errorf("failed to save docker images: %w", err)
+	}
+	art := &artifact.Artifact{
+		Type:    artifact.UploadableDockerImage,
+		Name:    name,
+		Path:    path,
+		Goos:    docker.Goos,
+		Goarch:  docker.Goarch,
+		Goarm:   docker.Goarm,
+		Goamd64: docker.Goamd64,
+		Goarm64: docker.Goarm64,
+		Gomips:  docker.Gomips,
+		Extra:   map[string]interface{}{},
+	}
+	if docker.ID != "" {
+		art.Extra[artifact.ExtraID] = docker.ID
+	}
+	ctx.Artifacts.Add(art)
+	return nil
+}
+
+// reserveSavePath creates an empty file at the given path, failing if it
+// already exists.
+func reserveSavePath(path string) error {
+	saveLock.Lock()
+	defer saveLock.Unlock()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return fmt.Errorf("docker save tarball named %s already exists. Check your save name template", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func saveName(ctx *context.Context, docker config.Docker) (string, error) {
+	name, err := tmpl.New(ctx).WithArtifact(&artifact.Artifact{
+		Goos:    docker.Goos,
+		Goarch:  docker.Goarch,
+		Goarm:   docker.Goarm,
+		Goamd64: docker.Goamd64,
+		Goarm64: docker.Goarm64,
+		Gomips:  docker.Gomips,
+	}, map[string]string{}).WithExtraFields(tmpl.Fields{
+		"ID": docker.ID,
+	}).Apply(docker.Save.NameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute docker save name template: %w", err)
+	}
+	return name + ".tar", nil
+}
+
 func processImageTemplates(ctx *context.Context, docker config.Docker) ([]string, error) {
 	// nolint:prealloc
 	var images []string
@@ -246,7 +344,8 @@ func processBuildFlagTemplates(ctx *context.Context, docker config.Docker) ([]st
 func dockerPush(ctx *context.Context, image *artifact.Artifact) error {
 	log.WithField("image", image.Name).Info("pushing")
 	docker := image.Extra[dockerConfigExtra].(config.Docker)
-	if err := imagers[docker.Use].Push(ctx, image.Name, docker.PushFlags); err != nil {
+	digest, err := imagers[docker.Use].Push(ctx, image.Name, docker.PushFlags)
+	if err != nil {
 		return err
 	}
 	art := &artifact.Artifact{
@@ -261,6 +360,9 @@ func dockerPush(ctx *context.Context, image *artifact.Artifact) error {
 	if docker.ID != "" {
 		art.Extra[artifact.ExtraID] = docker.ID
 	}
+	if digest != "" {
+		art.Extra[artifact.ExtraDigest] = digest
+	}
 	ctx.Artifacts.Add(art)
 	return nil
 }
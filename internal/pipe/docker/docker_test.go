@@ -1091,6 +1091,171 @@ func TestRunPipe(t *testing.T) {
 	}
 }
 
+func TestRunPipeSave(t *testing.T) {
+	testlib.CheckPath(t, "docker")
+	folder := t.TempDir()
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.MkdirAll(filepath.Join(dist, "mybin"), 0o755))
+	f, err := os.Create(filepath.Join(dist, "mybin", "mybin"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	image := "goreleaser/test_run_pipe_save:latest"
+	ctx := context.New(config.Project{
+		ProjectName: "mybin",
+		Dist:        dist,
+		Dockers: []config.Docker{
+			{
+				ImageTemplates: []string{image},
+				Goos:           "linux",
+				Goarch:         "amd64",
+				Dockerfile:     "testdata/Dockerfile",
+				SkipPush:       "true",
+				Save: config.DockerSave{
+					Enabled: true,
+				},
+			},
+		},
+	})
+	ctx.Version = "1.0.0"
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "mybin",
+		Path:   filepath.Join(dist, "mybin", "mybin"),
+		Goarch: "amd64",
+		Goos:   "linux",
+		Type:   artifact.Binary,
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	t.Cleanup(func() {
+		_ = exec.Command("docker", "rmi", "--force", image).Run()
+	})
+
+	images := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableDockerImage)).List()
+	require.Len(t, images, 1)
+	require.Equal(t, "mybin_1.0.0_linux_amd64_docker.tar", images[0].Name)
+	require.Equal(t, "linux", images[0].Goos)
+	require.Equal(t, "amd64", images[0].Goarch)
+	require.FileExists(t, images[0].Path)
+}
+
+func TestSaveName(t *testing.T) {
+	ctx := context.New(config.Project{ProjectName: "mybin"})
+	ctx.Version = "1.2.3"
+	for expected, docker := range map[string]config.Docker{
+		"mybin_1.2.3_linux_amd64_docker.tar": {
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+		},
+		"mybin_1.2.3_linux_amd64v3_docker.tar": {
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v3",
+		},
+		"mybin_1.2.3_linux_armv7_docker.tar": {
+			Goos:    "linux",
+			Goarch:  "arm",
+			Goarm:   "7",
+			Goamd64: "v1",
+		},
+		"mybin_1.2.3_linux_arm64_v9.0_docker.tar": {
+			Goos:    "linux",
+			Goarch:  "arm64",
+			Goarm64: "v9.0",
+			Goamd64: "v1",
+		},
+		"mybin_1.2.3_linux_amd64_slim_docker.tar": {
+			ID:      "slim",
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+		},
+	} {
+		t.Run(expected, func(t *testing.T) {
+			docker.Save = config.DockerSave{NameTemplate: defaultSaveNameTemplate}
+			name, err := saveName(ctx, docker)
+			require.NoError(t, err)
+			require.Equal(t, expected, name)
+		})
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		name, err := saveName(ctx, config.Docker{
+			Goos:   "linux",
+			Goarch: "arm64",
+			Save:   config.DockerSave{NameTemplate: "{{ .ProjectName }}-{{ .Arch }}-image"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "mybin-arm64-image.tar", name)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := saveName(ctx, config.Docker{
+			Save: config.DockerSave{NameTemplate: "{{ .Nope }"},
+		})
+		require.EqualError(t, err, `failed to execute docker save name template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
+func TestReserveSavePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.tar")
+	require.NoError(t, reserveSavePath(path))
+	require.FileExists(t, path)
+	require.EqualError(t, reserveSavePath(path), "docker save tarball named "+path+" already exists. Check your save name template")
+}
+
+func TestFilterFor(t *testing.T) {
+	ctx := context.New(config.Project{})
+	for _, a := range []*artifact.Artifact{
+		{Name: "amd64v1", Goos: "linux", Goarch: "amd64", Goamd64: "v1"},
+		{Name: "amd64v3", Goos: "linux", Goarch: "amd64", Goamd64: "v3"},
+		{Name: "arm6", Goos: "linux", Goarch: "arm", Goarm: "6"},
+		{Name: "arm7", Goos: "linux", Goarch: "arm", Goarm: "7"},
+		{Name: "arm64", Goos: "linux", Goarch: "arm64"},
+		{Name: "arm64v9", Goos: "linux", Goarch: "arm64", Goarm64: "v9.0"},
+		{Name: "mips", Goos: "linux", Goarch: "mips", Gomips: "softfloat"},
+		{Name: "darwin", Goos: "darwin", Goarch: "arm64"},
+	} {
+		a.Type = artifact.Binary
+		ctx.Artifacts.Add(a)
+	}
+
+	for _, tt := range []struct {
+		docker   config.Docker
+		expected string
+	}{
+		{config.Docker{Goos: "linux", Goarch: "amd64", Goamd64: "v3"}, "amd64v3"},
+		{config.Docker{Goos: "linux", Goarch: "arm", Goarm: "7"}, "arm7"},
+		{config.Docker{Goos: "linux", Goarch: "arm64"}, "arm64"},
+		{config.Docker{Goos: "linux", Goarch: "arm64", Goarm64: "v9.0"}, "arm64v9"},
+		{config.Docker{Goos: "linux", Goarch: "mips", Gomips: "softfloat"}, "mips"},
+		{config.Docker{Goos: "darwin", Goarch: "arm64"}, "darwin"},
+	} {
+		t.Run(tt.expected, func(t *testing.T) {
+			result := ctx.Artifacts.Filter(filterFor(tt.docker)).List()
+			require.Len(t, result, 1)
+			require.Equal(t, tt.expected, result[0].Name)
+		})
+	}
+}
+
+func TestDigestOf(t *testing.T) {
+	const digest = "sha256:ea9ab2f3a7e2b8e3b0a9b7f4e3d6c2a1b0e9f8d7c6b5a4f3e2d1c0b9a8f7e6d5"
+	for name, out := range map[string]string{
+		"image push": `The push refers to repository [docker.io/goreleaser/foo]
+5b1f1b4e3d2c: Pushed
+latest: digest: ` + digest + ` size: 528
+`,
+		"manifest push": digest + "\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, digest, digestOf([]byte(out)))
+		})
+	}
+	require.Empty(t, digestOf([]byte("nothing to see here")))
+}
+
 func TestBuildCommand(t *testing.T) {
 	images := []string{"goreleaser/test_build_flag", "goreleaser/test_multiple_tags"}
 	tests := []struct {
@@ -1153,7 +1318,8 @@ func TestDefault(t *testing.T) {
 					IDs: []string{"aa"},
 				},
 				{
-					Use: useBuildx,
+					Use:  useBuildx,
+					Save: config.DockerSave{Enabled: true},
 				},
 			},
 			DockerManifests: []config.DockerManifest{
@@ -1169,10 +1335,13 @@ func TestDefault(t *testing.T) {
 	docker := ctx.Config.Dockers[0]
 	require.Equal(t, "linux", docker.Goos)
 	require.Equal(t, "amd64", docker.Goarch)
+	require.Equal(t, "hardfloat", docker.Gomips)
 	require.Equal(t, []string{"aa"}, docker.IDs)
 	require.Equal(t, useDocker, docker.Use)
+	require.Empty(t, docker.Save.NameTemplate)
 	docker = ctx.Config.Dockers[1]
 	require.Equal(t, useBuildx, docker.Use)
+	require.Equal(t, defaultSaveNameTemplate, docker.Save.NameTemplate)
 
 	require.NoError(t, ManifestPipe{}.Default(ctx))
 	require.Len(t, ctx.Config.DockerManifests, 2)
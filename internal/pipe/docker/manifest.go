@@ -42,7 +42,7 @@ func (ManifestPipe) Default(ctx *context.Context) error {
 
 // Publish the docker manifests.
 func (ManifestPipe) Publish(ctx *context.Context) error {
-	g := semerrgroup.NewSkipAware(semerrgroup.New(1))
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
 	for _, manifest := range ctx.Config.DockerManifests {
 		manifest := manifest
 		g.Go(func() error {
@@ -70,6 +70,13 @@ func (ManifestPipe) Publish(ctx *context.Context) error {
 			if err := manifester.Create(ctx, name, images, manifest.CreateFlags); err != nil {
 				return err
 			}
+
+			log.WithField("manifest", name).Info("pushing")
+			digest, err := manifester.Push(ctx, name, manifest.PushFlags)
+			if err != nil {
+				return err
+			}
+
 			art := &artifact.Artifact{
 				Type:  artifact.DockerManifest,
 				Name:  name,
@@ -79,10 +86,11 @@ func (ManifestPipe) Publish(ctx *context.Context) error {
 			if manifest.ID != "" {
 				art.Extra[artifact.ExtraID] = manifest.ID
 			}
+			if digest != "" {
+				art.Extra[artifact.ExtraDigest] = digest
+			}
 			ctx.Artifacts.Add(art)
-
-			log.WithField("manifest", name).Info("pushing")
-			return manifester.Push(ctx, name, manifest.PushFlags)
+			return nil
 		})
 	}
 	return g.Wait()
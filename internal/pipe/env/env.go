@@ -85,7 +85,12 @@ func (Pipe) Run(ctx *context.Context) error {
 	noTokens := githubToken == "" && gitlabToken == "" && giteaToken == ""
 	noTokenErrs := githubTokenErr == nil && gitlabTokenErr == nil && giteaTokenErr == nil
 
-	if err := checkErrors(ctx, noTokens, noTokenErrs, gitlabTokenErr, githubTokenErr, giteaTokenErr); err != nil {
+	releaseDisabled, err := mayDisableRelease(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := checkErrors(ctx, releaseDisabled, noTokens, noTokenErrs, gitlabTokenErr, githubTokenErr, giteaTokenErr); err != nil {
 		return err
 	}
 
@@ -113,8 +118,23 @@ func (Pipe) Run(ctx *context.Context) error {
 	return nil
 }
 
-func checkErrors(ctx *context.Context, noTokens, noTokenErrs bool, gitlabTokenErr, githubTokenErr, giteaTokenErr error) error {
-	if ctx.SkipTokenCheck || ctx.SkipPublish || ctx.Config.Release.Disable {
+// mayDisableRelease tells whether release.disable might disable the release.
+// auto depends on the current tag, which isn't parsed yet, so it is only
+// checked by the release pipe itself.
+func mayDisableRelease(ctx *context.Context) (bool, error) {
+	disable, err := tmpl.New(ctx).Apply(ctx.Config.Release.Disable)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply release.disable template: %w", err)
+	}
+	switch strings.TrimSpace(disable) {
+	case "true", "auto":
+		return true, nil
+	}
+	return false, nil
+}
+
+func checkErrors(ctx *context.Context, releaseDisabled, noTokens, noTokenErrs bool, gitlabTokenErr, githubTokenErr, giteaTokenErr error) error {
+	if ctx.SkipTokenCheck || ctx.SkipPublish || releaseDisabled {
 		return nil
 	}
 
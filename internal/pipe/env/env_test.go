@@ -213,13 +213,47 @@ func TestInvalidEnvReleaseDisabled(t *testing.T) {
 	ctx := &context.Context{
 		Config: config.Project{
 			Release: config.Release{
-				Disable: true,
+				Disable: "true",
 			},
 		},
 	}
 	require.NoError(t, Pipe{}.Run(ctx))
 }
 
+func TestInvalidEnvReleaseMaybeDisabled(t *testing.T) {
+	require.NoError(t, os.Unsetenv("GITHUB_TOKEN"))
+	for _, disable := range []string{"auto", "{{ .Env.NO_RELEASE }}"} {
+		t.Run(disable, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				Env: []string{"NO_RELEASE=true"},
+				Release: config.Release{
+					Disable: disable,
+				},
+			})
+			require.NoError(t, Pipe{}.Run(ctx))
+		})
+	}
+
+	t.Run("not disabled", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Env: []string{"NO_RELEASE=false"},
+			Release: config.Release{
+				Disable: "{{ .Env.NO_RELEASE }}",
+			},
+		})
+		require.ErrorIs(t, Pipe{}.Run(ctx), ErrMissingToken)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				Disable: "{{ .Nope }",
+			},
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), `failed to apply release.disable template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
 func TestLoadEnv(t *testing.T) {
 	t.Run("env exists", func(t *testing.T) {
 		env := "SUPER_SECRET_ENV"
@@ -19,14 +19,29 @@ type Pipe struct{}
 
 func (Pipe) String() string { return "loading go mod information" }
 
+// nolint: gochecknoglobals
+var validMods = []string{"mod", "readonly", "vendor"}
+
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
 	if ctx.Config.GoMod.GoBinary == "" {
 		ctx.Config.GoMod.GoBinary = "go"
 	}
+	if mod := ctx.Config.GoMod.Mod; mod != "" && !contains(validMods, mod) {
+		return fmt.Errorf("invalid gomod.mod: %s, valid options are %v", mod, validMods)
+	}
 	return nil
 }
 
+func contains(ss []string, s string) bool {
+	for _, z := range ss {
+		if z == s {
+			return true
+		}
+	}
+	return false
+}
+
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
 	flags := []string{"list", "-m"}
@@ -38,6 +53,9 @@ func (Pipe) Run(ctx *context.Context) error {
 	if result == go115NotAGoModuleError || result == go116NotAGoModuleError {
 		return pipe.Skip("not a go module")
 	}
+	if strings.Contains(result, "inconsistent vendoring") {
+		return fmt.Errorf("vendor directory is out of sync with go.mod, run 'go mod vendor' to fix it: %w: %s", err, result)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get module path: %w: %s", err, string(out))
 	}
@@ -31,6 +31,10 @@ func (ProxyPipe) Skip(ctx *context.Context) bool {
 func (ProxyPipe) Run(ctx *context.Context) error {
 	for i := range ctx.Config.Builds {
 		build := &ctx.Config.Builds[i]
+		if build.Builder != "" && build.Builder != "go" {
+			log.WithField("id", build.ID).Debug("skipping non-go build")
+			continue
+		}
 		if err := proxyBuild(ctx, build); err != nil {
 			return err
 		}
@@ -49,6 +49,33 @@ func TestGoModProxy(t *testing.T) {
 		require.Equal(t, ctx.ModulePath, ctx.ModulePath)
 	})
 
+	t.Run("prebuilt", func(t *testing.T) {
+		dir := testlib.Mktmp(t)
+		dist := filepath.Join(dir, "dist")
+		ctx := context.New(config.Project{
+			Dist: dist,
+			GoMod: config.GoMod{
+				Proxy:    true,
+				GoBinary: "go",
+			},
+			Builds: []config.Build{
+				{
+					ID:      "foo",
+					Builder: "prebuilt",
+					Main:    ".",
+					Dir:     ".",
+				},
+			},
+		})
+		ctx.Git.CurrentTag = "v0.161.1"
+		ctx.ModulePath = "github.com/goreleaser/goreleaser"
+
+		require.NoError(t, ProxyPipe{}.Run(ctx))
+		require.Equal(t, ".", ctx.Config.Builds[0].Main)
+		require.Equal(t, ".", ctx.Config.Builds[0].Dir)
+		require.Empty(t, ctx.Config.Builds[0].UnproxiedMain)
+	})
+
 	t.Run("nfpm", func(t *testing.T) {
 		dir := testlib.Mktmp(t)
 		dist := filepath.Join(dir, "dist")
@@ -29,6 +29,32 @@ func TestRunCustomMod(t *testing.T) {
 	require.Equal(t, "github.com/goreleaser/goreleaser", ctx.ModulePath)
 }
 
+func TestDefaultInvalidMod(t *testing.T) {
+	ctx := context.New(config.Project{
+		GoMod: config.GoMod{
+			Mod: "nope",
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "invalid gomod.mod: nope, valid options are [mod readonly vendor]")
+}
+
+func TestRunStaleVendor(t *testing.T) {
+	dir := testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module foo\n\ngo 1.17\n"), 0o666))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "vendor"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor", "modules.txt"), []byte("# example.com/x v1.0.0\n## explicit\nexample.com/x\n"), 0o666))
+	ctx := context.New(config.Project{
+		GoMod: config.GoMod{
+			Mod: "vendor",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "vendor directory is out of sync with go.mod")
+	require.Empty(t, ctx.ModulePath)
+}
+
 func TestRunOutsideGoModule(t *testing.T) {
 	dir := testlib.Mktmp(t)
 	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\nfunc main() {println(0)}"), 0o666))
@@ -0,0 +1,56 @@
+// Package goversion provides a pipe that checks that the installed go
+// toolchain satisfies the configured version constraint.
+package goversion
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+var versionRe = regexp.MustCompile(`go(\d+(?:\.\d+)*)`)
+
+// Pipe for goversion.
+type Pipe struct{}
+
+func (Pipe) String() string { return "checking go version" }
+
+func (Pipe) Skip(ctx *context.Context) bool {
+	return ctx.Config.Go == ""
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	constraint, err := semver.NewConstraint(ctx.Config.Go)
+	if err != nil {
+		return fmt.Errorf("invalid go version constraint: %s: %w", ctx.Config.Go, err)
+	}
+
+	gobin := ctx.Config.GoMod.GoBinary
+	if gobin == "" {
+		gobin = "go"
+	}
+	/* #nosec */
+	out, err := exec.CommandContext(ctx, gobin, "version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("unable to determine version of go binary (%s): %w", gobin, err)
+	}
+	match := versionRe.FindSubmatch(out)
+	if len(match) < 2 {
+		return fmt.Errorf("unable to parse go version: %s", out)
+	}
+	version, err := semver.NewVersion(string(match[1]))
+	if err != nil {
+		return fmt.Errorf("unable to parse go version: %s: %w", match[1], err)
+	}
+
+	log.WithField("version", version).Debug("found go")
+	if !constraint.Check(version) {
+		return fmt.Errorf("go %s does not satisfy the version constraint %s", version, ctx.Config.Go)
+	}
+	return nil
+}
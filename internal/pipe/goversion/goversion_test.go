@@ -0,0 +1,46 @@
+package goversion
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	require.False(t, Pipe{}.Skip(context.New(config.Project{Go: ">=1.9"})))
+}
+
+func TestRun(t *testing.T) {
+	t.Run("satisfied", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(context.New(config.Project{Go: ">=1.9"})))
+	})
+
+	t.Run("not satisfied", func(t *testing.T) {
+		err := Pipe{}.Run(context.New(config.Project{Go: "<1.9"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not satisfy the version constraint <1.9")
+	})
+
+	t.Run("invalid constraint", func(t *testing.T) {
+		err := Pipe{}.Run(context.New(config.Project{Go: "nope"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid go version constraint: nope")
+	})
+
+	t.Run("invalid go binary", func(t *testing.T) {
+		err := Pipe{}.Run(context.New(config.Project{
+			Go: ">=1.9",
+			GoMod: config.GoMod{
+				GoBinary: "nope",
+			},
+		}))
+		require.EqualError(t, err, `unable to determine version of go binary (nope): exec: "nope": executable file not found in $PATH`)
+	})
+}
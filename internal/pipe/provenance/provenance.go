@@ -0,0 +1,155 @@
+// Package provenance generates a SLSA provenance statement describing how the
+// released artifacts were built, inside a DSSE envelope.
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/intoto"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const (
+	defaultNameTemplate = "{{ .ProjectName }}_{{ .Version }}.intoto.jsonl"
+	defaultBuilderID    = "https://goreleaser.com"
+
+	predicateType = "https://slsa.dev/provenance/v0.2"
+	buildType     = "https://goreleaser.com/provenance/v1"
+)
+
+// Pipe for provenance.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "provenance" }
+func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Provenance.Enabled }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	cfg := &ctx.Config.Provenance
+	if cfg.NameTemplate == "" {
+		cfg.NameTemplate = defaultNameTemplate
+	}
+	if cfg.BuilderID == "" {
+		cfg.BuilderID = defaultBuilderID
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	cfg := ctx.Config.Provenance
+	artifacts := ctx.Artifacts.Filter(intoto.Filter(cfg.IDs)).List()
+	if len(artifacts) == 0 {
+		log.Warn("no artifacts to describe, skipping provenance")
+		return nil
+	}
+
+	subjects, err := intoto.SubjectsOf(ctx, artifacts)
+	if err != nil {
+		return err
+	}
+
+	t := tmpl.New(ctx)
+	name, err := t.Apply(cfg.NameTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to apply provenance name template: %w", err)
+	}
+	builderID, err := t.Apply(cfg.BuilderID)
+	if err != nil {
+		return fmt.Errorf("failed to apply provenance builder_id template: %w", err)
+	}
+
+	statement, err := newStatement(ctx, builderID, subjects)
+	if err != nil {
+		return err
+	}
+	env, err := intoto.NewEnvelope(ctx, statement, cfg.Signer)
+	if err != nil {
+		return fmt.Errorf("failed to sign provenance: %w", err)
+	}
+	bts, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(ctx.Config.Dist, name)
+	log.WithField("file", path).Info("writing provenance")
+	if err := os.WriteFile(path, append(bts, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write provenance: %w", err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Provenance,
+		Name: name,
+		Path: path,
+	})
+	return nil
+}
+
+func newStatement(ctx *context.Context, builderID string, subjects []intoto.Subject) (intoto.Statement, error) {
+	source := material{
+		URI:    "git+" + ctx.Git.URL + "@" + ctx.Git.CurrentTag,
+		Digest: map[string]string{"sha1": ctx.Git.FullCommit},
+	}
+	pred, err := json.Marshal(predicate{
+		Builder:   builder{ID: builderID},
+		BuildType: buildType,
+		Invocation: invocation{
+			ConfigSource: configSource{
+				URI:    source.URI,
+				Digest: source.Digest,
+			},
+		},
+		Metadata: metadata{
+			BuildStartedOn:  ctx.Date.UTC().Format(time.RFC3339),
+			BuildFinishedOn: time.Now().UTC().Format(time.RFC3339),
+		},
+		Materials: []material{source},
+	})
+	if err != nil {
+		return intoto.Statement{}, err
+	}
+	return intoto.Statement{
+		Type:          intoto.StatementType,
+		PredicateType: predicateType,
+		Subject:       subjects,
+		Predicate:     pred,
+	}, nil
+}
+
+type predicate struct {
+	Builder    builder    `json:"builder"`
+	BuildType  string     `json:"buildType"`
+	Invocation invocation `json:"invocation"`
+	Metadata   metadata   `json:"metadata"`
+	Materials  []material `json:"materials"`
+}
+
+type builder struct {
+	ID string `json:"id"`
+}
+
+type invocation struct {
+	ConfigSource configSource `json:"configSource"`
+}
+
+type configSource struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+type metadata struct {
+	BuildStartedOn  string `json:"buildStartedOn"`
+	BuildFinishedOn string `json:"buildFinishedOn"`
+}
+
+type material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
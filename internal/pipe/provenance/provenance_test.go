@@ -0,0 +1,195 @@
+package provenance
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/intoto"
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	require.False(t, Pipe{}.Skip(context.New(config.Project{
+		Provenance: config.Provenance{Enabled: true},
+	})))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultNameTemplate, ctx.Config.Provenance.NameTemplate)
+	require.Equal(t, defaultBuilderID, ctx.Config.Provenance.BuilderID)
+}
+
+func TestRun(t *testing.T) {
+	const sum = testlib.ReleaseArtifactsSum
+	for name, tt := range map[string]struct {
+		ids      []string
+		subjects []intoto.Subject
+	}{
+		"all": {
+			subjects: []intoto.Subject{
+				{Name: "foo_1.2.3_amd64.deb", Digest: map[string]string{"sha256": sum}},
+				{Name: "foo_linux_amd64.tar.gz", Digest: map[string]string{"sha256": sum}},
+				{Name: "foo_windows_amd64.zip", Digest: map[string]string{"sha256": sum}},
+			},
+		},
+		"filter by id": {
+			ids: []string{"packages"},
+			subjects: []intoto.Subject{
+				{Name: "foo_1.2.3_amd64.deb", Digest: map[string]string{"sha256": sum}},
+			},
+		},
+		"no artifacts": {
+			ids: []string{"nope"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				ProjectName: "foo",
+				Dist:        t.TempDir(),
+				Provenance: config.Provenance{
+					Enabled:   true,
+					IDs:       tt.ids,
+					BuilderID: "{{ .Env.RUNNER }}",
+				},
+			})
+			ctx.Version = "1.2.3"
+			ctx.Date = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+			ctx.Git = context.GitInfo{
+				URL:        "https://github.com/goreleaser/foo.git",
+				CurrentTag: "v1.2.3",
+				FullCommit: "a1b2c3d4e5f6",
+			}
+			ctx.Env = map[string]string{"RUNNER": "https://ci.example.com/runner"}
+			testlib.AddReleaseArtifacts(t, ctx)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			provenances := ctx.Artifacts.Filter(artifact.ByType(artifact.Provenance)).List()
+			if len(tt.subjects) == 0 {
+				require.Empty(t, provenances)
+				return
+			}
+			require.Len(t, provenances, 1)
+			require.Equal(t, "foo_1.2.3.intoto.jsonl", provenances[0].Name)
+
+			env, st, pred := readEnvelope(t, provenances[0].Path)
+			require.Equal(t, intoto.PayloadType, env.PayloadType)
+			require.Empty(t, env.Signatures)
+			require.Equal(t, intoto.StatementType, st.Type)
+			require.Equal(t, predicateType, st.PredicateType)
+			require.Equal(t, tt.subjects, st.Subject)
+			require.Equal(t, "https://ci.example.com/runner", pred.Builder.ID)
+			require.Equal(t, buildType, pred.BuildType)
+			require.Equal(t, "git+https://github.com/goreleaser/foo.git@v1.2.3", pred.Invocation.ConfigSource.URI)
+			require.Equal(t, map[string]string{"sha1": "a1b2c3d4e5f6"}, pred.Invocation.ConfigSource.Digest)
+			require.Equal(t, "2022-06-01T10:00:00Z", pred.Metadata.BuildStartedOn)
+			require.NotEmpty(t, pred.Metadata.BuildFinishedOn)
+			require.Equal(t, []material{{
+				URI:    "git+https://github.com/goreleaser/foo.git@v1.2.3",
+				Digest: map[string]string{"sha1": "a1b2c3d4e5f6"},
+			}}, pred.Materials)
+		})
+	}
+}
+
+func TestRunSigned(t *testing.T) {
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        t.TempDir(),
+		Provenance: config.Provenance{
+			Enabled: true,
+			Signer: config.AttestationSigner{
+				Cmd:   "sh",
+				Args:  []string{"-c", "cp ${payload} ${signature}"},
+				KeyID: "my-key",
+			},
+		},
+	})
+	ctx.Version = "1.2.3"
+	testlib.AddReleaseArtifacts(t, ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	provenances := ctx.Artifacts.Filter(artifact.ByType(artifact.Provenance)).List()
+	require.Len(t, provenances, 1)
+	env, st, _ := readEnvelope(t, provenances[0].Path)
+	require.Equal(t, predicateType, st.PredicateType)
+	require.Len(t, env.Signatures, 1)
+	require.Equal(t, "my-key", env.Signatures[0].KeyID)
+	sig, err := base64.StdEncoding.DecodeString(env.Signatures[0].Sig)
+	require.NoError(t, err)
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	require.NoError(t, err)
+	require.Equal(t, string(intoto.PAE(intoto.PayloadType, payload)), string(sig))
+}
+
+func TestRunSignerFails(t *testing.T) {
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Dist:        t.TempDir(),
+		Provenance: config.Provenance{
+			Enabled: true,
+			Signer: config.AttestationSigner{
+				Cmd:  "sh",
+				Args: []string{"-c", "echo oops; exit 1"},
+			},
+		},
+	})
+	testlib.AddReleaseArtifacts(t, ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Run(ctx), "failed to sign provenance: sh failed: exit status 1: oops\n")
+}
+
+func TestRunInvalidTemplate(t *testing.T) {
+	for name, tt := range map[string]struct {
+		provenance config.Provenance
+		err        string
+	}{
+		"name": {
+			provenance: config.Provenance{Enabled: true, NameTemplate: "{{ .Nope }"},
+			err:        `failed to apply provenance name template: template: tmpl:1: unexpected "}" in operand`,
+		},
+		"builder id": {
+			provenance: config.Provenance{Enabled: true, BuilderID: "{{ .Nope }"},
+			err:        `failed to apply provenance builder_id template: template: tmpl:1: unexpected "}" in operand`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				ProjectName: "foo",
+				Dist:        t.TempDir(),
+				Provenance:  tt.provenance,
+			})
+			testlib.AddReleaseArtifacts(t, ctx)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.EqualError(t, Pipe{}.Run(ctx), tt.err)
+		})
+	}
+}
+
+func readEnvelope(tb testing.TB, path string) (intoto.Envelope, intoto.Statement, predicate) {
+	tb.Helper()
+	bts, err := os.ReadFile(path)
+	require.NoError(tb, err)
+	var env intoto.Envelope
+	require.NoError(tb, json.Unmarshal(bts, &env))
+	st, err := env.Statement()
+	require.NoError(tb, err)
+	var pred predicate
+	require.NoError(tb, json.Unmarshal(st.Predicate, &pred))
+	return env, st, pred
+}
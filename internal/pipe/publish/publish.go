@@ -9,6 +9,7 @@ import (
 	"github.com/goreleaser/goreleaser/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/internal/pipe/artifactory"
 	"github.com/goreleaser/goreleaser/internal/pipe/aur"
+	"github.com/goreleaser/goreleaser/internal/pipe/bitbucket"
 	"github.com/goreleaser/goreleaser/internal/pipe/blob"
 	"github.com/goreleaser/goreleaser/internal/pipe/brew"
 	"github.com/goreleaser/goreleaser/internal/pipe/custompublishers"
@@ -44,6 +45,7 @@ var publishers = []Publisher{
 	snapcraft.Pipe{},
 	// This should be one of the last steps
 	release.Pipe{},
+	bitbucket.Pipe{},
 	// brew et al use the release URL, so, they should be last
 	brew.Pipe{},
 	aur.Pipe{},
@@ -14,7 +14,7 @@ func TestDescription(t *testing.T) {
 
 func TestPublish(t *testing.T) {
 	ctx := context.New(config.Project{})
-	ctx.Config.Release.Disable = true
+	ctx.Config.Release.Disable = "true"
 	ctx.TokenType = context.TokenTypeGitHub
 	for i := range ctx.Config.Dockers {
 		ctx.Config.Dockers[i].SkipPush = "true"
@@ -2,39 +2,119 @@ package release
 
 import (
 	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"text/template"
 
+	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/tmpl"
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
 
 const bodyTemplateText = `{{ with .Header }}{{ . }}{{ "\n" }}{{ end }}
 {{- .ReleaseNotes }}
+{{- with .TransparencyLog }}
+
+## Transparency log
+{{ range . }}
+- {{ . }}
+{{- end }}
+{{- end }}
 {{- with .Footer }}{{ "\n" }}{{ . }}{{ end }}
 `
 
 func describeBody(ctx *context.Context) (bytes.Buffer, error) {
 	var out bytes.Buffer
-	t := tmpl.New(ctx)
+	header, err := inlineOrFile(ctx.Config.Release.Header, ctx.Config.Release.HeaderFile)
+	if err != nil {
+		return out, err
+	}
+	footer, err := inlineOrFile(ctx.Config.Release.Footer, ctx.Config.Release.FooterFile)
+	if err != nil {
+		return out, err
+	}
 
-	header, err := t.Apply(ctx.Config.Release.Header)
+	sums, err := checksums(ctx)
+	if err != nil {
+		return out, err
+	}
+	t := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"Checksums": sums,
+	})
+	header, err = t.Apply(header)
 	if err != nil {
 		return out, err
 	}
-	footer, err := t.Apply(ctx.Config.Release.Footer)
+	footer, err = t.Apply(footer)
 	if err != nil {
 		return out, err
 	}
 
 	bodyTemplate := template.Must(template.New("release").Parse(bodyTemplateText))
 	err = bodyTemplate.Execute(&out, struct {
-		Header       string
-		Footer       string
-		ReleaseNotes string
+		Header          string
+		Footer          string
+		ReleaseNotes    string
+		TransparencyLog []string
 	}{
-		Header:       header,
-		Footer:       footer,
-		ReleaseNotes: ctx.ReleaseNotes,
+		Header:          header,
+		Footer:          footer,
+		ReleaseNotes:    ctx.ReleaseNotes,
+		TransparencyLog: transparencyLog(ctx),
 	})
 	return out, err
 }
+
+// inlineOrFile returns the inline template, or the contents of the given
+// file if there is no inline one.
+func inlineOrFile(inline, file string) (string, error) {
+	if inline != "" || file == "" {
+		return inline, nil
+	}
+	bts, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return string(bts), nil
+}
+
+// checksums returns the contents of the main checksums file, if any.
+// Sidecars and the extra checksum outputs are not included.
+func checksums(ctx *context.Context) (string, error) {
+	sums := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.Checksum),
+		func(a *artifact.Artifact) bool {
+			return a.ExtraOr(artifact.ExtraChecksumsMain, false).(bool)
+		},
+	)).List()
+	if len(sums) == 0 {
+		return "", nil
+	}
+	bts, err := os.ReadFile(sums[0].Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(bts)), nil
+}
+
+// transparencyLog lists the rekor entries of the signatures, if any.
+func transparencyLog(ctx *context.Context) []string {
+	var entries []string
+	for _, sig := range ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List() {
+		index := sig.ExtraOr(artifact.ExtraRekorLogIndex, "").(string)
+		if index == "" {
+			continue
+		}
+		entries = append(entries, fmt.Sprintf(
+			"`%s`: [%s](%s/api/v1/log/entries?logIndex=%s)",
+			sig.Name,
+			index,
+			sig.ExtraOr(artifact.ExtraRekorURL, "").(string),
+			index,
+		))
+	}
+	sort.Strings(entries)
+	return entries
+}
@@ -1,8 +1,11 @@
 package release
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/golden"
 	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
@@ -45,6 +48,127 @@ func TestDescribeBodyWithHeaderAndFooter(t *testing.T) {
 	golden.RequireEqual(t, out.Bytes())
 }
 
+func TestDescribeBodyWithHeaderAndFooterFiles(t *testing.T) {
+	dir := t.TempDir()
+	header := filepath.Join(dir, "header.md")
+	footer := filepath.Join(dir, "footer.md")
+	sums := filepath.Join(dir, "checksums.txt")
+	require.NoError(t, os.WriteFile(header, []byte("## Install\n\ngo install foo@{{ .Tag }}\n"), 0o644))
+	require.NoError(t, os.WriteFile(footer, []byte("## Checksums\n\n```\n{{ .Checksums }}\n```"), 0o644))
+	require.NoError(t, os.WriteFile(sums, []byte("abc  foo.tar.gz\ndef  foo.deb\n"), 0o644))
+
+	ctx := context.New(config.Project{
+		Release: config.Release{
+			HeaderFile: header,
+			FooterFile: footer,
+		},
+	})
+	ctx.ReleaseNotes = "feature1: description\n"
+	ctx.Git = context.GitInfo{CurrentTag: "v1.0"}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Path: sums,
+		Type: artifact.Checksum,
+		Extra: map[string]interface{}{
+			artifact.ExtraChecksumsMain: true,
+		},
+	})
+	// sidecars and other checksum outputs are not part of .Checksums.
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo.tar.gz.sha256",
+		Path: header,
+		Type: artifact.Checksum,
+	})
+	out, err := describeBody(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "## Install\n\ngo install foo@v1.0\n\nfeature1: description\n\n## Checksums\n\n```\nabc  foo.tar.gz\ndef  foo.deb\n```\n", out.String())
+}
+
+func TestDescribeBodyWithoutChecksums(t *testing.T) {
+	ctx := context.New(config.Project{
+		Release: config.Release{
+			Footer: "checksums: '{{ .Checksums }}'",
+		},
+	})
+	out, err := describeBody(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "\nchecksums: ''\n", out.String())
+}
+
+func TestDescribeBodyWithMissingChecksumsFile(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Path: filepath.Join(t.TempDir(), "nope.txt"),
+		Type: artifact.Checksum,
+		Extra: map[string]interface{}{
+			artifact.ExtraChecksumsMain: true,
+		},
+	})
+	_, err := describeBody(ctx)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDescribeBodyInlineHeaderOverridesFile(t *testing.T) {
+	ctx := context.New(config.Project{
+		Release: config.Release{
+			Header:     "inline",
+			HeaderFile: "testdata/nope.md",
+		},
+	})
+	out, err := describeBody(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "inline\n\n", out.String())
+}
+
+func TestDescribeBodyWithMissingFooterFile(t *testing.T) {
+	ctx := context.New(config.Project{
+		Release: config.Release{
+			FooterFile: "testdata/nope.md",
+		},
+	})
+	_, err := describeBody(ctx)
+	require.EqualError(t, err, "open testdata/nope.md: no such file or directory")
+}
+
+func TestDescribeBodyWithTransparencyLog(t *testing.T) {
+	changelog := "feature1: description\nfeature2: other description"
+	ctx := context.New(config.Project{
+		Release: config.Release{
+			Footer: "---\nfooter",
+		},
+	})
+	ctx.ReleaseNotes = changelog
+	for _, sig := range []*artifact.Artifact{
+		{
+			Name: "checksums.txt.sig",
+			Type: artifact.Signature,
+			Extra: map[string]interface{}{
+				artifact.ExtraRekorURL:      "https://rekor.sigstore.dev",
+				artifact.ExtraRekorLogIndex: "1234",
+			},
+		},
+		{
+			Name: "foo.tar.gz.sig",
+			Type: artifact.Signature,
+			Extra: map[string]interface{}{
+				artifact.ExtraRekorURL:      "https://rekor.sigstore.dev",
+				artifact.ExtraRekorLogIndex: "1235",
+			},
+		},
+		{
+			Name: "foo.tar.gz.asc",
+			Type: artifact.Signature,
+		},
+	} {
+		ctx.Artifacts.Add(sig)
+	}
+	out, err := describeBody(ctx)
+	require.NoError(t, err)
+
+	golden.RequireEqual(t, out.Bytes())
+}
+
 func TestDescribeBodyWithInvalidHeaderTemplate(t *testing.T) {
 	ctx := context.New(config.Project{
 		Release: config.Release{
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/apex/log"
@@ -11,7 +12,9 @@ import (
 	"github.com/goreleaser/goreleaser/internal/client"
 	"github.com/goreleaser/goreleaser/internal/extrafiles"
 	"github.com/goreleaser/goreleaser/internal/git"
+	"github.com/goreleaser/goreleaser/internal/pipe/env"
 	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
 	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
@@ -24,10 +27,14 @@ var ErrMultipleReleases = errors.New("multiple releases are defined. Only one is
 type Pipe struct{}
 
 func (Pipe) String() string                 { return "scm releases" }
-func (Pipe) Skip(ctx *context.Context) bool { return ctx.Config.Release.Disable }
+func (Pipe) Skip(ctx *context.Context) bool { return ctx.Config.Release.Disable == "true" }
 
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
+	if err := setDisable(ctx); err != nil {
+		return err
+	}
+
 	numOfReleases := 0
 	if ctx.Config.Release.GitHub.String() != "" {
 		numOfReleases++
@@ -95,6 +102,18 @@ func (Pipe) Default(ctx *context.Context) error {
 		)
 	}
 
+	if ctx.TokenType == context.TokenTypeGitLab || ctx.TokenType == context.TokenTypeGitea {
+		if ctx.Config.Release.DiscussionCategoryName != "" {
+			log.Warn("release.discussion_category_name is only supported on GitHub, ignoring it")
+		}
+		if ctx.Config.Release.UseGitHubReleaseNotes {
+			log.Warn("release.use_github_release_notes is only supported on GitHub, ignoring it")
+		}
+	}
+	if ctx.TokenType == context.TokenTypeGitea && ctx.Config.Release.ReplaceExistingArtifacts {
+		log.Warn("release.replace_existing_artifacts is only supported on GitHub and GitLab, ignoring it")
+	}
+
 	// Check if we have to check the git tag for an indicator to mark as pre release
 	switch ctx.Config.Release.Prerelease {
 	case "auto":
@@ -104,12 +123,39 @@ func (Pipe) Default(ctx *context.Context) error {
 		log.Debugf("pre-release was detected for tag %s: %v", ctx.Git.CurrentTag, ctx.PreRelease)
 	case "true":
 		ctx.PreRelease = true
+	case "", "false":
+	default:
+		return fmt.Errorf("invalid prerelease: %q, valid options are true, false and auto", ctx.Config.Release.Prerelease)
 	}
 	log.Debugf("pre-release for tag %s set to %v", ctx.Git.CurrentTag, ctx.PreRelease)
 
 	return nil
 }
 
+// setDisable evaluates the release.disable template, setting it to either
+// "true" or empty. auto disables the release if the tag is a prerelease.
+func setDisable(ctx *context.Context) error {
+	disable, err := tmpl.New(ctx).Apply(ctx.Config.Release.Disable)
+	if err != nil {
+		return fmt.Errorf("failed to apply release.disable template: %w", err)
+	}
+	switch strings.TrimSpace(disable) {
+	case "true":
+		ctx.Config.Release.Disable = "true"
+	case "auto":
+		ctx.Config.Release.Disable = ""
+		if ctx.Semver.Prerelease != "" {
+			ctx.Config.Release.Disable = "true"
+		}
+	case "", "false":
+		ctx.Config.Release.Disable = ""
+	default:
+		return fmt.Errorf("invalid release.disable: %q, valid options are true, false and auto", disable)
+	}
+	log.Debugf("release disabled: %v", ctx.Config.Release.Disable == "true")
+	return nil
+}
+
 func getRepository(ctx *context.Context) (config.Repo, error) {
 	repo, err := git.ExtractRepoFromConfig(ctx)
 	if err != nil {
@@ -123,6 +169,10 @@ func getRepository(ctx *context.Context) (config.Repo, error) {
 
 // Publish the release.
 func (Pipe) Publish(ctx *context.Context) error {
+	// the env pipe doesn't require a token if the release might be disabled
+	if ctx.Token == "" {
+		return env.ErrMissingToken
+	}
 	c, err := client.New(ctx)
 	if err != nil {
 		return err
@@ -159,16 +209,7 @@ func doPublish(ctx *context.Context, client client.Client) error {
 		})
 	}
 
-	filters := artifact.Or(
-		artifact.ByType(artifact.UploadableArchive),
-		artifact.ByType(artifact.UploadableBinary),
-		artifact.ByType(artifact.UploadableSourceArchive),
-		artifact.ByType(artifact.Checksum),
-		artifact.ByType(artifact.Signature),
-		artifact.ByType(artifact.Certificate),
-		artifact.ByType(artifact.LinuxPackage),
-		artifact.ByType(artifact.SBOM),
-	)
+	filters := artifact.ByReleaseUploadableTypes()
 
 	if len(ctx.Config.Release.IDs) > 0 {
 		filters = artifact.And(filters, artifact.ByIDs(ctx.Config.Release.IDs...))
@@ -8,6 +8,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/internal/artifact"
 	"github.com/goreleaser/goreleaser/internal/client"
+	"github.com/goreleaser/goreleaser/internal/pipe/env"
 	"github.com/goreleaser/goreleaser/internal/testlib"
 	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
@@ -458,6 +459,16 @@ func TestDefaultPreRelease(t *testing.T) {
 		require.False(t, ctx.PreRelease)
 	})
 
+	t.Run("invalid", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				Prerelease: "yes",
+			},
+		})
+		ctx.TokenType = context.TokenTypeGitHub
+		require.EqualError(t, Pipe{}.Default(ctx), `invalid prerelease: "yes", valid options are true, false and auto`)
+	})
+
 	t.Run("auto-release", func(t *testing.T) {
 		ctx := context.New(config.Project{
 			Release: config.Release{
@@ -520,7 +531,7 @@ func TestDefaultPipeDisabled(t *testing.T) {
 
 	ctx := context.New(config.Project{
 		Release: config.Release{
-			Disable: true,
+			Disable: "true",
 		},
 	})
 	ctx.TokenType = context.TokenTypeGitHub
@@ -529,6 +540,62 @@ func TestDefaultPipeDisabled(t *testing.T) {
 	require.Equal(t, "goreleaser", ctx.Config.Release.GitHub.Owner)
 }
 
+func TestDefaultDisable(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@github.com:goreleaser/goreleaser.git")
+
+	for name, tt := range map[string]struct {
+		disable    string
+		prerelease string
+		expected   string
+	}{
+		"empty":           {"", "", ""},
+		"false":           {"false", "", ""},
+		"true":            {"true", "", "true"},
+		"auto stable":     {"auto", "", ""},
+		"auto prerelease": {"auto", "rc1", "true"},
+		"template":        {"{{ .Env.NO_RELEASE }}", "", "true"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				Release: config.Release{
+					Disable: tt.disable,
+				},
+			})
+			ctx.Env["NO_RELEASE"] = "true"
+			ctx.TokenType = context.TokenTypeGitHub
+			ctx.Semver.Prerelease = tt.prerelease
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.Equal(t, tt.expected, ctx.Config.Release.Disable)
+			require.Equal(t, tt.expected == "true", Pipe{}.Skip(ctx))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				Disable: "yes",
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), `invalid release.disable: "yes", valid options are true, false and auto`)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Release: config.Release{
+				Disable: "{{ .Nope }",
+			},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), `failed to apply release.disable template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
+func TestPublishMissingToken(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.ErrorIs(t, Pipe{}.Publish(ctx), env.ErrMissingToken)
+}
+
 func TestDefaultFilled(t *testing.T) {
 	testlib.Mktmp(t)
 	testlib.GitInit(t)
@@ -606,7 +673,7 @@ func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		ctx := context.New(config.Project{
 			Release: config.Release{
-				Disable: true,
+				Disable: "true",
 			},
 		})
 		require.True(t, Pipe{}.Skip(ctx))
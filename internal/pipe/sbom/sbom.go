@@ -51,6 +51,11 @@ func setConfigDefaults(cfg *config.SBOM) error {
 	if cfg.Artifacts == "" {
 		cfg.Artifacts = "archive"
 	}
+	switch cfg.Artifacts {
+	case "source", "archive", "binary", "package", "any":
+	default:
+		return fmt.Errorf("invalid list of artifacts to catalog: %s", cfg.Artifacts)
+	}
 	if len(cfg.Documents) == 0 {
 		switch cfg.Artifacts {
 		case "binary":
@@ -213,7 +218,7 @@ func catalogArtifact(ctx *context.Context, cfg config.SBOM, a *artifact.Artifact
 		for _, match := range matches {
 			artifacts = append(artifacts, &artifact.Artifact{
 				Type: artifact.SBOM,
-				Name: filepath.Base(path),
+				Name: filepath.Base(match),
 				Path: match,
 				Extra: map[string]interface{}{
 					artifact.ExtraID: cfg.ID,
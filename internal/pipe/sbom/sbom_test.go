@@ -172,6 +172,15 @@ func TestSBOMCatalogInvalidArtifacts(t *testing.T) {
 	require.EqualError(t, err, "invalid list of artifacts to catalog: foo")
 }
 
+func TestSBOMCatalogDefaultInvalidArtifacts(t *testing.T) {
+	ctx := context.New(config.Project{
+		SBOMs: []config.SBOM{
+			{Artifacts: "foo"},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "invalid list of artifacts to catalog: foo")
+}
+
 func TestSeveralSBOMsWithTheSameID(t *testing.T) {
 	ctx := &context.Context{
 		Config: config.Project{
@@ -421,6 +430,29 @@ func TestSBOMCatalogArtifacts(t *testing.T) {
 				"artifact4.test-user-name.sbom",
 			},
 		},
+		{
+			desc: "catalog 'any' artifacts with a glob",
+			ctx: context.New(
+				config.Project{
+					SBOMs: []config.SBOM{
+						{
+							Artifacts: "any",
+							Cmd:       "sh",
+							Args:      []string{"-c", "echo a > a.cdx.json && echo b > b.cdx.json"},
+							Documents: []string{"*.cdx.json"},
+						},
+					},
+				},
+			),
+			sbomPaths: []string{
+				"a.cdx.json",
+				"b.cdx.json",
+			},
+			sbomNames: []string{
+				"a.cdx.json",
+				"b.cdx.json",
+			},
+		},
 		{
 			desc: "cataloging 'any' artifacts fails",
 			ctx: context.New(
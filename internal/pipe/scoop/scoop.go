@@ -137,7 +137,7 @@ func doPublish(ctx *context.Context, cl client.Client) error {
 	if ctx.Config.Release.Draft {
 		return pipe.Skip("release is marked as draft")
 	}
-	if ctx.Config.Release.Disable {
+	if ctx.Config.Release.Disable == "true" {
 		return pipe.Skip("release is disabled")
 	}
 
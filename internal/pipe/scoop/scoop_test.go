@@ -415,7 +415,7 @@ func Test_doRun(t *testing.T) {
 					Config: config.Project{
 						ProjectName: "run-pipe",
 						Release: config.Release{
-							Disable: true,
+							Disable: "true",
 						},
 						Scoop: config.Scoop{
 							Bucket: config.RepoRef{
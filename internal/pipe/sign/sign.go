@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/apex/log"
@@ -21,6 +22,23 @@ import (
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
 
+var validArtifacts = map[string]bool{
+	"all":      true,
+	"none":     true,
+	"checksum": true,
+	"source":   true,
+	"archive":  true,
+	"binary":   true,
+	"sbom":     true,
+	"package":  true,
+}
+
+const defaultRekorURL = "https://rekor.sigstore.dev"
+
+// rekorLogIndexRe matches the log index cosign prints once the signature
+// was uploaded to rekor.
+var rekorLogIndexRe = regexp.MustCompile(`tlog entry created with index: (\d+)`)
+
 // Pipe that signs common artifacts.
 type Pipe struct{}
 
@@ -32,18 +50,15 @@ func (Pipe) Default(ctx *context.Context) error {
 	ids := ids.New("signs")
 	for i := range ctx.Config.Signs {
 		cfg := &ctx.Config.Signs[i]
-		if cfg.Cmd == "" {
-			cfg.Cmd = "gpg"
-		}
-		if cfg.Signature == "" {
-			cfg.Signature = "${artifact}.sig"
-		}
-		if len(cfg.Args) == 0 {
-			cfg.Args = []string{"--output", "$signature", "--detach-sig", "$artifact"}
+		if err := setDefaults(cfg, "${artifact}"); err != nil {
+			return err
 		}
 		if cfg.Artifacts == "" {
 			cfg.Artifacts = "none"
 		}
+		if !validArtifacts[cfg.Artifacts] {
+			return fmt.Errorf("invalid list of artifacts to sign: %s", cfg.Artifacts)
+		}
 		if cfg.ID == "" {
 			cfg.ID = "default"
 		}
@@ -52,6 +67,70 @@ func (Pipe) Default(ctx *context.Context) error {
 	return ids.Validate()
 }
 
+// setDefaults sets the defaults shared by the signs and binary_signs, the
+// default signature being the given name plus the signature extension.
+func setDefaults(cfg *config.Sign, signature string) error {
+	if cfg.GPG != (config.SignGPG{}) && ((cfg.Cmd != "" && cfg.Cmd != "gpg") || len(cfg.Args) > 0) {
+		return fmt.Errorf("gpg options can only be used with the default cmd and args")
+	}
+	if cfg.Cmd == "" {
+		cfg.Cmd = "gpg"
+	}
+	if err := setRekorDefaults(cfg); err != nil {
+		return err
+	}
+	if cfg.Signature == "" {
+		cfg.Signature = signature + ".sig"
+		if cfg.GPG.Armor || cfg.GPG.Mode == "clear" {
+			cfg.Signature = signature + ".asc"
+		}
+	}
+	if len(cfg.Args) == 0 {
+		args, err := gpgArgs(cfg.GPG)
+		if err != nil {
+			return err
+		}
+		cfg.Args = args
+	}
+	return nil
+}
+
+// setRekorDefaults validates and sets the defaults of the rekor options, which
+// are only supported by cosign.
+func setRekorDefaults(cfg *config.Sign) error {
+	if !cfg.Rekor.Enabled {
+		return nil
+	}
+	if filepath.Base(cfg.Cmd) != "cosign" {
+		return fmt.Errorf("rekor can only be used with cosign")
+	}
+	if cfg.Rekor.URL == "" {
+		cfg.Rekor.URL = defaultRekorURL
+	}
+	return nil
+}
+
+// gpgArgs builds the gpg arguments for the given options.
+func gpgArgs(opts config.SignGPG) ([]string, error) {
+	var args []string
+	if opts.KeyID != "" {
+		args = append(args, "--local-user", opts.KeyID)
+	}
+	if opts.Armor {
+		args = append(args, "--armor")
+	}
+	args = append(args, "--output", "$signature")
+	switch opts.Mode {
+	case "", "detach":
+		args = append(args, "--detach-sig")
+	case "clear":
+		args = append(args, "--clearsign")
+	default:
+		return nil, fmt.Errorf("invalid gpg mode: %s", opts.Mode)
+	}
+	return append(args, "$artifact"), nil
+}
+
 // Run executes the Pipe.
 func (Pipe) Run(ctx *context.Context) error {
 	g := semerrgroup.New(ctx.Parallelism)
@@ -65,6 +144,9 @@ func (Pipe) Run(ctx *context.Context) error {
 				if len(cfg.IDs) > 0 {
 					log.Warn("when artifacts is `checksum`, `ids` has no effect. ignoring")
 				}
+				if ctx.Config.Checksum.Disable {
+					log.Warn("checksum.disable is set, only sidecar and output checksum files will be signed")
+				}
 			case "source":
 				filters = append(filters, artifact.ByType(artifact.UploadableSourceArchive))
 				if len(cfg.IDs) > 0 {
@@ -78,6 +160,9 @@ func (Pipe) Run(ctx *context.Context) error {
 					artifact.ByType(artifact.Checksum),
 					artifact.ByType(artifact.LinuxPackage),
 					artifact.ByType(artifact.SBOM),
+					artifact.ByType(artifact.DebugSymbols),
+					artifact.ByType(artifact.Provenance),
+					artifact.ByType(artifact.Attestation),
 				))
 			case "archive":
 				filters = append(filters, artifact.ByType(artifact.UploadableArchive))
@@ -141,8 +226,8 @@ func relativeToDist(dist, f string) (string, error) {
 	return filepath.Join(dist, f), nil
 }
 
-func tmplPath(ctx *context.Context, env map[string]string, s string) (string, error) {
-	result, err := tmpl.New(ctx).WithEnv(env).Apply(expand(s, env))
+func tmplPath(ctx *context.Context, t *tmpl.Template, env map[string]string, s string) (string, error) {
+	result, err := t.Apply(expand(s, env))
 	if err != nil || result == "" {
 		return "", err
 	}
@@ -154,6 +239,7 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	env["artifactName"] = art.Name // shouldn't be used
 	env["artifact"] = art.Path
 	env["artifactID"] = art.ID()
+	env["digest"] = art.ExtraOr(artifact.ExtraDigest, "").(string)
 
 	tmplEnv, err := templateEnvS(ctx, cfg.Env)
 	if err != nil {
@@ -163,47 +249,62 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	for k, v := range context.ToEnv(tmplEnv) {
 		env[k] = v
 	}
+	t := tmpl.New(ctx).WithArtifact(art, map[string]string{}).WithEnv(env)
 
-	name, err := tmplPath(ctx, env, cfg.Signature)
+	name, err := tmplPath(ctx, t, env, cfg.Signature)
 	if err != nil {
 		return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
 	}
 	env["signature"] = name
 
-	cert, err := tmplPath(ctx, env, cfg.Certificate)
+	cert, err := tmplPath(ctx, t, env, cfg.Certificate)
 	if err != nil {
 		return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
 	}
 	env["certificate"] = cert
 
+	command, err := t.Apply(expand(cfg.Cmd, env))
+	if err != nil {
+		return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
+	}
+
 	// nolint:prealloc
 	var args []string
 	for _, a := range cfg.Args {
-		arg, err := tmpl.New(ctx).WithEnv(env).Apply(expand(a, env))
+		arg, err := t.Apply(expand(a, env))
 		if err != nil {
 			return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
 		}
 		args = append(args, arg)
 	}
+	if cfg.Rekor.Enabled {
+		// cosign only uploads to the transparency log in experimental mode.
+		env["COSIGN_EXPERIMENTAL"] = "1"
+		args = append(args, "--rekor-url="+cfg.Rekor.URL)
+	}
 
 	var stdin io.Reader
 	if cfg.Stdin != nil {
-		s, err := tmpl.New(ctx).WithEnv(env).Apply(expand(*cfg.Stdin, env))
+		s, err := t.Apply(expand(*cfg.Stdin, env))
 		if err != nil {
 			return nil, err
 		}
 		stdin = strings.NewReader(s)
 	} else if cfg.StdinFile != "" {
-		f, err := os.Open(cfg.StdinFile)
+		stdinFile, err := t.Apply(expand(cfg.StdinFile, env))
+		if err != nil {
+			return nil, fmt.Errorf("sign failed: %s: %w", art.Name, err)
+		}
+		f, err := os.Open(stdinFile)
 		if err != nil {
-			return nil, fmt.Errorf("sign failed: cannot open file %s: %w", cfg.StdinFile, err)
+			return nil, fmt.Errorf("sign failed: cannot open file %s: %w", stdinFile, err)
 		}
 		defer f.Close()
 
 		stdin = f
 	}
 
-	fields := log.Fields{"cmd": cfg.Cmd, "artifact": art.Name}
+	fields := log.Fields{"cmd": command, "artifact": art.Name}
 	if name != "" {
 		fields["signature"] = name
 	}
@@ -215,7 +316,7 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	// However, this works as intended. The nosec annotation
 	// tells the scanner to ignore this.
 	// #nosec
-	cmd := exec.CommandContext(ctx, cfg.Cmd, args...)
+	cmd := exec.CommandContext(ctx, command, args...)
 	var b bytes.Buffer
 	w := gio.Safe(&b)
 	cmd.Stderr = io.MultiWriter(logext.NewConditionalWriter(fields, logext.Error, cfg.Output), w)
@@ -226,41 +327,58 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	cmd.Env = env.Strings()
 	log.WithFields(fields).Info("signing")
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("sign: %s failed: %w: %s", cfg.Cmd, err, b.String())
+		return nil, fmt.Errorf("sign: %s failed: %w: %s", command, err, b.String())
+	}
+
+	extra := map[string]interface{}{
+		artifact.ExtraID: cfg.ID,
+	}
+	if cfg.Rekor.Enabled {
+		if match := rekorLogIndexRe.FindStringSubmatch(b.String()); match != nil {
+			log.WithFields(fields).WithField("index", match[1]).Info("uploaded to rekor")
+			extra[artifact.ExtraRekorURL] = cfg.Rekor.URL
+			extra[artifact.ExtraRekorLogIndex] = match[1]
+		} else {
+			log.WithFields(fields).Warn("could not find the rekor log index in the output")
+		}
 	}
 
 	var result []*artifact.Artifact
 
 	// re-execute template results, using artifact desc as artifact so they eval to the actual needed file desc.
 	env["artifact"] = art.Name
-	name, _ = tmpl.New(ctx).WithEnv(env).Apply(expand(cfg.Signature, env))   // could never error as it passed the previous check
-	cert, _ = tmpl.New(ctx).WithEnv(env).Apply(expand(cfg.Certificate, env)) // could never error as it passed the previous check
+	name, _ = t.Apply(expand(cfg.Signature, env))   // could never error as it passed the previous check
+	cert, _ = t.Apply(expand(cfg.Certificate, env)) // could never error as it passed the previous check
 
 	if cfg.Signature != "" {
 		result = append(result, &artifact.Artifact{
-			Type: artifact.Signature,
-			Name: name,
-			Path: env["signature"],
-			Extra: map[string]interface{}{
-				artifact.ExtraID: cfg.ID,
-			},
+			Type:  artifact.Signature,
+			Name:  name,
+			Path:  env["signature"],
+			Extra: extra,
 		})
 	}
 
 	if cert != "" {
 		result = append(result, &artifact.Artifact{
-			Type: artifact.Certificate,
-			Name: cert,
-			Path: env["certificate"],
-			Extra: map[string]interface{}{
-				artifact.ExtraID: cfg.ID,
-			},
+			Type:  artifact.Certificate,
+			Name:  cert,
+			Path:  env["certificate"],
+			Extra: copyExtra(extra),
 		})
 	}
 
 	return result, nil
 }
 
+func copyExtra(extra map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(extra))
+	for k, v := range extra {
+		result[k] = v
+	}
+	return result
+}
+
 func expand(s string, env map[string]string) string {
 	return os.Expand(s, func(key string) string {
 		return env[key]
@@ -0,0 +1,73 @@
+package sign
+
+import (
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/ids"
+	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const defaultBinarySignature = `${artifact}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}{{ with .Arm64 }}_{{ . }}{{ end }}`
+
+// BinaryPipe signs the built binaries before they are archived, so the
+// signatures can be shipped inside the archives.
+type BinaryPipe struct{}
+
+func (BinaryPipe) String() string { return "signing binaries" }
+
+func (BinaryPipe) Skip(ctx *context.Context) bool {
+	return ctx.SkipSign || len(ctx.Config.BinarySigns) == 0
+}
+
+// Default sets the Pipes defaults.
+func (BinaryPipe) Default(ctx *context.Context) error {
+	ids := ids.New("binary_signs")
+	for i := range ctx.Config.BinarySigns {
+		cfg := &ctx.Config.BinarySigns[i]
+		if err := setDefaults(cfg, defaultBinarySignature); err != nil {
+			return err
+		}
+		if cfg.ID == "" {
+			cfg.ID = "default"
+		}
+		ids.Inc(cfg.ID)
+	}
+	return ids.Validate()
+}
+
+// Run signs the binaries.
+func (BinaryPipe) Run(ctx *context.Context) error {
+	g := semerrgroup.New(ctx.Parallelism)
+	for i := range ctx.Config.BinarySigns {
+		cfg := ctx.Config.BinarySigns[i]
+		g.Go(func() error {
+			filters := []artifact.Filter{artifact.Or(
+				artifact.ByType(artifact.Binary),
+				artifact.ByType(artifact.UniversalBinary),
+			)}
+			if len(cfg.IDs) > 0 {
+				filters = append(filters, artifact.ByIDs(cfg.IDs...))
+			}
+			for _, bin := range ctx.Artifacts.Filter(artifact.And(filters...)).List() {
+				sigs, err := signone(ctx, cfg, bin)
+				if err != nil {
+					return err
+				}
+				for _, sig := range sigs {
+					// keep track of the binary and its platform, so the
+					// archive pipe can put the signature next to it.
+					sig.Goos = bin.Goos
+					sig.Goarch = bin.Goarch
+					sig.Goarm = bin.Goarm
+					sig.Gomips = bin.Gomips
+					sig.Goamd64 = bin.Goamd64
+					sig.Goarm64 = bin.Goarm64
+					sig.Extra[artifact.ExtraSigned] = bin.Path
+					ctx.Artifacts.Add(sig)
+				}
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
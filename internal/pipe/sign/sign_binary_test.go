@@ -0,0 +1,107 @@
+package sign
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinarySignDescription(t *testing.T) {
+	require.NotEmpty(t, BinaryPipe{}.String())
+}
+
+func TestBinarySignSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, BinaryPipe{}.Skip(context.New(config.Project{})))
+	})
+
+	t.Run("skip sign", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			BinarySigns: []config.Sign{{}},
+		})
+		ctx.SkipSign = true
+		require.True(t, BinaryPipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		require.False(t, BinaryPipe{}.Skip(context.New(config.Project{
+			BinarySigns: []config.Sign{{}},
+		})))
+	})
+}
+
+func TestBinarySignDefault(t *testing.T) {
+	ctx := context.New(config.Project{
+		BinarySigns: []config.Sign{{}},
+	})
+	require.NoError(t, BinaryPipe{}.Default(ctx))
+	require.Equal(t, "default", ctx.Config.BinarySigns[0].ID)
+	require.Equal(t, "gpg", ctx.Config.BinarySigns[0].Cmd)
+	require.Equal(t, defaultBinarySignature+".sig", ctx.Config.BinarySigns[0].Signature)
+	require.Equal(t, []string{"--output", "$signature", "--detach-sig", "$artifact"}, ctx.Config.BinarySigns[0].Args)
+}
+
+func TestBinarySignDefaultDuplicatedIDs(t *testing.T) {
+	ctx := context.New(config.Project{
+		BinarySigns: []config.Sign{{}, {}},
+	})
+	require.EqualError(t, BinaryPipe{}.Default(ctx), "found 2 binary_signs with the ID 'default', please fix your config")
+}
+
+func TestBinarySign(t *testing.T) {
+	dist := t.TempDir()
+	ctx := context.New(config.Project{
+		Dist: dist,
+		BinarySigns: []config.Sign{{
+			Cmd:  "cp",
+			Args: []string{"$artifact", "$signature"},
+			IDs:  []string{"foo"},
+		}},
+	})
+	for _, bin := range []struct {
+		id, goos, goarch, goarm, goamd64 string
+	}{
+		{"foo", "linux", "amd64", "", "v1"},
+		{"foo", "linux", "amd64", "", "v3"},
+		{"foo", "linux", "arm", "7", ""},
+		{"bar", "linux", "amd64", "", "v1"},
+	} {
+		path := filepath.Join(dist, bin.id+"_"+bin.goos+"_"+bin.goarch+bin.goarm+bin.goamd64, bin.id)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(bin.id), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    bin.id,
+			Path:    path,
+			Goos:    bin.goos,
+			Goarch:  bin.goarch,
+			Goarm:   bin.goarm,
+			Goamd64: bin.goamd64,
+			Type:    artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: bin.id,
+			},
+		})
+	}
+	require.NoError(t, BinaryPipe{}.Default(ctx))
+	require.NoError(t, BinaryPipe{}.Run(ctx))
+
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, sigs, 3)
+	for _, sig := range sigs {
+		signed := sig.ExtraOr(artifact.ExtraSigned, "").(string)
+		require.Equal(t, "foo", filepath.Base(signed))
+		require.Equal(t, "linux", sig.Goos)
+		require.FileExists(t, sig.Path)
+		require.Equal(t, filepath.Dir(signed), filepath.Dir(sig.Path))
+	}
+	var names []string
+	for _, sig := range sigs {
+		names = append(names, sig.Name)
+	}
+	require.ElementsMatch(t, []string{"foo_linux_amd64.sig", "foo_linux_amd64v3.sig", "foo_linux_armv7.sig"}, names)
+}
@@ -27,6 +27,9 @@ func (DockerPipe) Default(ctx *context.Context) error {
 		if cfg.Cmd == "" {
 			cfg.Cmd = "cosign"
 		}
+		if err := setRekorDefaults(cfg); err != nil {
+			return err
+		}
 		if len(cfg.Args) == 0 {
 			cfg.Args = []string{"sign", "--key=cosign.key", "$artifact"}
 		}
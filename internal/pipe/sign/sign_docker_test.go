@@ -50,6 +50,32 @@ func TestDockerSignInvalidArtifacts(t *testing.T) {
 	require.EqualError(t, err, "invalid list of artifacts to sign: foo")
 }
 
+func TestDockerSignDigest(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "signed")
+	const digest = "sha256:ea9ab2f3a7e2b8e3b0a9b7f4e3d6c2a1b0e9f8d7c6b5a4f3e2d1c0b9a8f7e6d5"
+	ctx := context.New(config.Project{
+		DockerSigns: []config.Sign{
+			{
+				Artifacts: "all",
+				Cmd:       "sh",
+				Args:      []string{"-c", "echo ${artifact}@${digest} >> " + out},
+			},
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo/bar:v1",
+		Path: "foo/bar:v1",
+		Type: artifact.DockerImage,
+		Extra: map[string]interface{}{
+			artifact.ExtraDigest: digest,
+		},
+	})
+	require.NoError(t, DockerPipe{}.Publish(ctx))
+	bts, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "foo/bar:v1@"+digest+"\n", string(bts))
+}
+
 func TestDockerSignArtifacts(t *testing.T) {
 	testlib.CheckPath(t, "cosign")
 	key := "cosign.key"
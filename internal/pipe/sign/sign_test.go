@@ -63,6 +63,123 @@ func TestSignDefault(t *testing.T) {
 	require.Equal(t, ctx.Config.Signs[0].Artifacts, "none")
 }
 
+func TestSignDefaultInvalidArtifacts(t *testing.T) {
+	ctx := context.New(config.Project{
+		Signs: []config.Sign{
+			{Artifacts: "archives"},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "invalid list of artifacts to sign: archives")
+}
+
+func TestSignDefaultGPG(t *testing.T) {
+	for name, tt := range map[string]struct {
+		gpg       config.SignGPG
+		signature string
+		args      []string
+	}{
+		"key and armor": {
+			gpg:       config.SignGPG{KeyID: "{{ .Env.KEY }}", Armor: true},
+			signature: "${artifact}.asc",
+			args:      []string{"--local-user", "{{ .Env.KEY }}", "--armor", "--output", "$signature", "--detach-sig", "$artifact"},
+		},
+		"detach": {
+			gpg:       config.SignGPG{Mode: "detach"},
+			signature: "${artifact}.sig",
+			args:      []string{"--output", "$signature", "--detach-sig", "$artifact"},
+		},
+		"clear": {
+			gpg:       config.SignGPG{Mode: "clear"},
+			signature: "${artifact}.asc",
+			args:      []string{"--output", "$signature", "--clearsign", "$artifact"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.New(config.Project{
+				Signs: []config.Sign{{GPG: tt.gpg}},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.Equal(t, tt.signature, ctx.Config.Signs[0].Signature)
+			require.Equal(t, tt.args, ctx.Config.Signs[0].Args)
+		})
+	}
+}
+
+func TestSignDefaultGPGInvalid(t *testing.T) {
+	t.Run("mode", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Signs: []config.Sign{{GPG: config.SignGPG{Mode: "inline"}}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "invalid gpg mode: inline")
+	})
+
+	t.Run("custom cmd", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Signs: []config.Sign{{Cmd: "cosign", GPG: config.SignGPG{Armor: true}}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "gpg options can only be used with the default cmd and args")
+	})
+
+	t.Run("custom args", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Signs: []config.Sign{{Args: []string{"--sign"}, GPG: config.SignGPG{Armor: true}}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "gpg options can only be used with the default cmd and args")
+	})
+}
+
+func TestSignGPGArmor(t *testing.T) {
+	dist := t.TempDir()
+	path := filepath.Join(dist, "checksums.txt")
+	require.NoError(t, os.WriteFile(path, []byte("foo"), 0o644))
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Signs: []config.Sign{{
+			Artifacts: "checksum",
+			Env:       []string{"GNUPGHOME=" + keyring},
+			GPG:       config.SignGPG{KeyID: user, Armor: true},
+		}},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Path: path,
+		Type: artifact.Checksum,
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bts, err := os.ReadFile(path + ".asc")
+	require.NoError(t, err)
+	require.Contains(t, string(bts), "-----BEGIN PGP SIGNATURE-----")
+}
+
+func TestSignAllIncludesDebugSymbols(t *testing.T) {
+	dist := t.TempDir()
+	path := filepath.Join(dist, "foo.debug")
+	require.NoError(t, os.WriteFile(path, []byte("foo"), 0o644))
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Signs: []config.Sign{
+			{
+				Artifacts: "all",
+				Cmd:       "cp",
+				Args:      []string{"${artifact}", "${signature}"},
+			},
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo.debug",
+		Path: path,
+		Type: artifact.DebugSymbols,
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, sigs, 1)
+	require.Equal(t, "foo.debug.sig", sigs[0].Name)
+	require.FileExists(t, sigs[0].Path)
+}
+
 func TestSignDisabled(t *testing.T) {
 	ctx := context.New(config.Project{})
 	ctx.Config.Signs = []config.Sign{
@@ -81,6 +198,22 @@ func TestSignInvalidArtifacts(t *testing.T) {
 	require.EqualError(t, err, "invalid list of artifacts to sign: foo")
 }
 
+func TestSignChecksumDisabled(t *testing.T) {
+	ctx := context.New(config.Project{
+		Checksum: config.Checksum{Disable: true},
+		Signs: []config.Sign{
+			{Artifacts: "checksum", Cmd: "exit", Args: []string{"1"}},
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo",
+		Path: "foo",
+		Type: artifact.UploadableArchive,
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List())
+}
+
 func TestSignArtifacts(t *testing.T) {
 	stdin := passwordUser
 	tmplStdin := passwordUserTmpl
@@ -122,6 +255,37 @@ func TestSignArtifacts(t *testing.T) {
 				},
 			),
 		},
+		{
+			desc:           "templated sign cmd",
+			expectedErrMsg: `sign: not-a-valid-cmd failed: exec: "not-a-valid-cmd": executable file not found in $PATH: `,
+			ctx: context.New(
+				config.Project{
+					Signs: []config.Sign{
+						{
+							Artifacts: "all",
+							Cmd:       "{{ .Env.SIGN_CMD }}",
+						},
+					},
+					Env: []string{
+						"SIGN_CMD=not-a-valid-cmd",
+					},
+				},
+			),
+		},
+		{
+			desc:           "invalid sign cmd template",
+			expectedErrMsg: `sign failed: artifact1: template: tmpl:1: unexpected "}" in operand`,
+			ctx: context.New(
+				config.Project{
+					Signs: []config.Sign{
+						{
+							Artifacts: "all",
+							Cmd:       "{{ .Nope }",
+						},
+					},
+				},
+			),
+		},
 		{
 			desc:           "invalid certificate template",
 			expectedErrMsg: `sign failed: artifact1: template: tmpl:1:3: executing "tmpl" at <.blah>: map has no entry for key "blah"`,
@@ -499,6 +663,50 @@ func TestSignArtifacts(t *testing.T) {
 			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
 			user:           passwordUser,
 		},
+		{
+			desc: "sign single with password from templated stdin_file",
+			ctx: context.New(
+				config.Project{
+					Env: []string{"GPG_PASSWORD_FILE=" + filepath.Join(keyring, passwordUser)},
+					Signs: []config.Sign{
+						{
+							Artifacts: "all",
+							Args: []string{
+								"-u",
+								passwordUser,
+								"--batch",
+								"--pinentry-mode",
+								"loopback",
+								"--passphrase-fd",
+								"0",
+								"--output",
+								"${signature}",
+								"--detach-sign",
+								"${artifact}",
+							},
+							StdinFile: "{{ .Env.GPG_PASSWORD_FILE }}",
+						},
+					},
+				},
+			),
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			user:           passwordUser,
+		},
+		{
+			desc:           "invalid stdin_file template",
+			expectedErrMsg: `sign failed: artifact1: template: tmpl:1: unexpected "}" in operand`,
+			ctx: context.New(
+				config.Project{
+					Signs: []config.Sign{
+						{
+							Artifacts: "all",
+							StdinFile: "{{ .Nope }",
+						},
+					},
+				},
+			),
+		},
 		{
 			desc: "missing stdin_file",
 			ctx: context.New(
@@ -783,3 +991,65 @@ func TestSkip(t *testing.T) {
 		require.False(t, Pipe{}.Skip(ctx))
 	})
 }
+
+func TestSignDefaultRekor(t *testing.T) {
+	t.Run("default url", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Signs: []config.Sign{{Cmd: "cosign", Rekor: config.Rekor{Enabled: true}}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, defaultRekorURL, ctx.Config.Signs[0].Rekor.URL)
+	})
+
+	t.Run("not cosign", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Signs: []config.Sign{{Rekor: config.Rekor{Enabled: true}}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "rekor can only be used with cosign")
+	})
+
+	t.Run("docker", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			DockerSigns: []config.Sign{{Rekor: config.Rekor{Enabled: true, URL: "https://rekor.example.com"}}},
+		})
+		require.NoError(t, DockerPipe{}.Default(ctx))
+		require.Equal(t, "https://rekor.example.com", ctx.Config.DockerSigns[0].Rekor.URL)
+	})
+}
+
+func TestSignRekor(t *testing.T) {
+	dist := t.TempDir()
+	cosign := filepath.Join(t.TempDir(), "cosign")
+	require.NoError(t, os.WriteFile(cosign, []byte(`#!/bin/sh
+echo "tlog entry created with index: 42" >&2
+echo "$COSIGN_EXPERIMENTAL $2" > "$1"
+`), 0o755))
+	path := filepath.Join(dist, "checksums.txt")
+	require.NoError(t, os.WriteFile(path, []byte("foo"), 0o644))
+
+	ctx := context.New(config.Project{
+		Dist: dist,
+		Signs: []config.Sign{{
+			Cmd:       cosign,
+			Args:      []string{"${signature}"},
+			Artifacts: "checksum",
+			Rekor:     config.Rekor{Enabled: true},
+		}},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "checksums.txt",
+		Path: path,
+		Type: artifact.Checksum,
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bts, err := os.ReadFile(path + ".sig")
+	require.NoError(t, err)
+	require.Equal(t, "1 --rekor-url=https://rekor.sigstore.dev\n", string(bts))
+
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, sigs, 1)
+	require.Equal(t, "42", sigs[0].ExtraOr(artifact.ExtraRekorLogIndex, ""))
+	require.Equal(t, defaultRekorURL, sigs[0].ExtraOr(artifact.ExtraRekorURL, ""))
+}
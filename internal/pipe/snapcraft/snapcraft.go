@@ -93,7 +93,7 @@ type LayoutMetadata struct {
 	Type     string `yaml:",omitempty"`
 }
 
-const defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}`
+const defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}{{ with .Arm64 }}_{{ . }}{{ end }}`
 
 // Pipe for snapcraft packaging.
 type Pipe struct{}
@@ -403,6 +403,7 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 		Goarch:  binaries[0].Goarch,
 		Goarm:   binaries[0].Goarm,
 		Goamd64: binaries[0].Goamd64,
+		Goarm64: binaries[0].Goarm64,
 		Extra: map[string]interface{}{
 			releasesExtra: channels,
 		},
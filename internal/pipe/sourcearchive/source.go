@@ -2,12 +2,17 @@
 package sourcearchive
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/archivefiles"
 	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/gio"
 	"github.com/goreleaser/goreleaser/internal/git"
 	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/archive"
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
 
@@ -35,8 +40,9 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 		"archive",
 		"-o", path,
 	}
+	prefix := ""
 	if ctx.Config.Source.PrefixTemplate != "" {
-		prefix, err := tmpl.New(ctx).Apply(ctx.Config.Source.PrefixTemplate)
+		prefix, err = tmpl.New(ctx).Apply(ctx.Config.Source.PrefixTemplate)
 		if err != nil {
 			return err
 		}
@@ -45,6 +51,16 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 	args = append(args, ctx.Git.FullCommit)
 	out, err := git.Clean(git.Run(ctx, args...))
 	log.Debug(out)
+	if err != nil {
+		return err
+	}
+
+	if len(ctx.Config.Source.Files) > 0 {
+		if err := appendExtraFiles(ctx, prefix, path, ctx.Config.Source.Format); err != nil {
+			return err
+		}
+	}
+
 	ctx.Artifacts.Add(&artifact.Artifact{
 		Type: artifact.UploadableSourceArchive,
 		Name: filename,
@@ -53,7 +69,52 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 			artifact.ExtraFormat: ctx.Config.Source.Format,
 		},
 	})
-	return err
+	return nil
+}
+
+// appendExtraFiles rewrites the archive created by git-archive at the given
+// path, adding the extra files from the config to it.
+func appendExtraFiles(ctx *context.Context, prefix, path, format string) error {
+	oldPath := path + ".bkp"
+	if err := gio.Copy(path, oldPath); err != nil {
+		return fmt.Errorf("failed to make a backup of %s: %w", path, err)
+	}
+	defer os.Remove(oldPath)
+
+	// archives can't be appended to, so we create a new one with the
+	// contents of the original and the extra files.
+	oldFile, err := os.Open(oldPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", oldPath, err)
+	}
+	defer oldFile.Close()
+
+	af, err := os.OpenFile(path, os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer af.Close()
+
+	arch, err := archive.Copying(oldFile, af, format)
+	if err != nil {
+		return err
+	}
+
+	files, err := archivefiles.Eval(tmpl.New(ctx), false, ctx.Config.Source.Files)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		f.Destination = filepath.ToSlash(filepath.Join(prefix, f.Destination))
+		if err := arch.Add(f); err != nil {
+			return fmt.Errorf("failed to add %s to the source archive: %w", f.Source, err)
+		}
+	}
+
+	if err := arch.Close(); err != nil {
+		return fmt.Errorf("could not close archive file: %w", err)
+	}
+	return af.Close()
 }
 
 // Default sets the pipe defaults.
@@ -66,5 +127,13 @@ func (Pipe) Default(ctx *context.Context) error {
 	if archive.NameTemplate == "" {
 		archive.NameTemplate = "{{ .ProjectName }}-{{ .Version }}"
 	}
+
+	if len(archive.Files) > 0 {
+		switch archive.Format {
+		case "tar", "tgz", "tar.gz", "zip":
+		default:
+			return fmt.Errorf("source archive format %s does not support extra files, use tar, tgz, tar.gz or zip", archive.Format)
+		}
+	}
 	return nil
 }
@@ -1,6 +1,10 @@
 package sourcearchive
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -56,6 +60,101 @@ func TestArchive(t *testing.T) {
 	}
 }
 
+func TestArchiveWithFiles(t *testing.T) {
+	for _, format := range []string{"tar.gz", "tar", "zip"} {
+		t.Run(format, func(t *testing.T) {
+			tmp := testlib.Mktmp(t)
+			require.NoError(t, os.Mkdir("dist", 0o744))
+
+			testlib.GitInit(t)
+			require.NoError(t, os.WriteFile("code.txt", []byte("not really code"), 0o655))
+			testlib.GitAdd(t)
+			testlib.GitCommit(t, "feat: first")
+			require.NoError(t, os.MkdirAll("gen", 0o755))
+			require.NoError(t, os.WriteFile("gen/generated.go", []byte("package gen"), 0o655))
+
+			ctx := context.New(config.Project{
+				ProjectName: "foo",
+				Dist:        "dist",
+				Source: config.Source{
+					Format:         format,
+					Enabled:        true,
+					PrefixTemplate: "{{ .ProjectName }}-{{ .Version }}/",
+					Files: []config.File{
+						{Source: "gen/*.go"},
+					},
+				},
+			})
+			ctx.Git.FullCommit = "HEAD"
+			ctx.Version = "1.0.0"
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+			require.Len(t, ctx.Artifacts.List(), 1)
+
+			path := filepath.Join(tmp, "dist", "foo-1.0.0."+format)
+			require.NoFileExists(t, path+".bkp")
+			require.ElementsMatch(t, []string{
+				"foo-1.0.0/",
+				"foo-1.0.0/code.txt",
+				"foo-1.0.0/gen/generated.go",
+			}, lsArchive(t, path, format))
+		})
+	}
+}
+
+func TestDefaultFilesInvalidFormat(t *testing.T) {
+	ctx := context.New(config.Project{
+		Source: config.Source{
+			Format: "tgz.zip",
+			Files: []config.File{
+				{Source: "code.txt"},
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), "source archive format tgz.zip does not support extra files, use tar, tgz, tar.gz or zip")
+}
+
+func lsArchive(tb testing.TB, path, format string) []string {
+	tb.Helper()
+	f, err := os.Open(path)
+	require.NoError(tb, err)
+	defer f.Close()
+
+	var paths []string
+	if format == "zip" {
+		info, err := f.Stat()
+		require.NoError(tb, err)
+		r, err := zip.NewReader(f, info.Size())
+		require.NoError(tb, err)
+		for _, zf := range r.File {
+			paths = append(paths, zf.Name)
+		}
+		return paths
+	}
+
+	var r io.Reader = f
+	if format == "tar.gz" {
+		gr, err := gzip.NewReader(f)
+		require.NoError(tb, err)
+		defer gr.Close()
+		r = gr
+	}
+	tr := tar.NewReader(r)
+	for {
+		next, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(tb, err)
+		if next.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		paths = append(paths, next.Name)
+	}
+	return paths
+}
+
 func TestDefault(t *testing.T) {
 	ctx := context.New(config.Project{})
 	require.NoError(t, Pipe{}.Default(ctx))
@@ -0,0 +1,60 @@
+// Package tests provides a pipe that runs the project tests before building,
+// failing the release early if they fail.
+package tests
+
+import (
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/caarlos0/go-shellwords"
+	"github.com/goreleaser/goreleaser/internal/shell"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Pipe for tests.
+type Pipe struct{}
+
+func (Pipe) String() string { return "running tests" }
+
+func (Pipe) Skip(ctx *context.Context) bool {
+	return !ctx.Config.Tests.Enabled || ctx.SkipTests
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	if ctx.Config.Tests.Cmd == "" {
+		ctx.Config.Tests.Cmd = "go test ./..."
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	env := ctx.Env.Strings()
+	for _, rawEnv := range ctx.Config.Tests.Env {
+		e, err := tmpl.New(ctx).Apply(rawEnv)
+		if err != nil {
+			return err
+		}
+		env = append(env, e)
+	}
+
+	s, err := tmpl.New(ctx).WithEnvS(env).Apply(ctx.Config.Tests.Cmd)
+	if err != nil {
+		return err
+	}
+	cmd, err := shellwords.Parse(s)
+	if err != nil {
+		return err
+	}
+	if len(cmd) == 0 {
+		return fmt.Errorf("tests.cmd evaluated to an empty command")
+	}
+
+	log.WithField("cmd", s).Info("running")
+	if err := shell.Run(ctx, ctx.Config.Tests.Dir, cmd, env, ctx.Config.Tests.Output); err != nil {
+		return fmt.Errorf("tests failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/testlib"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	})
+
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Tests: config.Tests{Enabled: true},
+		})
+		ctx.SkipTests = true
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		require.False(t, Pipe{}.Skip(context.New(config.Project{
+			Tests: config.Tests{Enabled: true},
+		})))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "go test ./...", ctx.Config.Tests.Cmd)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		folder := testlib.Mktmp(t)
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Tests: config.Tests{
+				Enabled: true,
+				Cmd:     "touch {{ .Env.FILE }}",
+				Dir:     folder,
+				Env:     []string{"FILE={{ .ProjectName }}.txt"},
+			},
+		})
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.FileExists(t, filepath.Join(folder, "foo.txt"))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Tests: config.Tests{
+				Enabled: true,
+				Cmd:     `sh -c "echo FAIL; exit 1"`,
+			},
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), "tests failed: failed to run 'sh -c echo FAIL; exit 1': exit status 1: FAIL")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Tests: config.Tests{
+				Enabled: true,
+				Cmd:     "{{ if .IsSnapshot }}go test ./...{{ end }}",
+			},
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), "tests.cmd evaluated to an empty command")
+	})
+
+	t.Run("invalid cmd template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Tests: config.Tests{
+				Enabled: true,
+				Cmd:     "{{ .Nope }",
+			},
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), `template: tmpl:1: unexpected "}" in operand`)
+	})
+
+	t.Run("invalid env template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Tests: config.Tests{
+				Enabled: true,
+				Cmd:     "go test ./...",
+				Env:     []string{"FOO={{ .Nope }"},
+			},
+		})
+		require.EqualError(t, Pipe{}.Run(ctx), `template: tmpl:1: unexpected "}" in operand`)
+	})
+}
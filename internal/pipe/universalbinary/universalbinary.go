@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/caarlos0/go-shellwords"
@@ -112,6 +114,10 @@ func runHook(ctx *context.Context, opts *build.Options, hooks config.Hooks) erro
 		}
 
 		if err := shell.Run(ctx, dir, cmd, envs, hook.Output); err != nil {
+			if hook.AllowFailure {
+				log.WithError(err).Warn("hook failed, ignoring")
+				continue
+			}
 			return err
 		}
 	}
@@ -221,6 +227,21 @@ func makeUniversalBinary(ctx *context.Context, opts *build.Options, unibin confi
 		return fmt.Errorf("failed to close file: %w", err)
 	}
 
+	if unibin.ModTimestamp != "" {
+		modTimestamp, err := tmpl.New(ctx).Apply(unibin.ModTimestamp)
+		if err != nil {
+			return err
+		}
+		modUnix, err := strconv.ParseInt(modTimestamp, 10, 64)
+		if err != nil {
+			return err
+		}
+		modTime := time.Unix(modUnix, 0)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			return fmt.Errorf("failed to change times for %s: %w", path, err)
+		}
+	}
+
 	extra := map[string]interface{}{}
 	for k, v := range binaries[0].Extra {
 		extra[k] = v
@@ -203,6 +203,19 @@ func TestRun(t *testing.T) {
 		},
 	})
 
+	ctx7 := context.New(config.Project{
+		Dist: dist,
+		UniversalBinaries: []config.UniversalBinary{
+			{
+				ID:           "foo",
+				IDs:          []string{"foo"},
+				NameTemplate: "foo",
+				ModTimestamp: "{{ .Env.MOD_TIME }}",
+			},
+		},
+	})
+	ctx7.Env["MOD_TIME"] = "1234567890"
+
 	for arch, path := range paths {
 		cmd := exec.Command("go", "build", "-o", path, src)
 		cmd.Env = append(os.Environ(), "GOOS=darwin", "GOARCH="+arch)
@@ -228,6 +241,7 @@ func TestRun(t *testing.T) {
 		ctx2.Artifacts.Add(&art)
 		ctx5.Artifacts.Add(&art)
 		ctx6.Artifacts.Add(&art)
+		ctx7.Artifacts.Add(&art)
 		ctx4.Artifacts.Add(&artifact.Artifact{
 			Name:   "fake",
 			Path:   path + "wrong",
@@ -267,6 +281,21 @@ func TestRun(t *testing.T) {
 		require.False(t, unis[0].Extra[artifact.ExtraReplaces].(bool))
 	})
 
+	t.Run("mod timestamp", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(ctx7))
+		unis := ctx7.Artifacts.Filter(artifact.ByType(artifact.UniversalBinary)).List()
+		require.Len(t, unis, 1)
+		stat, err := os.Stat(unis[0].Path)
+		require.NoError(t, err)
+		require.Equal(t, int64(1234567890), stat.ModTime().Unix())
+	})
+
+	t.Run("bad mod timestamp", func(t *testing.T) {
+		ctx := ctx7
+		ctx.Env["MOD_TIME"] = "not a number"
+		require.EqualError(t, Pipe{}.Run(ctx), `strconv.ParseInt: parsing "not a number": invalid syntax`)
+	})
+
 	t.Run("bad template", func(t *testing.T) {
 		require.EqualError(t, Pipe{}.Run(context.New(config.Project{
 			UniversalBinaries: []config.UniversalBinary{
@@ -310,6 +339,13 @@ func TestRun(t *testing.T) {
 		require.EqualError(t, Pipe{}.Run(ctx), "post hook failed: failed to run 'exit 1': exec: \"exit\": executable file not found in $PATH")
 	})
 
+	t.Run("failing hook allowed to fail", func(t *testing.T) {
+		ctx := ctx5
+		ctx.Config.UniversalBinaries[0].Hooks.Pre = []config.Hook{{Cmd: "exit 1", AllowFailure: true}}
+		ctx.Config.UniversalBinaries[0].Hooks.Post = []config.Hook{{Cmd: "exit 1", AllowFailure: true}}
+		require.NoError(t, Pipe{}.Run(ctx))
+	})
+
 	t.Run("hook with env tmpl", func(t *testing.T) {
 		ctx := ctx5
 		ctx.Config.UniversalBinaries[0].Hooks.Pre = []config.Hook{{
@@ -0,0 +1,138 @@
+// Package upx compresses the built binaries with upx.
+package upx
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Pipe for upx compression.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "upx" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.UPXs) == 0 }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	for i := range ctx.Config.UPXs {
+		upx := &ctx.Config.UPXs[i]
+		if upx.Binary == "" {
+			upx.Binary = "upx"
+		}
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	for _, upx := range ctx.Config.UPXs {
+		upx := upx
+		if !upx.Enabled {
+			continue
+		}
+		args, err := argsFor(upx)
+		if err != nil {
+			return err
+		}
+		if _, err := exec.LookPath(upx.Binary); err != nil {
+			log.WithError(err).Warnf("%s not found in PATH, skipping compression", upx.Binary)
+			continue
+		}
+		for _, bin := range findBinaries(ctx, upx) {
+			bin := bin
+			g.Go(func() error {
+				return compress(ctx, upx.Binary, args, bin)
+			})
+		}
+	}
+	return g.Wait()
+}
+
+func argsFor(upx config.UPX) ([]string, error) {
+	var args []string
+	switch upx.Compress {
+	case "":
+	case "best":
+		args = append(args, "--best")
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		args = append(args, "-"+upx.Compress)
+	default:
+		return nil, fmt.Errorf("invalid upx compress level: %s: should be between 1 and 9, or best", upx.Compress)
+	}
+	if upx.LZMA {
+		args = append(args, "--lzma")
+	}
+	if upx.Brute {
+		args = append(args, "--brute")
+	}
+	return args, nil
+}
+
+func compress(ctx *context.Context, binary string, args []string, bin *artifact.Artifact) error {
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, binary, append(args, bin.Path)...)
+	log := log.WithField("binary", bin.Path)
+	log.Info("compressing")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		for _, reason := range []string{
+			"AlreadyPackedException",
+			"NotCompressibleException",
+			"UnknownExecutableFormatException",
+			"CantPackException",
+		} {
+			if strings.Contains(string(out), reason) {
+				log.WithField("reason", reason).Warn("could not compress")
+				return nil
+			}
+		}
+		return fmt.Errorf("failed to compress %s: %w: %s", bin.Path, err, string(out))
+	}
+	return nil
+}
+
+func findBinaries(ctx *context.Context, upx config.UPX) []*artifact.Artifact {
+	filters := []artifact.Filter{
+		artifact.Or(
+			artifact.ByType(artifact.Binary),
+			artifact.ByType(artifact.UniversalBinary),
+		),
+	}
+	if f := orBy(artifact.ByGoos, upx.Goos); f != nil {
+		filters = append(filters, f)
+	}
+	if f := orBy(artifact.ByGoarch, upx.Goarch); f != nil {
+		filters = append(filters, f)
+	}
+	if f := orBy(artifact.ByGoarm, upx.Goarm); f != nil {
+		filters = append(filters, f)
+	}
+	if f := orBy(artifact.ByGoamd64, upx.Goamd64); f != nil {
+		filters = append(filters, f)
+	}
+	if f := orBy(artifact.ByGoarm64, upx.Goarm64); f != nil {
+		filters = append(filters, f)
+	}
+	if len(upx.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(upx.IDs...))
+	}
+	return ctx.Artifacts.Filter(artifact.And(filters...)).List()
+}
+
+func orBy(fn func(string) artifact.Filter, values []string) artifact.Filter {
+	if len(values) == 0 {
+		return nil
+	}
+	filters := make([]artifact.Filter, 0, len(values))
+	for _, v := range values {
+		filters = append(filters, fn(v))
+	}
+	return artifact.Or(filters...)
+}
@@ -0,0 +1,201 @@
+package upx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	require.True(t, Pipe{}.Skip(context.New(config.Project{})))
+	require.False(t, Pipe{}.Skip(context.New(config.Project{
+		UPXs: []config.UPX{{}},
+	})))
+}
+
+func TestDefault(t *testing.T) {
+	ctx := context.New(config.Project{
+		UPXs: []config.UPX{{}, {Binary: "/bin/upx"}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "upx", ctx.Config.UPXs[0].Binary)
+	require.Equal(t, "/bin/upx", ctx.Config.UPXs[1].Binary)
+}
+
+func TestArgsFor(t *testing.T) {
+	args, err := argsFor(config.UPX{})
+	require.NoError(t, err)
+	require.Empty(t, args)
+
+	args, err = argsFor(config.UPX{Compress: "9", LZMA: true, Brute: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"-9", "--lzma", "--brute"}, args)
+
+	args, err = argsFor(config.UPX{Compress: "best"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"--best"}, args)
+
+	_, err = argsFor(config.UPX{Compress: "10"})
+	require.EqualError(t, err, "invalid upx compress level: 10: should be between 1 and 9, or best")
+}
+
+func TestRun(t *testing.T) {
+	for name, tt := range map[string]struct {
+		upx        config.UPX
+		compressed map[string]string // binary file -> expected upx flags
+		err        string
+	}{
+		"filtered": {
+			upx: config.UPX{
+				Enabled:  true,
+				IDs:      []string{"foo"},
+				Goos:     []string{"linux"},
+				Compress: "best",
+			},
+			compressed: map[string]string{"foo_linux_amd64": "--best "},
+		},
+		"already packed": {
+			upx: config.UPX{Enabled: true, IDs: []string{"foo", "bar", "packed"}},
+			compressed: map[string]string{
+				"foo_linux_amd64":  "",
+				"foo_darwin_arm64": "",
+				"bar_linux_arm64":  "",
+			},
+		},
+		"disabled": {
+			upx: config.UPX{},
+		},
+		"binary not found": {
+			upx: config.UPX{Enabled: true, Binary: "nope-upx"},
+		},
+		"failure": {
+			upx: config.UPX{Enabled: true, IDs: []string{"broken"}},
+			err: "failed to compress ",
+		},
+		"invalid compress": {
+			upx: config.UPX{Enabled: true, Compress: "nope"},
+			err: "invalid upx compress level: nope: should be between 1 and 9, or best",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			if tt.upx.Binary == "" {
+				tt.upx.Binary = fakeUPX(t)
+			}
+			ctx := context.New(config.Project{UPXs: []config.UPX{tt.upx}})
+			contents := addBinaries(t, ctx, folder)
+
+			err := Pipe{}.Run(ctx)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			for name, content := range contents {
+				path := filepath.Join(folder, name)
+				if flags, ok := tt.compressed[name]; ok {
+					content = flags + path + "\n"
+				}
+				bts, err := os.ReadFile(path)
+				require.NoError(t, err)
+				require.Equal(t, content, string(bts), name)
+			}
+		})
+	}
+}
+
+// fakeUPX creates a fake upx binary, which replaces the binary with the
+// arguments it got.
+// Binaries containing "packed" are reported as already packed, the ones
+// containing "broken" make it fail.
+func fakeUPX(tb testing.TB) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "fakeupx")
+	require.NoError(tb, os.WriteFile(path, []byte(`#!/bin/sh
+for bin; do :; done
+if grep -q packed "$bin"; then
+  echo "upx: $bin: AlreadyPackedException: already packed by UPX"
+  exit 2
+fi
+if grep -q broken "$bin"; then
+  echo "upx: $bin: IOException: boom"
+  exit 1
+fi
+echo "$@" > "$bin"
+`), 0o755))
+	return path
+}
+
+// addBinaries writes a few binaries to the given folder and adds them to the
+// context, returning their contents by file name.
+func addBinaries(tb testing.TB, ctx *context.Context, folder string) map[string]string {
+	tb.Helper()
+	contents := map[string]string{}
+	for _, bin := range []struct {
+		id, goos, goarch, content string
+	}{
+		{"foo", "linux", "amd64", "bin"},
+		{"foo", "darwin", "arm64", "bin"},
+		{"bar", "linux", "arm64", "bin"},
+		{"packed", "windows", "amd64", "packed"},
+		{"broken", "linux", "386", "broken"},
+	} {
+		name := bin.id + "_" + bin.goos + "_" + bin.goarch
+		path := filepath.Join(folder, name)
+		require.NoError(tb, os.WriteFile(path, []byte(bin.content), 0o755))
+		contents[name] = bin.content
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type:   artifact.Binary,
+			Name:   bin.id,
+			Path:   path,
+			Goos:   bin.goos,
+			Goarch: bin.goarch,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: bin.id,
+			},
+		})
+	}
+	return contents
+}
+
+func TestFindBinaries(t *testing.T) {
+	ctx := context.New(config.Project{})
+	for _, a := range []*artifact.Artifact{
+		{Name: "amd64v1", Goos: "linux", Goarch: "amd64", Goamd64: "v1"},
+		{Name: "amd64v3", Goos: "linux", Goarch: "amd64", Goamd64: "v3"},
+		{Name: "arm7", Goos: "linux", Goarch: "arm", Goarm: "7"},
+		{Name: "arm64", Goos: "linux", Goarch: "arm64"},
+		{Name: "arm64v9", Goos: "linux", Goarch: "arm64", Goarm64: "v9.0"},
+	} {
+		a.Type = artifact.Binary
+		ctx.Artifacts.Add(a)
+	}
+
+	for name, tt := range map[string]struct {
+		upx      config.UPX
+		expected []string
+	}{
+		"all":     {config.UPX{}, []string{"amd64v1", "amd64v3", "arm7", "arm64", "arm64v9"}},
+		"goarch":  {config.UPX{Goarch: []string{"arm64"}}, []string{"arm64", "arm64v9"}},
+		"goamd64": {config.UPX{Goamd64: []string{"v3"}}, []string{"amd64v3"}},
+		"goarm":   {config.UPX{Goarm: []string{"7"}}, []string{"arm7"}},
+		"goarm64": {config.UPX{Goarm64: []string{"v9.0"}}, []string{"arm64v9"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var names []string
+			for _, a := range findBinaries(ctx, tt.upx) {
+				names = append(names, a.Name)
+			}
+			require.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
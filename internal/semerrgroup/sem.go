@@ -16,8 +16,9 @@ type Group interface {
 }
 
 // New returns a new Group of a given size.
+// Sizes lower than 1 result in a serial Group.
 func New(size int) Group {
-	if size == 1 {
+	if size <= 1 {
 		return &serialGroup{}
 	}
 	return &parallelGroup{
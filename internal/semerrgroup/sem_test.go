@@ -42,6 +42,19 @@ func TestSemaphoreOrder(t *testing.T) {
 	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, output)
 }
 
+func TestSemaphoreZeroSize(t *testing.T) {
+	g := New(0)
+	var counter int
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			counter++
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.Equal(t, 10, counter)
+}
+
 func TestSemaphoreOrderError(t *testing.T) {
 	g := New(1)
 	output := []int{}
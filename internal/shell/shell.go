@@ -38,6 +38,9 @@ func Run(ctx *context.Context, dir string, command, env []string, output bool) e
 	log.WithFields(fields).Debug("running")
 	if err := cmd.Run(); err != nil {
 		log.WithFields(fields).WithError(err).Debug("failed")
+		if out := strings.TrimSpace(b.String()); out != "" {
+			return fmt.Errorf("failed to run '%s': %w: %s", strings.Join(command, " "), err, out)
+		}
 		return fmt.Errorf("failed to run '%s': %w", strings.Join(command, " "), err)
 	}
 
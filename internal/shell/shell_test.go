@@ -27,7 +27,15 @@ func TestRunCommand(t *testing.T) {
 		require.EqualError(
 			t,
 			shell.Run(context.New(config.Project{}), "", []string{"sh", "-c", `echo something; exit 1`}, []string{}, true),
-			`failed to run 'sh -c echo something; exit 1': exit status 1`,
+			`failed to run 'sh -c echo something; exit 1': exit status 1: something`,
+		)
+	})
+
+	t.Run("cmd with stderr and no output", func(t *testing.T) {
+		require.EqualError(
+			t,
+			shell.Run(context.New(config.Project{}), "", []string{"sh", "-c", `echo oops >&2; exit 1`}, []string{}, false),
+			`failed to run 'sh -c echo oops >&2; exit 1': exit status 1: oops`,
 		)
 	})
 
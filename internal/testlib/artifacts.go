@@ -0,0 +1,46 @@
+package testlib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+// ReleaseArtifactsSum is the sha256 of each file written by
+// AddReleaseArtifacts.
+const ReleaseArtifactsSum = "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc"
+
+// AddReleaseArtifacts adds a couple archives, with the `archives` ID, and a
+// package, with the `packages` ID, to the context, written to its dist
+// folder, and a binary, which is not released.
+func AddReleaseArtifacts(tb testing.TB, ctx *context.Context) {
+	tb.Helper()
+	for _, a := range []struct {
+		name, id string
+		typ      artifact.Type
+	}{
+		{"foo_linux_amd64.tar.gz", "archives", artifact.UploadableArchive},
+		{"foo_windows_amd64.zip", "archives", artifact.UploadableArchive},
+		{"foo_1.2.3_amd64.deb", "packages", artifact.LinuxPackage},
+	} {
+		path := filepath.Join(ctx.Config.Dist, a.name)
+		require.NoError(tb, os.WriteFile(path, []byte("some string"), 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: a.name,
+			Path: path,
+			Type: a.typ,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: a.id,
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo",
+		Path: filepath.Join(ctx.Config.Dist, "foo_linux_amd64", "foo"),
+		Type: artifact.Binary,
+	})
+}
@@ -0,0 +1,26 @@
+package testlib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// FakeToolOutputEnv is the env variable holding the path of the file the fake
+// tool writes its arguments to.
+const FakeToolOutputEnv = "FAKE_TOOL_OUTPUT"
+
+// FakeTool creates a script that writes its arguments to the file set in the
+// FakeToolOutputEnv env variable, creating its parent directories, and
+// returns its path.
+func FakeTool(tb testing.TB) string {
+	tb.Helper()
+	tool := filepath.Join(tb.TempDir(), "faketool")
+	require.NoError(tb, os.WriteFile(tool, []byte(`#!/bin/sh
+mkdir -p "$(dirname "$`+FakeToolOutputEnv+`")"
+echo "$@" > "$`+FakeToolOutputEnv+`"
+`), 0o755))
+	return tool
+}
@@ -0,0 +1,20 @@
+package testlib
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeTool(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "a", "b")
+	cmd := exec.Command(FakeTool(t), "foo", "--bar")
+	cmd.Env = []string{FakeToolOutputEnv + "=" + out}
+	require.NoError(t, cmd.Run())
+	bts, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "foo --bar\n", string(bts))
+}
@@ -58,6 +58,7 @@ const (
 	// artifact-only keys.
 	osKey        = "Os"
 	amd64        = "Amd64"
+	arm64        = "Arm64"
 	arch         = "Arch"
 	arm          = "Arm"
 	mips         = "Mips"
@@ -148,6 +149,7 @@ func (t *Template) WithArtifact(a *artifact.Artifact, replacements map[string]st
 	t.fields[arm] = replace(replacements, a.Goarm)
 	t.fields[mips] = replace(replacements, a.Gomips)
 	t.fields[amd64] = replace(replacements, a.Goamd64)
+	t.fields[arm64] = replace(replacements, a.Goarm64)
 	t.fields[binary] = bin.(string)
 	t.fields[artifactName] = a.Name
 	t.fields[artifactPath] = a.Path
@@ -168,6 +170,8 @@ func buildOptsToFields(opts build.Options) Fields {
 		arch:   opts.Goarch,
 		arm:    opts.Goarm,
 		mips:   opts.Gomips,
+		amd64:  opts.Goamd64,
+		arm64:  opts.Goarm64,
 	}
 }
 
@@ -46,6 +46,7 @@ func TestWithArtifact(t *testing.T) {
 		"6":                                "{{.Arm}}",
 		"softfloat":                        "{{.Mips}}",
 		"v3":                               "{{.Amd64}}",
+		"v8.2":                             "{{.Arm64}}",
 		"1.2.3":                            "{{.Version}}",
 		"v1.2.3":                           "{{.Tag}}",
 		"1-2-3":                            "{{.Major}}-{{.Minor}}-{{.Patch}}",
@@ -85,6 +86,7 @@ func TestWithArtifact(t *testing.T) {
 					Goarm:   "6",
 					Gomips:  "softfloat",
 					Goamd64: "v3",
+					Goarm64: "v8.2",
 					Extra: map[string]interface{}{
 						artifact.ExtraBinary: "binary",
 					},
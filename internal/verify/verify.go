@@ -0,0 +1,391 @@
+// Package verify downloads the artifacts of an existing release and checks
+// them against its checksums file and signatures.
+package verify
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/shell"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// errNotFound is returned when a file is not part of the release.
+var errNotFound = errors.New("not found")
+
+// Status of a single check.
+type Status string
+
+const (
+	// StatusOK means the check passed.
+	StatusOK Status = "ok"
+	// StatusFailed means the check failed.
+	StatusFailed Status = "failed"
+	// StatusMissing means there was nothing to check, e.g. the artifact was
+	// not signed, but it might not have to be.
+	StatusMissing Status = "missing"
+)
+
+// Check is the result of verifying one file.
+type Check struct {
+	Name      string
+	Kind      string // checksum or signature
+	Status    Status
+	Err       error
+	Signature string
+}
+
+// Report holds all the checks done.
+type Report struct {
+	Checks []Check
+}
+
+// Failed returns true if any of the checks failed.
+func (r Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Options for the verification.
+type Options struct {
+	// URLTemplate of the release downloads, with `.Tag` and `.ArtifactName`
+	// available.
+	URLTemplate string
+	// Cmd and Args used to verify signatures. Args may use the `${artifact}`
+	// and `${signature}` variables.
+	Cmd  string
+	Args []string
+	// Dir to download files into.
+	Dir string
+}
+
+// Run downloads the checksums file of the release in the given context,
+// checks all the files listed in it, and then verifies their signatures
+// according to the signs configuration.
+// The context is expected to have its defaults set.
+func Run(ctx *context.Context, opts Options) (Report, error) {
+	var report Report
+	cfg := ctx.Config.Checksum
+	if cfg.Disable {
+		return report, errors.New("checksums are disabled in the configuration, nothing to verify")
+	}
+
+	checksums, err := tmpl.New(ctx).
+		WithExtraFields(tmpl.Fields{"Algorithm": cfg.Algorithm}).
+		Apply(cfg.NameTemplate)
+	if err != nil {
+		return report, fmt.Errorf("failed to apply checksums name template: %w", err)
+	}
+	checksumsPath, err := download(ctx, opts, checksums)
+	if err != nil {
+		return report, fmt.Errorf("failed to download %s: %w", checksums, err)
+	}
+	sums, err := parseChecksums(checksumsPath)
+	if err != nil {
+		return report, err
+	}
+
+	names := make([]string, 0, len(sums))
+	for _, sum := range sums {
+		names = append(names, sum.name)
+		report.Checks = append(report.Checks, verifyChecksum(ctx, opts, cfg.Algorithm, sum))
+	}
+
+	kinds, err := kindsOf(ctx, names)
+	if err != nil {
+		return report, err
+	}
+	for _, sign := range ctx.Config.Signs {
+		var candidates []string
+		switch sign.Artifacts {
+		case "none":
+			continue
+		case "checksum":
+			candidates = []string{checksums}
+		case "all":
+			candidates = append([]string{checksums}, names...)
+		case "source", "archive", "binary", "package", "sbom":
+			for _, name := range names {
+				if kinds[name] == sign.Artifacts {
+					candidates = append(candidates, name)
+				}
+			}
+		default:
+			return report, fmt.Errorf("invalid list of artifacts to sign: %s", sign.Artifacts)
+		}
+		for _, name := range candidates {
+			check := verifySignature(ctx, opts, sign, name)
+			if check.Status == StatusMissing && mustBeSigned(sign, name, checksums) {
+				check.Status = StatusFailed
+				check.Err = errors.New("signature not found")
+			}
+			report.Checks = append(report.Checks, check)
+		}
+	}
+	return report, nil
+}
+
+// mustBeSigned returns false if the given file might not be signed by the
+// given sign, as its ids can't be matched against the file names.
+func mustBeSigned(sign config.Sign, name, checksums string) bool {
+	switch sign.Artifacts {
+	case "checksum", "source":
+		// ids have no effect on those.
+		return true
+	}
+	return name == checksums || len(sign.IDs) == 0
+}
+
+// kindsOf guesses the kind of each of the given files, as used by the
+// `artifacts` option of the signs, from their names and the configuration:
+// source, package, sbom, archive or binary.
+// Debug symbols, provenance and attestations get an empty kind.
+func kindsOf(ctx *context.Context, names []string) (map[string]string, error) {
+	var source string
+	if ctx.Config.Source.Enabled {
+		name, err := tmpl.New(ctx).Apply(ctx.Config.Source.NameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply source name template: %w", err)
+		}
+		source = name + "." + ctx.Config.Source.Format
+	}
+
+	var packages, sboms, others, archives []string
+	for _, fpm := range ctx.Config.NFPMs {
+		for _, format := range fpm.Formats {
+			packages = append(packages, "."+format)
+		}
+	}
+	for _, sbom := range ctx.Config.SBOMs {
+		for _, doc := range sbom.Documents {
+			sboms = append(sboms, literalSuffix(doc))
+		}
+	}
+	others = append(others, ".intoto.json", ".intoto.jsonl", literalSuffix(ctx.Config.Provenance.NameTemplate))
+	if ctx.Config.DebugSymbols.Enabled {
+		others = append(others, literalSuffix(ctx.Config.DebugSymbols.NameTemplate))
+	}
+	for _, attestation := range ctx.Config.Attestations {
+		others = append(others, literalSuffix(attestation.NameTemplate))
+	}
+	for _, archive := range ctx.Config.Archives {
+		formats := []string{archive.Format}
+		for _, override := range archive.FormatOverrides {
+			formats = append(formats, override.Format)
+		}
+		for _, format := range formats {
+			if format != "binary" {
+				archives = append(archives, "."+format)
+			}
+		}
+	}
+
+	kinds := make(map[string]string, len(names))
+	for _, name := range names {
+		switch {
+		case name == source:
+			kinds[name] = "source"
+		case hasAnySuffix(name, packages):
+			kinds[name] = "package"
+		case hasAnySuffix(name, sboms):
+			kinds[name] = "sbom"
+		case hasAnySuffix(name, others):
+			kinds[name] = ""
+		case hasAnySuffix(name, archives):
+			kinds[name] = "archive"
+		default:
+			kinds[name] = "binary"
+		}
+	}
+	return kinds, nil
+}
+
+// literalSuffix returns the part of the given name template after its last
+// action, e.g. `.sbom` for `{{ .ArtifactName }}.sbom`.
+func literalSuffix(s string) string {
+	if i := strings.LastIndex(s, "}}"); i >= 0 {
+		return s[i+2:]
+	}
+	return s
+}
+
+func hasAnySuffix(name string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if suffix != "" && strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+type checksum struct {
+	sum  string
+	name string
+}
+
+func parseChecksums(path string) ([]checksum, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sums []checksum
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid checksums file: unexpected line: %q", line)
+		}
+		sums = append(sums, checksum{sum: parts[0], name: parts[1]})
+	}
+	return sums, scanner.Err()
+}
+
+func verifyChecksum(ctx *context.Context, opts Options, algorithm string, sum checksum) Check {
+	check := Check{Name: sum.name, Kind: "checksum"}
+	path, err := download(ctx, opts, sum.name)
+	if err != nil {
+		check.Status = StatusFailed
+		check.Err = fmt.Errorf("failed to download: %w", err)
+		return check
+	}
+	got, err := artifact.Artifact{Path: path}.Checksum(algorithm)
+	if err != nil {
+		check.Status = StatusFailed
+		check.Err = err
+		return check
+	}
+	if got != sum.sum {
+		check.Status = StatusFailed
+		check.Err = fmt.Errorf("expected %s, got %s", sum.sum, got)
+		return check
+	}
+	check.Status = StatusOK
+	return check
+}
+
+func verifySignature(ctx *context.Context, opts Options, sign config.Sign, name string) Check {
+	check := Check{Name: name, Kind: "signature"}
+	env := ctx.Env.Copy()
+	env["artifact"] = filepath.Join(opts.Dir, name)
+	env["artifactName"] = name
+
+	signature, err := tmpl.New(ctx).WithEnv(env).Apply(expand(sign.Signature, env))
+	if err != nil {
+		check.Status = StatusFailed
+		check.Err = fmt.Errorf("failed to apply signature template: %w", err)
+		return check
+	}
+	// the signature template usually refers to the artifact path, so
+	// make it relative to the download dir again.
+	signature = strings.TrimPrefix(signature, opts.Dir+string(filepath.Separator))
+	check.Signature = signature
+
+	sigPath, err := download(ctx, opts, signature)
+	if errors.Is(err, errNotFound) {
+		check.Status = StatusMissing
+		return check
+	}
+	if err != nil {
+		check.Status = StatusFailed
+		check.Err = fmt.Errorf("failed to download %s: %w", signature, err)
+		return check
+	}
+	if _, err := os.Stat(env["artifact"]); err != nil {
+		if _, err := download(ctx, opts, name); err != nil {
+			check.Status = StatusFailed
+			check.Err = fmt.Errorf("failed to download: %w", err)
+			return check
+		}
+	}
+	env["signature"] = sigPath
+
+	command := []string{opts.Cmd}
+	for _, a := range opts.Args {
+		command = append(command, expand(a, env))
+	}
+	if err := shell.Run(ctx, opts.Dir, command, env.Strings(), false); err != nil {
+		check.Status = StatusFailed
+		check.Err = err
+		return check
+	}
+	check.Status = StatusOK
+	return check
+}
+
+func expand(s string, env map[string]string) string {
+	return os.Expand(s, func(k string) string {
+		return env[k]
+	})
+}
+
+// download fetches the given release file into the download dir, unless it
+// was already downloaded.
+func download(ctx *context.Context, opts Options, name string) (string, error) {
+	// names come from the downloaded checksums file, so they can't be
+	// trusted to stay inside the download dir.
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) || filepath.IsAbs(name) {
+		return "", fmt.Errorf("invalid file name: %q", name)
+	}
+	path := filepath.Join(opts.Dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	url, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"ArtifactName": name,
+	}).Apply(opts.URLTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply url template: %w", err)
+	}
+
+	log.WithField("url", url).Debug("downloading")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, f.Close()
+}
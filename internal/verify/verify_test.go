@@ -0,0 +1,362 @@
+package verify
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+// sha256 of "some string"
+const sum = "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc"
+
+func TestRun(t *testing.T) {
+	newRelease := func(tb testing.TB, files map[string]string) string {
+		tb.Helper()
+		dir := tb.TempDir()
+		for name, content := range files {
+			require.NoError(tb, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+		srv := httptest.NewServer(http.StripPrefix("/v1.2.3/", http.FileServer(http.Dir(dir))))
+		tb.Cleanup(srv.Close)
+		return srv.URL + "/{{ .Tag }}/{{ .ArtifactName }}"
+	}
+
+	newCtx := func(signs ...config.Sign) *context.Context {
+		ctx := context.New(config.Project{
+			ProjectName: "foo",
+			Checksum: config.Checksum{
+				NameTemplate: "checksums.txt",
+				Algorithm:    "sha256",
+			},
+			Signs: signs,
+		})
+		ctx.Git.CurrentTag = "v1.2.3"
+		ctx.Version = "1.2.3"
+		return ctx
+	}
+
+	// signatures are copies of the signed files.
+	opts := func(tb testing.TB, url string) Options {
+		tb.Helper()
+		return Options{
+			URLTemplate: url,
+			Cmd:         "sh",
+			Args:        []string{"-c", "cmp -s ${signature} ${artifact}"},
+			Dir:         tb.TempDir(),
+		}
+	}
+
+	checksums := sum + "  foo_linux_amd64.tar.gz\n" + sum + "  foo_1.2.3_amd64.deb\n"
+
+	t.Run("checksums only", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+			"foo_1.2.3_amd64.deb":    "some string",
+		})
+		report, err := Run(newCtx(), opts(t, url))
+		require.NoError(t, err)
+		require.False(t, report.Failed())
+		require.Equal(t, []Check{
+			{Name: "foo_linux_amd64.tar.gz", Kind: "checksum", Status: StatusOK},
+			{Name: "foo_1.2.3_amd64.deb", Kind: "checksum", Status: StatusOK},
+		}, report.Checks)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+			"foo_1.2.3_amd64.deb":    "tampered",
+		})
+		report, err := Run(newCtx(), opts(t, url))
+		require.NoError(t, err)
+		require.True(t, report.Failed())
+		require.Equal(t, StatusOK, report.Checks[0].Status)
+		require.Equal(t, StatusFailed, report.Checks[1].Status)
+		require.ErrorContains(t, report.Checks[1].Err, "expected "+sum)
+	})
+
+	t.Run("missing artifact", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+		})
+		report, err := Run(newCtx(), opts(t, url))
+		require.NoError(t, err)
+		require.True(t, report.Failed())
+		require.ErrorIs(t, report.Checks[1].Err, errNotFound)
+	})
+
+	t.Run("unsafe names", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt": sum + "  ../foo.tar.gz\n" + sum + "  sub/foo.deb\n" + sum + "  ..\n",
+		})
+		o := opts(t, url)
+		o.Dir = filepath.Join(t.TempDir(), "downloads")
+		report, err := Run(newCtx(), o)
+		require.NoError(t, err)
+		require.True(t, report.Failed())
+		require.Len(t, report.Checks, 3)
+		for _, check := range report.Checks {
+			require.Equal(t, StatusFailed, check.Status)
+			require.EqualError(t, check.Err, fmt.Sprintf("failed to download: invalid file name: %q", check.Name))
+		}
+		require.NoFileExists(t, filepath.Join(filepath.Dir(o.Dir), "foo.tar.gz"))
+	})
+
+	t.Run("signed checksums", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"checksums.txt.sig":      checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+			"foo_1.2.3_amd64.deb":    "some string",
+		})
+		report, err := Run(newCtx(config.Sign{
+			ID:        "default",
+			Signature: "${artifact}.sig",
+			Artifacts: "checksum",
+		}), opts(t, url))
+		require.NoError(t, err)
+		require.False(t, report.Failed())
+		require.Len(t, report.Checks, 3)
+		require.Equal(t, Check{
+			Name:      "checksums.txt",
+			Kind:      "signature",
+			Status:    StatusOK,
+			Signature: "checksums.txt.sig",
+		}, report.Checks[2])
+	})
+
+	t.Run("missing checksums signature", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+			"foo_1.2.3_amd64.deb":    "some string",
+		})
+		report, err := Run(newCtx(config.Sign{
+			ID:        "default",
+			Signature: "${artifact}.sig",
+			Artifacts: "checksum",
+		}), opts(t, url))
+		require.NoError(t, err)
+		require.True(t, report.Failed())
+		require.Equal(t, StatusFailed, report.Checks[2].Status)
+		require.EqualError(t, report.Checks[2].Err, "signature not found")
+	})
+
+	t.Run("signed archives", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			ids    []string
+			status Status
+		}{
+			"missing":         {status: StatusFailed},
+			"missing but ids": {ids: []string{"foo"}, status: StatusMissing},
+		} {
+			t.Run(name, func(t *testing.T) {
+				url := newRelease(t, map[string]string{
+					"checksums.txt":          checksums,
+					"foo_linux_amd64.tar.gz": "some string",
+					"foo_1.2.3_amd64.deb":    "some string",
+				})
+				ctx := newCtx(config.Sign{
+					ID:        "default",
+					IDs:       tt.ids,
+					Signature: "${artifact}.sig",
+					Artifacts: "archive",
+				})
+				ctx.Config.Archives = []config.Archive{{Format: "tar.gz"}}
+				ctx.Config.NFPMs = []config.NFPM{{Formats: []string{"deb"}}}
+				report, err := Run(ctx, opts(t, url))
+				require.NoError(t, err)
+				// the package is not an archive, so it is not checked
+				require.Len(t, report.Checks, 3)
+				require.Equal(t, "foo_linux_amd64.tar.gz", report.Checks[2].Name)
+				require.Equal(t, tt.status, report.Checks[2].Status)
+				require.Equal(t, tt.status == StatusFailed, report.Failed())
+			})
+		}
+	})
+
+	t.Run("signed packages", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":           checksums,
+			"foo_linux_amd64.tar.gz":  "some string",
+			"foo_1.2.3_amd64.deb":     "some string",
+			"foo_1.2.3_amd64.deb.sig": "some string",
+		})
+		ctx := newCtx(config.Sign{
+			ID:        "default",
+			Signature: "${artifact}.sig",
+			Artifacts: "package",
+		})
+		ctx.Config.NFPMs = []config.NFPM{{Formats: []string{"deb"}}}
+		report, err := Run(ctx, opts(t, url))
+		require.NoError(t, err)
+		require.False(t, report.Failed())
+		require.Len(t, report.Checks, 3)
+		require.Equal(t, Check{
+			Name:      "foo_1.2.3_amd64.deb",
+			Kind:      "signature",
+			Status:    StatusOK,
+			Signature: "foo_1.2.3_amd64.deb.sig",
+		}, report.Checks[2])
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":              checksums,
+			"checksums.txt.sig":          checksums,
+			"foo_linux_amd64.tar.gz":     "some string",
+			"foo_linux_amd64.tar.gz.sig": "some string",
+			"foo_1.2.3_amd64.deb":        "some string",
+			"foo_1.2.3_amd64.deb.sig":    "forged",
+		})
+		report, err := Run(newCtx(config.Sign{
+			ID:        "default",
+			Signature: "${artifact}.sig",
+			Artifacts: "all",
+		}), opts(t, url))
+		require.NoError(t, err)
+		require.True(t, report.Failed())
+		require.Len(t, report.Checks, 5)
+		require.Equal(t, StatusOK, report.Checks[2].Status)
+		require.Equal(t, StatusOK, report.Checks[3].Status)
+		require.Equal(t, "foo_1.2.3_amd64.deb", report.Checks[4].Name)
+		require.Equal(t, StatusFailed, report.Checks[4].Status)
+		require.Error(t, report.Checks[4].Err)
+	})
+
+	t.Run("missing signature of all", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":              checksums,
+			"checksums.txt.sig":          checksums,
+			"foo_linux_amd64.tar.gz":     "some string",
+			"foo_linux_amd64.tar.gz.sig": "some string",
+			"foo_1.2.3_amd64.deb":        "some string",
+		})
+		report, err := Run(newCtx(config.Sign{
+			ID:        "default",
+			Signature: "${artifact}.sig",
+			Artifacts: "all",
+		}), opts(t, url))
+		require.NoError(t, err)
+		require.True(t, report.Failed())
+		require.Len(t, report.Checks, 5)
+		require.Equal(t, "foo_1.2.3_amd64.deb", report.Checks[4].Name)
+		require.Equal(t, StatusFailed, report.Checks[4].Status)
+		require.EqualError(t, report.Checks[4].Err, "signature not found")
+	})
+
+	t.Run("invalid sign artifacts", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+			"foo_1.2.3_amd64.deb":    "some string",
+		})
+		_, err := Run(newCtx(config.Sign{Artifacts: "nope"}), opts(t, url))
+		require.EqualError(t, err, "invalid list of artifacts to sign: nope")
+	})
+
+	t.Run("signs none", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt":          checksums,
+			"foo_linux_amd64.tar.gz": "some string",
+			"foo_1.2.3_amd64.deb":    "some string",
+		})
+		report, err := Run(newCtx(config.Sign{
+			ID:        "default",
+			Signature: "${artifact}.sig",
+			Artifacts: "none",
+		}), opts(t, url))
+		require.NoError(t, err)
+		require.Len(t, report.Checks, 2)
+	})
+
+	t.Run("missing checksums file", func(t *testing.T) {
+		url := newRelease(t, map[string]string{})
+		_, err := Run(newCtx(), opts(t, url))
+		require.EqualError(t, err, "failed to download checksums.txt: not found")
+	})
+
+	t.Run("invalid checksums file", func(t *testing.T) {
+		url := newRelease(t, map[string]string{
+			"checksums.txt": "nope\n",
+		})
+		_, err := Run(newCtx(), opts(t, url))
+		require.EqualError(t, err, `invalid checksums file: unexpected line: "nope"`)
+	})
+
+	t.Run("checksums disabled", func(t *testing.T) {
+		ctx := newCtx()
+		ctx.Config.Checksum.Disable = true
+		_, err := Run(ctx, opts(t, "http://example.com"))
+		require.EqualError(t, err, "checksums are disabled in the configuration, nothing to verify")
+	})
+
+	t.Run("invalid name template", func(t *testing.T) {
+		ctx := newCtx()
+		ctx.Config.Checksum.NameTemplate = "{{ .Nope }"
+		_, err := Run(ctx, opts(t, "http://example.com"))
+		require.EqualError(t, err, `failed to apply checksums name template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
+
+func TestKindsOf(t *testing.T) {
+	ctx := context.New(config.Project{
+		ProjectName: "foo",
+		Source: config.Source{
+			Enabled:      true,
+			NameTemplate: "{{ .ProjectName }}-{{ .Version }}",
+			Format:       "tar.gz",
+		},
+		Archives: []config.Archive{{
+			Format:          "tar.gz",
+			FormatOverrides: []config.FormatOverride{{Goos: "windows", Format: "zip"}},
+		}},
+		NFPMs:        []config.NFPM{{Formats: []string{"deb", "rpm"}}},
+		SBOMs:        []config.SBOM{{Documents: []string{"{{ .ArtifactName }}.sbom"}}},
+		DebugSymbols: config.DebugSymbols{Enabled: true, NameTemplate: "{{ .Binary }}.debug"},
+		Provenance:   config.Provenance{NameTemplate: "{{ .ProjectName }}.intoto.jsonl"},
+	})
+	ctx.Version = "1.2.3"
+
+	kinds, err := kindsOf(ctx, []string{
+		"foo-1.2.3.tar.gz",
+		"foo_linux_amd64.tar.gz",
+		"foo_windows_amd64.zip",
+		"foo_1.2.3_amd64.deb",
+		"foo-1.2.3.x86_64.rpm",
+		"foo_linux_amd64.tar.gz.sbom",
+		"foo_linux_amd64.debug",
+		"foo.intoto.jsonl",
+		"foo_1.2.3_linux_amd64",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"foo-1.2.3.tar.gz":            "source",
+		"foo_linux_amd64.tar.gz":      "archive",
+		"foo_windows_amd64.zip":       "archive",
+		"foo_1.2.3_amd64.deb":         "package",
+		"foo-1.2.3.x86_64.rpm":        "package",
+		"foo_linux_amd64.tar.gz.sbom": "sbom",
+		"foo_linux_amd64.debug":       "",
+		"foo.intoto.jsonl":            "",
+		"foo_1.2.3_linux_amd64":       "binary",
+	}, kinds)
+
+	t.Run("invalid source name template", func(t *testing.T) {
+		ctx := context.New(config.Project{
+			Source: config.Source{Enabled: true, NameTemplate: "{{ .Nope }"},
+		})
+		_, err := kindsOf(ctx, nil)
+		require.EqualError(t, err, `failed to apply source name template: template: tmpl:1: unexpected "}" in operand`)
+	})
+}
@@ -2,13 +2,19 @@
 package archive
 
 import (
+	"compress/flate"
+	"context"
 	"fmt"
 	"io"
+	"os"
 
+	"github.com/goreleaser/goreleaser/pkg/archive/bzip2"
 	"github.com/goreleaser/goreleaser/pkg/archive/gzip"
+	"github.com/goreleaser/goreleaser/pkg/archive/sevenzip"
 	"github.com/goreleaser/goreleaser/pkg/archive/tar"
 	"github.com/goreleaser/goreleaser/pkg/archive/targz"
 	"github.com/goreleaser/goreleaser/pkg/archive/tarxz"
+	"github.com/goreleaser/goreleaser/pkg/archive/tarzst"
 	"github.com/goreleaser/goreleaser/pkg/archive/zip"
 	"github.com/goreleaser/goreleaser/pkg/config"
 )
@@ -21,17 +27,104 @@ type Archive interface {
 
 // New archive.
 func New(w io.Writer, format string) (Archive, error) {
+	return NewContext(context.Background(), w, format, "")
+}
+
+// NewContext creates a new archive, using the given compression preset if
+// it is not empty, like NewWithCompression.
+// The context is used by the formats that run external commands (7z), which
+// are stopped when it is cancelled.
+func NewContext(ctx context.Context, w io.Writer, format, compression string) (Archive, error) {
+	if compression != "" {
+		return newWithCompression(ctx, w, format, compression)
+	}
+	return newFormat(ctx, w, format)
+}
+
+func newFormat(ctx context.Context, w io.Writer, format string) (Archive, error) {
 	switch format {
-	case "tar.gz":
+	case "tar.gz", "tgz":
 		return targz.New(w), nil
 	case "tar":
 		return tar.New(w), nil
 	case "gz":
 		return gzip.New(w), nil
-	case "tar.xz":
+	case "bz2":
+		return bzip2.New(w), nil
+	case "tar.xz", "txz":
 		return tarxz.New(w), nil
+	case "tar.zst", "tzst":
+		return tarzst.New(w, 0), nil
 	case "zip":
 		return zip.New(w), nil
+	case "7z":
+		return sevenzip.New(ctx, w), nil
+	}
+	return nil, fmt.Errorf("invalid archive format: %s", format)
+}
+
+// Compression presets, translated to the levels of each format.
+const (
+	CompressionStore   = "store"
+	CompressionFastest = "fastest"
+	CompressionDefault = "default"
+	CompressionBest    = "best"
+)
+
+// flateLevels are the gzip and zip levels for each compression preset.
+var flateLevels = map[string]int{
+	CompressionStore:   flate.NoCompression,
+	CompressionFastest: flate.BestSpeed,
+	CompressionDefault: flate.DefaultCompression,
+	CompressionBest:    flate.BestCompression,
+}
+
+// zstdLevels are the tar.zst levels for each compression preset.
+// zstd can't store without compressing, so store is the same as fastest.
+var zstdLevels = map[string]int{
+	CompressionStore:   1,
+	CompressionFastest: 1,
+	CompressionDefault: 3,
+	CompressionBest:    22,
+}
+
+// NewWithCompression creates a new archive using the given compression
+// preset: store, fastest, default or best.
+// It is supported by the tar.gz, gz, zip and tar.zst formats; the other
+// formats use their usual level.
+func NewWithCompression(w io.Writer, format, compression string) (Archive, error) {
+	return newWithCompression(context.Background(), w, format, compression)
+}
+
+func newWithCompression(ctx context.Context, w io.Writer, format, compression string) (Archive, error) {
+	level, ok := flateLevels[compression]
+	if !ok {
+		return nil, fmt.Errorf("invalid compression: %s", compression)
+	}
+	switch format {
+	case "tar.gz", "tgz":
+		return targz.NewWithLevel(w, level)
+	case "gz":
+		return gzip.NewWithLevel(w, level)
+	case "zip":
+		return zip.NewWithLevel(w, level)
+	case "tar.zst", "tzst":
+		return tarzst.New(w, zstdLevels[compression]), nil
+	}
+	return newFormat(ctx, w, format)
+}
+
+// Copying creates a new archive in the given format with the contents of the
+// given source archive, so more files can be added to it.
+// Only the tar, tar.gz and zip formats are supported.
+func Copying(source *os.File, target io.Writer, format string) (Archive, error) {
+	switch format {
+	case "tar.gz", "tgz":
+		return targz.Copying(source, target)
+	case "tar":
+		return tar.Copying(source, target)
+	case "zip":
+		return zip.Copying(source, target)
 	}
 	return nil, fmt.Errorf("invalid archive format: %s", format)
 }
@@ -1,8 +1,12 @@
 package archive
 
 import (
+	"bytes"
+	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/pkg/config"
@@ -16,7 +20,7 @@ func TestArchive(t *testing.T) {
 	require.NoError(t, empty.Close())
 	require.NoError(t, os.Mkdir(folder+"/folder-inside", 0o755))
 
-	for _, format := range []string{"tar.gz", "zip", "gz", "tar.xz", "tar"} {
+	for _, format := range []string{"tar.gz", "tgz", "zip", "gz", "bz2", "tar.xz", "txz", "tar.zst", "tzst", "tar"} {
 		format := format
 		t.Run(format, func(t *testing.T) {
 			archive, err := New(io.Discard, format)
@@ -35,8 +39,104 @@ func TestArchive(t *testing.T) {
 		})
 	}
 
-	t.Run("7z", func(t *testing.T) {
-		_, err := New(io.Discard, "7z")
-		require.EqualError(t, err, "invalid archive format: 7z")
+	t.Run("rar", func(t *testing.T) {
+		_, err := New(io.Discard, "rar")
+		require.EqualError(t, err, "invalid archive format: rar")
+	})
+}
+
+func TestCopying(t *testing.T) {
+	folder := t.TempDir()
+	for _, format := range []string{"tar.gz", "tgz", "zip", "tar"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			f, err := os.Create(filepath.Join(folder, "src."+format))
+			require.NoError(t, err)
+			defer f.Close() // nolint: errcheck
+			archive, err := New(f, format)
+			require.NoError(t, err)
+			require.NoError(t, archive.Add(config.File{
+				Source:      "testdata/foo.txt",
+				Destination: "foo.txt",
+			}))
+			require.NoError(t, archive.Close())
+			_, err = f.Seek(0, io.SeekStart)
+			require.NoError(t, err)
+
+			archive, err = Copying(f, io.Discard, format)
+			require.NoError(t, err)
+			require.NoError(t, archive.Add(config.File{
+				Source:      "testdata/regular.txt",
+				Destination: "regular.txt",
+			}))
+			require.NoError(t, archive.Close())
+		})
+	}
+
+	t.Run("tar.xz", func(t *testing.T) {
+		_, err := Copying(nil, io.Discard, "tar.xz")
+		require.EqualError(t, err, "invalid archive format: tar.xz")
+	})
+}
+
+func TestNewWithCompression(t *testing.T) {
+	folder := t.TempDir()
+	content := filepath.Join(folder, "content.txt")
+	require.NoError(t, os.WriteFile(content, []byte(strings.Repeat("goreleaser ", 10000)), 0o644))
+
+	for _, format := range []string{"tar.gz", "tgz", "gz", "zip", "tar.zst", "tzst", "tar.xz", "tar"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			sizes := map[string]int{}
+			for _, compression := range []string{"store", "fastest", "default", "best"} {
+				var buf bytes.Buffer
+				archive, err := NewWithCompression(&buf, format, compression)
+				require.NoError(t, err)
+				require.NoError(t, archive.Add(config.File{
+					Source:      content,
+					Destination: "content.txt",
+				}))
+				require.NoError(t, archive.Close())
+				sizes[compression] = buf.Len()
+			}
+			switch format {
+			case "tar.xz", "tar":
+				// not affected by the compression presets
+				require.Equal(t, sizes["store"], sizes["best"])
+			case "tar.zst", "tzst":
+				require.LessOrEqual(t, sizes["best"], sizes["store"])
+			default:
+				require.Less(t, sizes["best"], sizes["store"])
+				require.Greater(t, sizes["store"], 110000)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewWithCompression(io.Discard, "zip", "ultra")
+		require.EqualError(t, err, "invalid compression: ultra")
+	})
+}
+
+func TestNewContext(t *testing.T) {
+	t.Run("compression", func(t *testing.T) {
+		var buf bytes.Buffer
+		archive, err := NewContext(context.Background(), &buf, "zip", "store")
+		require.NoError(t, err)
+		require.NoError(t, archive.Close())
+		require.NotZero(t, buf.Len())
+	})
+
+	t.Run("invalid compression", func(t *testing.T) {
+		_, err := NewContext(context.Background(), io.Discard, "zip", "ultra")
+		require.EqualError(t, err, "invalid compression: ultra")
+	})
+
+	t.Run("cancelled 7z", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		archive, err := NewContext(ctx, io.Discard, "7z", "")
+		require.NoError(t, err)
+		cancel()
+		require.ErrorIs(t, archive.Close(), context.Canceled)
 	})
 }
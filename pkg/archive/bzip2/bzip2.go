@@ -0,0 +1,55 @@
+// Package bzip2 implements the Archive interface providing bz2 archiving
+// and compression.
+package bzip2
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dsnet/compress/bzip2"
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+// Archive as bz2.
+type Archive struct {
+	bw    *bzip2.Writer
+	added *bool
+}
+
+// New bz2 archive.
+func New(target io.Writer) Archive {
+	// the error will be nil since the compression level is valid
+	bw, _ := bzip2.NewWriter(target, &bzip2.WriterConfig{Level: bzip2.BestCompression})
+	return Archive{
+		bw:    bw,
+		added: new(bool),
+	}
+}
+
+// Close all closeables.
+func (a Archive) Close() error {
+	return a.bw.Close()
+}
+
+// Add file to the archive.
+func (a Archive) Add(f config.File) error {
+	if *a.added {
+		return fmt.Errorf("bzip2: failed to add %s, only one file can be archived in bz2 format", f.Destination)
+	}
+	file, err := os.Open(f.Source) // #nosec
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+	*a.added = true
+	_, err = io.Copy(a.bw, file)
+	return err
+}
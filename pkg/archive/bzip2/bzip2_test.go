@@ -0,0 +1,44 @@
+package bzip2
+
+import (
+	"compress/bzip2"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBz2File(t *testing.T) {
+	tmp := t.TempDir()
+	f, err := os.Create(filepath.Join(tmp, "test.bz2"))
+	require.NoError(t, err)
+	defer f.Close() // nolint: errcheck
+	archive := New(f)
+	defer archive.Close() // nolint: errcheck
+
+	require.NoError(t, archive.Add(config.File{
+		Destination: "sub1",
+		Source:      "../testdata/sub1",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Destination: "sub1/sub2/subfoo.txt",
+		Source:      "../testdata/sub1/sub2/subfoo.txt",
+	}))
+	require.EqualError(t, archive.Add(config.File{
+		Destination: "foo.txt",
+		Source:      "../testdata/foo.txt",
+	}), "bzip2: failed to add foo.txt, only one file can be archived in bz2 format")
+	require.NoError(t, archive.Close())
+	require.NoError(t, f.Close())
+
+	f, err = os.Open(f.Name())
+	require.NoError(t, err)
+	defer f.Close() // nolint: errcheck
+
+	bts, err := io.ReadAll(bzip2.NewReader(f))
+	require.NoError(t, err)
+	require.Equal(t, "sub\n", string(bts))
+}
@@ -25,6 +25,18 @@ func New(target io.Writer) Archive {
 	}
 }
 
+// NewWithLevel creates a new gz archive using the given gzip compression
+// level.
+func NewWithLevel(target io.Writer, level int) (Archive, error) {
+	gw, err := gzip.NewWriterLevel(target, level)
+	if err != nil {
+		return Archive{}, err
+	}
+	return Archive{
+		gw: gw,
+	}, nil
+}
+
 // Close all closeables.
 func (a Archive) Close() error {
 	return a.gw.Close()
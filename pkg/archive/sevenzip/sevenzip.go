@@ -0,0 +1,170 @@
+// Package sevenzip implements the Archive interface providing 7z archiving
+// and compression.
+//
+// There is no native Go 7z writer, so the files are staged in a temporary
+// directory and archived with the 7z command when the archive is closed.
+package sevenzip
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+// commands are the 7z commands that are tried, in order.
+var commands = []string{"7z", "7za", "7zz"}
+
+// Archive as 7z.
+type Archive struct {
+	ctx    context.Context
+	target io.Writer
+	dir    string
+	err    error
+	closed bool
+}
+
+// New 7z archive.
+// The archive is only created when it is closed: if the context is done by
+// then, the staged files are removed and nothing is written.
+func New(ctx context.Context, target io.Writer) *Archive {
+	dir, err := os.MkdirTemp("", "goreleaser-7z-")
+	return &Archive{
+		ctx:    ctx,
+		target: target,
+		dir:    dir,
+		err:    err,
+	}
+}
+
+// Close creates the 7z archive from the added files and writes it to the
+// target.
+// Closing it again is a no-op.
+func (a *Archive) Close() error {
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+	if a.err != nil {
+		return a.err
+	}
+	defer os.RemoveAll(a.dir)
+	if err := a.ctx.Err(); err != nil {
+		return err
+	}
+	root := filepath.Join(a.dir, "root")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+	bin, err := lookPath()
+	if err != nil {
+		return err
+	}
+	out := filepath.Join(a.dir, "archive.7z")
+	cmd := exec.CommandContext(a.ctx, bin, args(out)...)
+	cmd.Dir = root
+	if bts, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("7z: failed to create archive: %w: %s", err, string(bts))
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(a.target, f)
+	return err
+}
+
+// Add file to the archive.
+func (a *Archive) Add(f config.File) error {
+	if a.closed {
+		return fmt.Errorf("7z: archive already closed")
+	}
+	if a.err != nil {
+		return a.err
+	}
+	info, err := os.Lstat(f.Source) // #nosec
+	if err != nil {
+		return err
+	}
+	dst := filepath.Join(a.dir, "root", filepath.FromSlash(f.Destination))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	mode := info.Mode()
+	if f.Info.Mode != 0 {
+		mode = f.Info.Mode
+	}
+	mtime := info.ModTime()
+	if !f.Info.MTime.IsZero() {
+		mtime = f.Info.MTime
+	}
+	switch {
+	case info.IsDir():
+		if err := os.MkdirAll(dst, mode.Perm()|0o700); err != nil {
+			return err
+		}
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(f.Source) // #nosec
+		if err != nil {
+			return err
+		}
+		// symlinks times can't be set portably, so they are kept as is.
+		return os.Symlink(link, dst)
+	default:
+		if err := copyFile(f.Source, dst, mode.Perm()); err != nil {
+			return err
+		}
+	}
+	return os.Chtimes(dst, mtime, mtime)
+}
+
+// args returns the arguments to create the archive at the given path with
+// the contents of the current directory.
+// They are chosen so the same inputs produce the same archive: only the
+// modification times are stored, and compression runs on a single thread.
+func args(out string) []string {
+	return []string{
+		"a",
+		"-t7z",
+		"-mx=9",
+		"-mmt=1",
+		"-mtm=on",
+		"-mtc=off",
+		"-mta=off",
+		"-bd",
+		"-y",
+		out,
+		".",
+	}
+}
+
+func lookPath() (string, error) {
+	for _, cmd := range commands {
+		if bin, err := exec.LookPath(cmd); err == nil {
+			return bin, nil
+		}
+	}
+	return "", fmt.Errorf("7z: could not find any of %v in $PATH", commands)
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src) // #nosec
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,139 @@
+package sevenzip
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSevenZipStaging(t *testing.T) {
+	archive := New(context.Background(), io.Discard)
+	require.NoError(t, archive.err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(archive.dir)) })
+
+	mtime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.Error(t, archive.Add(config.File{
+		Source:      "../testdata/nope.txt",
+		Destination: "nope.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+		Info: config.FileInfo{
+			Mode:  0o600,
+			MTime: mtime,
+		},
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/sub1/sub2/subfoo.txt",
+		Destination: "sub1/sub2/subfoo.txt",
+	}))
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/link.txt",
+		Destination: "link.txt",
+	}))
+
+	root := filepath.Join(archive.dir, "root")
+	info, err := os.Stat(filepath.Join(root, "foo.txt"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	require.Equal(t, mtime, info.ModTime().UTC())
+
+	bts, err := os.ReadFile(filepath.Join(root, "sub1/sub2/subfoo.txt"))
+	require.NoError(t, err)
+	expected, err := os.ReadFile("../testdata/sub1/sub2/subfoo.txt")
+	require.NoError(t, err)
+	require.Equal(t, expected, bts)
+
+	link, err := os.Readlink(filepath.Join(root, "link.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "regular.txt", link)
+}
+
+func TestSevenZipArgs(t *testing.T) {
+	require.Equal(t, []string{
+		"a", "-t7z", "-mx=9", "-mmt=1", "-mtm=on", "-mtc=off", "-mta=off", "-bd", "-y", "out.7z", ".",
+	}, args("out.7z"))
+}
+
+func TestSevenZipMissingCommand(t *testing.T) {
+	t.Setenv("PATH", "")
+	archive := New(context.Background(), io.Discard)
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+	}))
+	require.EqualError(t, archive.Close(), "7z: could not find any of [7z 7za 7zz] in $PATH")
+	require.NoDirExists(t, archive.dir)
+}
+
+func TestSevenZipCancelled(t *testing.T) {
+	t.Setenv("PATH", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	archive := New(ctx, io.Discard)
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+	}))
+	cancel()
+	require.ErrorIs(t, archive.Close(), context.Canceled)
+	require.NoDirExists(t, archive.dir)
+}
+
+func TestSevenZipCloseTwice(t *testing.T) {
+	t.Setenv("PATH", "")
+	archive := New(context.Background(), io.Discard)
+	require.Error(t, archive.Close())
+	require.NoError(t, archive.Close())
+	require.EqualError(t, archive.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+	}), "7z: archive already closed")
+}
+
+func TestSevenZipFile(t *testing.T) {
+	if _, err := lookPath(); err != nil {
+		t.Skip("7z not installed")
+	}
+	create := func() []byte {
+		var buf bytes.Buffer
+		archive := New(context.Background(), &buf)
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/foo.txt",
+			Destination: "foo.txt",
+			Info: config.FileInfo{
+				MTime: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		}))
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/sub1/bar.txt",
+			Destination: "sub1/bar.txt",
+			Info: config.FileInfo{
+				MTime: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		}))
+		require.NoError(t, archive.Close())
+		require.NoDirExists(t, archive.dir)
+		return buf.Bytes()
+	}
+
+	bts := create()
+	require.Equal(t, bts, create())
+
+	path := filepath.Join(t.TempDir(), "test.7z")
+	require.NoError(t, os.WriteFile(path, bts, 0o644))
+	bin, err := lookPath()
+	require.NoError(t, err)
+	out, err := exec.Command(bin, "l", "-slt", path).CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.Contains(t, string(out), "Path = foo.txt")
+	require.Contains(t, string(out), "Path = sub1/bar.txt")
+}
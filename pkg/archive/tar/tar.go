@@ -21,6 +21,29 @@ func New(target io.Writer) Archive {
 	}
 }
 
+// Copying creates a new tar archive with the contents of the given source
+// tar archive, so more files can be added to it.
+func Copying(source io.Reader, target io.Writer) (Archive, error) {
+	w := New(target)
+	r := tar.NewReader(source)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Archive{}, err
+		}
+		if err := w.tw.WriteHeader(header); err != nil {
+			return Archive{}, err
+		}
+		if _, err := io.Copy(w.tw, r); err != nil { // #nosec
+			return Archive{}, err
+		}
+	}
+	return w, nil
+}
+
 // Close all closeables.
 func (a Archive) Close() error {
 	return a.tw.Close()
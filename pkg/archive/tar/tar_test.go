@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -159,3 +160,58 @@ func TestTarInvalidLink(t *testing.T) {
 		Destination: "badlink.txt",
 	}))
 }
+
+func TestTarCopying(t *testing.T) {
+	tmp := t.TempDir()
+	f1, err := os.Create(filepath.Join(tmp, "1.tar"))
+	require.NoError(t, err)
+	a := New(f1)
+	require.NoError(t, a.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+	}))
+	require.NoError(t, a.Close())
+	require.NoError(t, f1.Close())
+
+	f1, err = os.Open(f1.Name())
+	require.NoError(t, err)
+	defer f1.Close() // nolint: errcheck
+	f2, err := os.Create(filepath.Join(tmp, "2.tar"))
+	require.NoError(t, err)
+	defer f2.Close() // nolint: errcheck
+	a, err = Copying(f1, f2)
+	require.NoError(t, err)
+	require.NoError(t, a.Add(config.File{
+		Source:      "../testdata/sub1/bar.txt",
+		Destination: "bar.txt",
+	}))
+	require.NoError(t, a.Close())
+	require.NoError(t, f2.Close())
+
+	f2, err = os.Open(f2.Name())
+	require.NoError(t, err)
+	defer f2.Close() // nolint: errcheck
+	r := tar.NewReader(f2)
+	var paths []string
+	for {
+		next, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		paths = append(paths, next.Name)
+		if next.Name == "foo.txt" {
+			bts, err := io.ReadAll(r)
+			require.NoError(t, err)
+			expected, err := os.ReadFile("../testdata/foo.txt")
+			require.NoError(t, err)
+			require.Equal(t, expected, bts)
+		}
+	}
+	require.Equal(t, []string{"foo.txt", "bar.txt"}, paths)
+}
+
+func TestTarCopyingInvalid(t *testing.T) {
+	_, err := Copying(strings.NewReader("not a tar file, not at all"), io.Discard)
+	require.Error(t, err)
+}
@@ -27,6 +27,39 @@ func New(target io.Writer) Archive {
 	}
 }
 
+// NewWithLevel creates a new tar.gz archive using the given gzip compression
+// level.
+func NewWithLevel(target io.Writer, level int) (Archive, error) {
+	gw, err := gzip.NewWriterLevel(target, level)
+	if err != nil {
+		return Archive{}, err
+	}
+	tw := tar.New(gw)
+	return Archive{
+		gw: gw,
+		tw: &tw,
+	}, nil
+}
+
+// Copying creates a new tar.gz archive with the contents of the given source
+// tar.gz archive, so more files can be added to it.
+func Copying(source io.Reader, target io.Writer) (Archive, error) {
+	gr, err := gzip.NewReader(source)
+	if err != nil {
+		return Archive{}, err
+	}
+	defer gr.Close()
+	gw, _ := gzip.NewWriterLevel(target, gzip.BestCompression)
+	tw, err := tar.Copying(gr, gw)
+	if err != nil {
+		return Archive{}, err
+	}
+	return Archive{
+		gw: gw,
+		tw: &tw,
+	}, nil
+}
+
 // Close all closeables.
 func (a Archive) Close() error {
 	if err := a.tw.Close(); err != nil {
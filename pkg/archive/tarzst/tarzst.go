@@ -0,0 +1,46 @@
+// Package tarzst implements the Archive interface providing tar.zst archiving
+// and compression.
+package tarzst
+
+import (
+	"io"
+
+	"github.com/goreleaser/goreleaser/pkg/archive/tar"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Archive as tar.zst.
+type Archive struct {
+	zw *zstd.Encoder
+	tw *tar.Archive
+}
+
+// New tar.zst archive, using the given zstd compression level (from 1 to 22).
+// Zero means the default level.
+func New(target io.Writer, level int) Archive {
+	opts := []zstd.EOption{}
+	if level > 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+	// the error will be nil since the options are valid
+	zw, _ := zstd.NewWriter(target, opts...)
+	tw := tar.New(zw)
+	return Archive{
+		zw: zw,
+		tw: &tw,
+	}
+}
+
+// Close all closeables.
+func (a Archive) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.zw.Close()
+}
+
+// Add file to the archive.
+func (a Archive) Add(f config.File) error {
+	return a.tw.Add(f)
+}
@@ -0,0 +1,80 @@
+package tarzst
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTarZstFile(t *testing.T) {
+	for _, level := range []int{0, 1, 19} {
+		tmp := t.TempDir()
+		f, err := os.Create(filepath.Join(tmp, "test.tar.zst"))
+		require.NoError(t, err)
+		defer f.Close() // nolint: errcheck
+		archive := New(f, level)
+		defer archive.Close() // nolint: errcheck
+
+		require.Error(t, archive.Add(config.File{
+			Source:      "../testdata/nope.txt",
+			Destination: "nope.txt",
+		}))
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/foo.txt",
+			Destination: "foo.txt",
+		}))
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/sub1",
+			Destination: "sub1",
+		}))
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/sub1/executable",
+			Destination: "sub1/executable",
+		}))
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/link.txt",
+			Destination: "link.txt",
+		}))
+
+		require.NoError(t, archive.Close())
+		require.NoError(t, f.Close())
+
+		f, err = os.Open(f.Name())
+		require.NoError(t, err)
+		defer f.Close() // nolint: errcheck
+
+		zr, err := zstd.NewReader(f)
+		require.NoError(t, err)
+		defer zr.Close()
+
+		var paths []string
+		r := tar.NewReader(zr)
+		for {
+			next, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			paths = append(paths, next.Name)
+			if next.Name == "sub1/executable" {
+				ex := next.FileInfo().Mode() | 0o111
+				require.Equal(t, next.FileInfo().Mode().String(), ex.String())
+			}
+			if next.Name == "link.txt" {
+				require.Equal(t, next.Linkname, "regular.txt")
+			}
+		}
+		require.Equal(t, []string{
+			"foo.txt",
+			"sub1",
+			"sub1/executable",
+			"link.txt",
+		}, paths)
+	}
+}
@@ -5,6 +5,7 @@ package zip
 import (
 	"archive/zip"
 	"compress/flate"
+	"fmt"
 	"io"
 	"os"
 
@@ -13,18 +14,58 @@ import (
 
 // Archive zip struct.
 type Archive struct {
-	z *zip.Writer
+	z      *zip.Writer
+	method uint16
 }
 
 // New zip archive.
 func New(target io.Writer) Archive {
+	// the error will be nil since the compression level is valid
+	a, _ := NewWithLevel(target, flate.BestCompression)
+	return a
+}
+
+// NewWithLevel creates a new zip archive using the given deflate compression
+// level.
+// flate.NoCompression stores the files without compressing them.
+func NewWithLevel(target io.Writer, level int) (Archive, error) {
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		return Archive{}, fmt.Errorf("zip: invalid compression level: %d", level)
+	}
 	compressor := zip.NewWriter(target)
+	if level == flate.NoCompression {
+		return Archive{
+			z:      compressor,
+			method: zip.Store,
+		}, nil
+	}
 	compressor.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
-		return flate.NewWriter(out, flate.BestCompression)
+		return flate.NewWriter(out, level)
 	})
 	return Archive{
-		z: compressor,
+		z:      compressor,
+		method: zip.Deflate,
+	}, nil
+}
+
+// Copying creates a new zip archive with the contents of the given source
+// zip archive, so more files can be added to it.
+func Copying(source *os.File, target io.Writer) (Archive, error) {
+	info, err := source.Stat()
+	if err != nil {
+		return Archive{}, err
+	}
+	r, err := zip.NewReader(source, info.Size())
+	if err != nil {
+		return Archive{}, err
 	}
+	w := New(target)
+	for _, zf := range r.File {
+		if err := w.z.Copy(zf); err != nil {
+			return Archive{}, err
+		}
+	}
+	return w, nil
 }
 
 // Close all closeables.
@@ -46,19 +87,25 @@ func (a Archive) Add(f config.File) error {
 		return err
 	}
 	header.Name = f.Destination
-	header.Method = zip.Deflate
+	header.Method = a.method
 	if !f.Info.MTime.IsZero() {
 		header.Modified = f.Info.MTime
 	}
 	if f.Info.Mode != 0 {
-		header.SetMode(f.Info.Mode)
+		header.SetMode(f.Info.Mode | info.Mode()&os.ModeSymlink)
 	}
 	w, err := a.z.CreateHeader(header)
 	if err != nil {
 		return err
 	}
-	if info.IsDir() || info.Mode()&os.ModeSymlink != 0 {
-		return nil
+	if info.Mode()&os.ModeSymlink != 0 {
+		// symlinks are stored with the link target as their content
+		link, err := os.Readlink(f.Source) // #nosec
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, link)
+		return err
 	}
 	file, err := os.Open(f.Source) // #nosec
 	if err != nil {
@@ -2,6 +2,7 @@ package zip
 
 import (
 	"archive/zip"
+	"compress/flate"
 	"io"
 	"io/fs"
 	"os"
@@ -86,6 +87,12 @@ func TestZipFile(t *testing.T) {
 		}
 		if zf.Name == "link.txt" {
 			require.True(t, zf.FileInfo().Mode()&os.ModeSymlink != 0)
+			rc, err := zf.Open()
+			require.NoError(t, err)
+			link, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			require.NoError(t, rc.Close())
+			require.Equal(t, "regular.txt", string(link))
 		}
 	}
 	require.Equal(t, []string{
@@ -147,3 +154,98 @@ func TestTarInvalidLink(t *testing.T) {
 		Destination: "badlink.txt",
 	}))
 }
+
+func TestZipSymlinkWithMode(t *testing.T) {
+	tmp := t.TempDir()
+	f, err := os.Create(filepath.Join(tmp, "test.zip"))
+	require.NoError(t, err)
+	defer f.Close() // nolint: errcheck
+	archive := New(f)
+	defer archive.Close() // nolint: errcheck
+
+	require.NoError(t, archive.Add(config.File{
+		Source:      "../testdata/link.txt",
+		Destination: "link.txt",
+		Info: config.FileInfo{
+			Mode: 0o644,
+		},
+	}))
+	require.NoError(t, archive.Close())
+	require.NoError(t, f.Close())
+
+	r, err := zip.OpenReader(f.Name())
+	require.NoError(t, err)
+	defer r.Close() // nolint: errcheck
+
+	require.Len(t, r.File, 1)
+	mode := r.File[0].Mode()
+	require.True(t, mode&os.ModeSymlink != 0)
+	require.Equal(t, fs.FileMode(0o644), mode.Perm())
+}
+
+func TestZipCopying(t *testing.T) {
+	tmp := t.TempDir()
+	f1, err := os.Create(filepath.Join(tmp, "1.zip"))
+	require.NoError(t, err)
+	a := New(f1)
+	require.NoError(t, a.Add(config.File{
+		Source:      "../testdata/foo.txt",
+		Destination: "foo.txt",
+	}))
+	require.NoError(t, a.Close())
+	require.NoError(t, f1.Close())
+
+	f1, err = os.Open(f1.Name())
+	require.NoError(t, err)
+	defer f1.Close() // nolint: errcheck
+	f2, err := os.Create(filepath.Join(tmp, "2.zip"))
+	require.NoError(t, err)
+	defer f2.Close() // nolint: errcheck
+	a, err = Copying(f1, f2)
+	require.NoError(t, err)
+	require.NoError(t, a.Add(config.File{
+		Source:      "../testdata/sub1/bar.txt",
+		Destination: "bar.txt",
+	}))
+	require.NoError(t, a.Close())
+	require.NoError(t, f2.Close())
+
+	r, err := zip.OpenReader(f2.Name())
+	require.NoError(t, err)
+	defer r.Close() // nolint: errcheck
+	var paths []string
+	for _, zf := range r.File {
+		paths = append(paths, zf.Name)
+	}
+	require.Equal(t, []string{"foo.txt", "bar.txt"}, paths)
+}
+
+func TestZipWithLevel(t *testing.T) {
+	for level, method := range map[int]uint16{
+		flate.NoCompression:   zip.Store,
+		flate.BestSpeed:       zip.Deflate,
+		flate.BestCompression: zip.Deflate,
+	} {
+		path := filepath.Join(t.TempDir(), "test.zip")
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close() // nolint: errcheck
+		archive, err := NewWithLevel(f, level)
+		require.NoError(t, err)
+		require.NoError(t, archive.Add(config.File{
+			Source:      "../testdata/foo.txt",
+			Destination: "foo.txt",
+		}))
+		require.NoError(t, archive.Close())
+		require.NoError(t, f.Close())
+
+		r, err := zip.OpenReader(path)
+		require.NoError(t, err)
+		require.Len(t, r.File, 1)
+		require.Equal(t, method, r.File[0].Method, "level %d", level)
+		require.NoError(t, r.Close())
+	}
+
+	_, err := NewWithLevel(io.Discard, 10)
+	require.EqualError(t, err, "zip: invalid compression level: 10")
+}
@@ -36,9 +36,19 @@ type Options struct {
 	Goarch  string
 	Goamd64 string
 	Goarm   string
+	Goarm64 string
 	Gomips  string
 }
 
+// HostEnv returns the environment a build should inherit from the host,
+// honoring its clean_env and env_passthrough settings.
+func HostEnv(ctx *context.Context, build config.Build) []string {
+	if !build.CleanEnv && len(build.EnvPassthrough) == 0 {
+		return ctx.Env.Strings()
+	}
+	return ctx.Env.Only(build.EnvPassthrough...).Strings()
+}
+
 // Builder defines a builder.
 type Builder interface {
 	WithDefaults(build config.Build) (config.Build, error)
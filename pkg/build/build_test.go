@@ -18,6 +18,21 @@ func (*dummy) Build(ctx *context.Context, build config.Build, options Options) e
 	return nil
 }
 
+func TestHostEnv(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Env = context.Env{"FOO": "bar", "HOME": "/home/foo"}
+
+	require.ElementsMatch(t, []string{"FOO=bar", "HOME=/home/foo"}, HostEnv(ctx, config.Build{}))
+	require.Empty(t, HostEnv(ctx, config.Build{CleanEnv: true}))
+	require.Equal(t, []string{"HOME=/home/foo"}, HostEnv(ctx, config.Build{
+		EnvPassthrough: []string{"HOME", "GOCACHE"},
+	}))
+	require.Equal(t, []string{"HOME=/home/foo"}, HostEnv(ctx, config.Build{
+		CleanEnv:       true,
+		EnvPassthrough: []string{"HOME"},
+	}))
+}
+
 func TestRegisterAndGet(t *testing.T) {
 	builder := &dummy{}
 	Register("dummy", builder)
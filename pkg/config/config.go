@@ -244,6 +244,7 @@ type IgnoredBuild struct {
 	Goarm   string `yaml:"goarm,omitempty"`
 	Gomips  string `yaml:"gomips,omitempty"`
 	Goamd64 string `yaml:"goamd64,omitempty"`
+	Goarm64 string `yaml:"goarm64,omitempty"`
 }
 
 // StringArray is a wrapper for an array of strings.
@@ -316,42 +317,62 @@ type Build struct {
 	Goarm           []string        `yaml:"goarm,omitempty"`
 	Gomips          []string        `yaml:"gomips,omitempty"`
 	Goamd64         []string        `yaml:"goamd64,omitempty"`
+	Goarm64         []string        `yaml:"goarm64,omitempty"`
 	Targets         []string        `yaml:"targets,omitempty"`
 	Ignore          []IgnoredBuild  `yaml:"ignore,omitempty"`
 	Dir             string          `yaml:"dir,omitempty"`
 	Main            string          `yaml:"main,omitempty"`
 	Binary          string          `yaml:"binary,omitempty"`
 	Hooks           BuildHookConfig `yaml:"hooks,omitempty"`
-	Env             []string        `yaml:"env,omitempty"`
 	Builder         string          `yaml:"builder,omitempty"`
 	ModTimestamp    string          `yaml:"mod_timestamp,omitempty"`
-	Skip            bool            `yaml:"skip,omitempty"`
+	Skip            string          `yaml:"skip,omitempty"`
 	GoBinary        string          `yaml:"gobinary,omitempty"`
+	Tool            string          `yaml:"tool,omitempty"`
+	BuildIn         string          `yaml:"build_in,omitempty"`
 	Command         string          `yaml:"command,omitempty"`
 	NoUniqueDistDir bool            `yaml:"no_unique_dist_dir,omitempty"`
+	DistDirTemplate string          `yaml:"dist_dir_template,omitempty"`
 	NoMainCheck     bool            `yaml:"no_main_check,omitempty"`
+	CleanEnv        bool            `yaml:"clean_env,omitempty"`
+	EnvPassthrough  []string        `yaml:"env_passthrough,omitempty"`
+	ZigCC           bool            `yaml:"zig_cc,omitempty"`
+	Trimpath        bool            `yaml:"trimpath,omitempty"`
+	Buildmode       string          `yaml:"buildmode,omitempty"`
+	PreBuilt        PreBuiltOptions `yaml:"prebuilt,omitempty"`
 	UnproxiedMain   string          `yaml:"-"` // used by gomod.proxy
 	UnproxiedDir    string          `yaml:"-"` // used by gomod.proxy
 
 	BuildDetails          `yaml:",inline"`       // nolint: tagliatelle
 	BuildDetailsOverrides []BuildDetailsOverride `yaml:"overrides,omitempty"`
+	FailOnInvalidTarget   bool                   `yaml:"fail_on_invalid_target,omitempty"`
+}
+
+// PreBuiltOptions configures the prebuilt builder.
+type PreBuiltOptions struct {
+	Path string `yaml:"path,omitempty"`
 }
 
 type BuildDetailsOverride struct {
+	Target       string           `yaml:"target,omitempty"`
 	Goos         string           `yaml:"goos,omitempty"`
 	Goarch       string           `yaml:"goarch,omitempty"`
 	Goarm        string           `yaml:"goarm,omitempty"`
 	Gomips       string           `yaml:"gomips,omitempty"`
 	Goamd64      string           `yaml:"goamd64,omitempty"`
+	Goarm64      string           `yaml:"goarm64,omitempty"`
 	BuildDetails `yaml:",inline"` // nolint: tagliatelle
 }
 
 type BuildDetails struct {
-	Ldflags  StringArray `yaml:"ldflags,omitempty"`
-	Tags     FlagArray   `yaml:"tags,omitempty"`
-	Flags    FlagArray   `yaml:"flags,omitempty"`
-	Asmflags StringArray `yaml:"asmflags,omitempty"`
-	Gcflags  StringArray `yaml:"gcflags,omitempty"`
+	Ldflags      StringArray `yaml:"ldflags,omitempty"`
+	Tags         FlagArray   `yaml:"tags,omitempty"`
+	Flags        FlagArray   `yaml:"flags,omitempty"`
+	Asmflags     StringArray `yaml:"asmflags,omitempty"`
+	Gcflags      StringArray `yaml:"gcflags,omitempty"`
+	Env          []string    `yaml:"env,omitempty"`
+	Goflags      FlagArray   `yaml:"goflags,omitempty"`
+	Goexperiment []string    `yaml:"goexperiment,omitempty"`
 }
 
 type BuildHookConfig struct {
@@ -402,6 +423,8 @@ type Hook struct {
 	Cmd    string   `yaml:"cmd,omitempty"`
 	Env    []string `yaml:"env,omitempty"`
 	Output bool     `yaml:"output,omitempty"`
+
+	AllowFailure bool `yaml:"allow_failure,omitempty"`
 }
 
 // UnmarshalYAML is a custom unmarshaler that allows simplified declarations of commands as strings.
@@ -439,16 +462,18 @@ func (bh Hook) JSONSchemaType() *jsonschema.Type {
 
 // FormatOverride is used to specify a custom format for a specific GOOS.
 type FormatOverride struct {
-	Goos   string `yaml:"goos,omitempty"`
-	Format string `yaml:"format,omitempty"`
+	Goos         string `yaml:"goos,omitempty"`
+	Format       string `yaml:"format,omitempty"`
+	NameTemplate string `yaml:"name_template,omitempty"`
 }
 
 // File is a file inside an archive.
 type File struct {
-	Source      string   `yaml:"src,omitempty"`
-	Destination string   `yaml:"dst,omitempty"`
-	StripParent bool     `yaml:"strip_parent,omitempty"`
-	Info        FileInfo `yaml:"info,omitempty"`
+	Source       string   `yaml:"src,omitempty"`
+	Destination  string   `yaml:"dst,omitempty"`
+	NameTemplate string   `yaml:"name_template,omitempty"`
+	StripParent  bool     `yaml:"strip_parent,omitempty"`
+	Info         FileInfo `yaml:"info,omitempty"`
 }
 
 // FileInfo is the file info of a file.
@@ -504,6 +529,59 @@ type UniversalBinary struct {
 	NameTemplate string          `yaml:"name_template,omitempty"`
 	Replace      bool            `yaml:"replace,omitempty"`
 	Hooks        BuildHookConfig `yaml:"hooks,omitempty"`
+	ModTimestamp string          `yaml:"mod_timestamp,omitempty"`
+}
+
+// UPX config used to compress binaries.
+type UPX struct {
+	Enabled  bool     `yaml:"enabled,omitempty"`
+	IDs      []string `yaml:"ids,omitempty"`
+	Goos     []string `yaml:"goos,omitempty"`
+	Goarch   []string `yaml:"goarch,omitempty"`
+	Goarm    []string `yaml:"goarm,omitempty"`
+	Goamd64  []string `yaml:"goamd64,omitempty"`
+	Goarm64  []string `yaml:"goarm64,omitempty"`
+	Binary   string   `yaml:"binary,omitempty"`
+	Compress string   `yaml:"compress,omitempty"`
+	LZMA     bool     `yaml:"lzma,omitempty"`
+	Brute    bool     `yaml:"brute,omitempty"`
+}
+
+// DebugSymbols config used to split the debug symbols out of the binaries.
+type DebugSymbols struct {
+	Enabled      bool     `yaml:"enabled,omitempty"`
+	IDs          []string `yaml:"ids,omitempty"`
+	Objcopy      string   `yaml:"objcopy,omitempty"`
+	NameTemplate string   `yaml:"name_template,omitempty"`
+}
+
+// Provenance config used to generate a SLSA provenance statement of the
+// released artifacts, inside a DSSE envelope.
+type Provenance struct {
+	Enabled      bool              `yaml:"enabled,omitempty"`
+	IDs          []string          `yaml:"ids,omitempty"`
+	NameTemplate string            `yaml:"name_template,omitempty"`
+	BuilderID    string            `yaml:"builder_id,omitempty"`
+	Signer       AttestationSigner `yaml:"signer,omitempty"`
+}
+
+// Attestation config used to wrap the released artifacts digests in an
+// in-toto statement, inside a DSSE envelope.
+type Attestation struct {
+	ID            string            `yaml:"id,omitempty"`
+	IDs           []string          `yaml:"ids,omitempty"`
+	NameTemplate  string            `yaml:"name_template,omitempty"`
+	PredicateType string            `yaml:"predicate_type,omitempty"`
+	PredicateFile string            `yaml:"predicate_file,omitempty"`
+	Signer        AttestationSigner `yaml:"signer,omitempty"`
+}
+
+// AttestationSigner config used to sign the DSSE envelope of an attestation
+// or of the provenance.
+type AttestationSigner struct {
+	Cmd   string   `yaml:"cmd,omitempty"`
+	Args  []string `yaml:"args,omitempty"`
+	KeyID string   `yaml:"key_id,omitempty"`
 }
 
 // Archive config used for the archive.
@@ -511,13 +589,30 @@ type Archive struct {
 	ID                        string            `yaml:"id,omitempty"`
 	Builds                    []string          `yaml:"builds,omitempty"`
 	NameTemplate              string            `yaml:"name_template,omitempty"`
+	BinaryNameTemplate        string            `yaml:"binary_name_template,omitempty"`
 	Replacements              map[string]string `yaml:"replacements,omitempty"`
 	Format                    string            `yaml:"format,omitempty"`
 	FormatOverrides           []FormatOverride  `yaml:"format_overrides,omitempty"`
+	Compression               string            `yaml:"compression,omitempty"`
 	WrapInDirectory           string            `yaml:"wrap_in_directory,omitempty"`
+	StripParentBinaryFolder   bool              `yaml:"strip_parent_binary_folder,omitempty"`
 	Files                     []File            `yaml:"files,omitempty"`
+	RLCP                      bool              `yaml:"rlcp,omitempty"`
+	DefaultFiles              []string          `yaml:"default_files,omitempty"`
+	DisableDefaultFiles       bool              `yaml:"disable_default_files,omitempty"`
+	FilesInfo                 FileInfo          `yaml:"files_info,omitempty"`
+	BuildsInfo                FileInfo          `yaml:"builds_info,omitempty"`
 	Meta                      bool              `yaml:"meta,omitempty"`
 	AllowDifferentBinaryCount bool              `yaml:"allow_different_binary_count,omitempty"`
+	Reproducible              bool              `yaml:"reproducible,omitempty"`
+	Manifest                  ArchiveManifest   `yaml:"manifest,omitempty"`
+}
+
+// ArchiveManifest configures the manifest file added to archives.
+type ArchiveManifest struct {
+	Enabled      bool   `yaml:"enabled,omitempty"`
+	NameTemplate string `yaml:"name_template,omitempty"`
+	Format       string `yaml:"format,omitempty"`
 }
 
 type ReleaseNotesMode string
@@ -535,18 +630,33 @@ type Release struct {
 	GitLab                 Repo        `yaml:"gitlab,omitempty"`
 	Gitea                  Repo        `yaml:"gitea,omitempty"`
 	Draft                  bool        `yaml:"draft,omitempty"`
-	Disable                bool        `yaml:"disable,omitempty"`
+	Disable                string      `yaml:"disable,omitempty"`
 	Prerelease             string      `yaml:"prerelease,omitempty"`
 	NameTemplate           string      `yaml:"name_template,omitempty"`
 	IDs                    []string    `yaml:"ids,omitempty"`
 	ExtraFiles             []ExtraFile `yaml:"extra_files,omitempty"`
 	DiscussionCategoryName string      `yaml:"discussion_category_name,omitempty"`
+	TargetCommitish        string      `yaml:"target_commitish,omitempty"`
+	UseGitHubReleaseNotes  bool        `yaml:"use_github_release_notes,omitempty"`
 	Header                 string      `yaml:"header,omitempty"`
 	Footer                 string      `yaml:"footer,omitempty"`
+	HeaderFile             string      `yaml:"header_file,omitempty"`
+	FooterFile             string      `yaml:"footer_file,omitempty"`
+
+	ReplaceExistingArtifacts bool `yaml:"replace_existing_artifacts,omitempty"`
 
 	ReleaseNotesMode ReleaseNotesMode `yaml:"mode,omitempty" jsonschema:"enum=keep-existing,enum=append,enum=prepend,enum=replace,default=keep-existing"`
 }
 
+// Bitbucket config used to upload the artifacts to the downloads of a
+// Bitbucket Cloud repository.
+type Bitbucket struct {
+	Repo                     Repo     `yaml:"repo,omitempty"`
+	IDs                      []string `yaml:"ids,omitempty"`
+	APIURL                   string   `yaml:"api_url,omitempty"`
+	ReleaseNotesNameTemplate string   `yaml:"release_notes_name_template,omitempty"`
+}
+
 // Milestone config used for VCS milestone.
 type Milestone struct {
 	Repo         Repo   `yaml:"repo,omitempty"`
@@ -711,6 +821,21 @@ type Sign struct {
 	Env         []string `yaml:"env,omitempty"`
 	Certificate string   `yaml:"certificate,omitempty"`
 	Output      bool     `yaml:"output,omitempty"`
+	GPG         SignGPG  `yaml:"gpg,omitempty"`
+	Rekor       Rekor    `yaml:"rekor,omitempty"`
+}
+
+// Rekor config, to upload cosign signatures to a Rekor transparency log.
+type Rekor struct {
+	Enabled bool   `yaml:"enabled,omitempty"`
+	URL     string `yaml:"url,omitempty"`
+}
+
+// SignGPG config, used to build the arguments of the default gpg command.
+type SignGPG struct {
+	KeyID string `yaml:"key_id,omitempty"`
+	Armor bool   `yaml:"armor,omitempty"`
+	Mode  string `yaml:"mode,omitempty" jsonschema:"enum=detach,enum=clear,default=detach"`
 }
 
 // SnapcraftAppMetadata for the binaries that will be in the snap package.
@@ -793,28 +918,48 @@ type Snapshot struct {
 
 // Checksum config.
 type Checksum struct {
-	NameTemplate string      `yaml:"name_template,omitempty"`
-	Algorithm    string      `yaml:"algorithm,omitempty"`
-	IDs          []string    `yaml:"ids,omitempty"`
-	Disable      bool        `yaml:"disable,omitempty"`
-	ExtraFiles   []ExtraFile `yaml:"extra_files,omitempty"`
+	NameTemplate      string           `yaml:"name_template,omitempty"`
+	Algorithm         string           `yaml:"algorithm,omitempty" jsonschema:"enum=sha256,enum=sha512,enum=sha1,enum=crc32,enum=md5,enum=sha224,enum=sha384,enum=blake2b,default=sha256"`
+	IDs               []string         `yaml:"ids,omitempty"`
+	Disable           bool             `yaml:"disable,omitempty"`
+	ExtraFiles        []ExtraFile      `yaml:"extra_files,omitempty"`
+	ReleaseExtraFiles bool             `yaml:"release_extra_files,omitempty"`
+	Sidecar           bool             `yaml:"sidecar,omitempty"`
+	Outputs           []ChecksumOutput `yaml:"outputs,omitempty"`
+}
+
+// ChecksumOutput config, an additional checksums file with a subset of the
+// artifacts.
+type ChecksumOutput struct {
+	NameTemplate string   `yaml:"name_template,omitempty"`
+	IDs          []string `yaml:"ids,omitempty"`
+	Goos         []string `yaml:"goos,omitempty"`
 }
 
 // Docker image config.
 type Docker struct {
-	ID                 string   `yaml:"id,omitempty"`
-	IDs                []string `yaml:"ids,omitempty"`
-	Goos               string   `yaml:"goos,omitempty"`
-	Goarch             string   `yaml:"goarch,omitempty"`
-	Goarm              string   `yaml:"goarm,omitempty"`
-	Goamd64            string   `yaml:"goamd64,omitempty"`
-	Dockerfile         string   `yaml:"dockerfile,omitempty"`
-	ImageTemplates     []string `yaml:"image_templates,omitempty"`
-	SkipPush           string   `yaml:"skip_push,omitempty"`
-	Files              []string `yaml:"extra_files,omitempty"`
-	BuildFlagTemplates []string `yaml:"build_flag_templates,omitempty"`
-	PushFlags          []string `yaml:"push_flags,omitempty"`
-	Use                string   `yaml:"use,omitempty"`
+	ID                 string     `yaml:"id,omitempty"`
+	IDs                []string   `yaml:"ids,omitempty"`
+	Goos               string     `yaml:"goos,omitempty"`
+	Goarch             string     `yaml:"goarch,omitempty"`
+	Goarm              string     `yaml:"goarm,omitempty"`
+	Goamd64            string     `yaml:"goamd64,omitempty"`
+	Goarm64            string     `yaml:"goarm64,omitempty"`
+	Gomips             string     `yaml:"gomips,omitempty"`
+	Dockerfile         string     `yaml:"dockerfile,omitempty"`
+	ImageTemplates     []string   `yaml:"image_templates,omitempty"`
+	SkipPush           string     `yaml:"skip_push,omitempty"`
+	Files              []string   `yaml:"extra_files,omitempty"`
+	BuildFlagTemplates []string   `yaml:"build_flag_templates,omitempty"`
+	PushFlags          []string   `yaml:"push_flags,omitempty"`
+	Use                string     `yaml:"use,omitempty"`
+	Save               DockerSave `yaml:"save,omitempty"`
+}
+
+// DockerSave configures exporting docker images with docker save.
+type DockerSave struct {
+	Enabled      bool   `yaml:"enabled,omitempty"`
+	NameTemplate string `yaml:"name_template,omitempty"`
 }
 
 // DockerManifest config.
@@ -835,11 +980,12 @@ type Filters struct {
 
 // Changelog Config.
 type Changelog struct {
-	Filters Filters          `yaml:"filters,omitempty"`
-	Sort    string           `yaml:"sort,omitempty"`
-	Skip    bool             `yaml:"skip,omitempty"` // TODO(caarlos0): rename to Disable to match other pipes
-	Use     string           `yaml:"use,omitempty" jsonschema:"enum=git,enum=github,enum=github-native,enum=gitlab,default=git"`
-	Groups  []ChangeLogGroup `yaml:"groups,omitempty"`
+	Filters      Filters          `yaml:"filters,omitempty"`
+	Sort         string           `yaml:"sort,omitempty"`
+	Skip         bool             `yaml:"skip,omitempty"` // TODO(caarlos0): rename to Disable to match other pipes
+	Use          string           `yaml:"use,omitempty" jsonschema:"enum=git,enum=github,enum=github-native,enum=gitlab,default=git"`
+	Groups       []ChangeLogGroup `yaml:"groups,omitempty"`
+	ReleaseNotes string           `yaml:"release_notes,omitempty"`
 }
 
 // ChangeLogGroup holds the grouping criteria for the changelog.
@@ -857,22 +1003,33 @@ type EnvFiles struct {
 	GiteaToken  string `yaml:"gitea_token,omitempty"`
 }
 
+// Tests config.
+type Tests struct {
+	Enabled bool     `yaml:"enabled,omitempty"`
+	Cmd     string   `yaml:"cmd,omitempty"`
+	Dir     string   `yaml:"dir,omitempty"`
+	Env     []string `yaml:"env,omitempty"`
+	Output  bool     `yaml:"output,omitempty"`
+}
+
 // Before config.
 type Before struct {
-	Hooks []string `yaml:"hooks,omitempty"`
+	Hooks Hooks `yaml:"hooks,omitempty"`
 }
 
 // Blob contains config for GO CDK blob.
 type Blob struct {
-	Bucket     string      `yaml:"bucket,omitempty"`
-	Provider   string      `yaml:"provider,omitempty"`
-	Region     string      `yaml:"region,omitempty"`
-	DisableSSL bool        `yaml:"disableSSL,omitempty"` // nolint:tagliatelle // TODO(caarlos0): rename to disable_ssl
-	Folder     string      `yaml:"folder,omitempty"`
-	KMSKey     string      `yaml:"kmskey,omitempty"`
-	IDs        []string    `yaml:"ids,omitempty"`
-	Endpoint   string      `yaml:"endpoint,omitempty"` // used for minio for example
-	ExtraFiles []ExtraFile `yaml:"extra_files,omitempty"`
+	Bucket       string      `yaml:"bucket,omitempty"`
+	Provider     string      `yaml:"provider,omitempty"`
+	Region       string      `yaml:"region,omitempty"`
+	DisableSSL   bool        `yaml:"disableSSL,omitempty"` // nolint:tagliatelle // TODO(caarlos0): rename to disable_ssl
+	Folder       string      `yaml:"folder,omitempty"`
+	KMSKey       string      `yaml:"kmskey,omitempty"`
+	IDs          []string    `yaml:"ids,omitempty"`
+	Endpoint     string      `yaml:"endpoint,omitempty"` // used for minio for example
+	ExtraFiles   []ExtraFile `yaml:"extra_files,omitempty"`
+	ACL          string      `yaml:"acl,omitempty"`
+	CacheControl []string    `yaml:"cache_control,omitempty"`
 }
 
 // Upload configuration.
@@ -886,6 +1043,8 @@ type Upload struct {
 	Method             string            `yaml:"method,omitempty"`
 	ChecksumHeader     string            `yaml:"checksum_header,omitempty"`
 	TrustedCerts       string            `yaml:"trusted_certificates,omitempty"`
+	ClientX509Cert     string            `yaml:"client_x509_cert,omitempty"`
+	ClientX509Key      string            `yaml:"client_x509_key,omitempty"`
 	Checksum           bool              `yaml:"checksum,omitempty"`
 	Signature          bool              `yaml:"signature,omitempty"`
 	CustomArtifactName bool              `yaml:"custom_artifact_name,omitempty"`
@@ -910,6 +1069,7 @@ type Source struct {
 	Format         string `yaml:"format,omitempty"`
 	Enabled        bool   `yaml:"enabled,omitempty"`
 	PrefixTemplate string `yaml:"prefix_template,omitempty"`
+	Files          []File `yaml:"files,omitempty"`
 }
 
 // Project includes all project configuration.
@@ -918,6 +1078,7 @@ type Project struct {
 	Env             []string         `yaml:"env,omitempty"`
 	Release         Release          `yaml:"release,omitempty"`
 	Milestones      []Milestone      `yaml:"milestones,omitempty"`
+	Bitbucket       Bitbucket        `yaml:"bitbucket,omitempty"`
 	Brews           []Homebrew       `yaml:"brews,omitempty"`
 	Rigs            []GoFish         `yaml:"rigs,omitempty"` // deprecated
 	AURs            []AUR            `yaml:"aurs,omitempty"`
@@ -937,16 +1098,25 @@ type Project struct {
 	Publishers      []Publisher      `yaml:"publishers,omitempty"`
 	Changelog       Changelog        `yaml:"changelog,omitempty"`
 	Dist            string           `yaml:"dist,omitempty"`
+	Parallelism     int              `yaml:"parallelism,omitempty"`
+	Go              string           `yaml:"go,omitempty"`
+	SingleTarget    bool             `yaml:"single_target,omitempty"`
 	Signs           []Sign           `yaml:"signs,omitempty"`
 	DockerSigns     []Sign           `yaml:"docker_signs,omitempty"`
+	BinarySigns     []Sign           `yaml:"binary_signs,omitempty"`
 	EnvFiles        EnvFiles         `yaml:"env_files,omitempty"`
 	Before          Before           `yaml:"before,omitempty"`
+	Tests           Tests            `yaml:"tests,omitempty"`
 	Source          Source           `yaml:"source,omitempty"`
 	GoMod           GoMod            `yaml:"gomod,omitempty"`
 	Announce        Announce         `yaml:"announce,omitempty"`
 	SBOMs           []SBOM           `yaml:"sboms,omitempty"`
 
 	UniversalBinaries []UniversalBinary `yaml:"universal_binaries,omitempty"`
+	UPXs              []UPX             `yaml:"upx,omitempty"`
+	DebugSymbols      DebugSymbols      `yaml:"debug_symbols,omitempty"`
+	Provenance        Provenance        `yaml:"provenance,omitempty"`
+	Attestations      []Attestation     `yaml:"attestations,omitempty"`
 
 	// this is a hack ¯\_(ツ)_/¯
 	SingleBuild Build `yaml:"build,omitempty"`
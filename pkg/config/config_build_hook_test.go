@@ -53,3 +53,28 @@ func TestBuildHook_complex(t *testing.T) {
 		Env: []string{"TEST=value"},
 	}, actual.Pre[0])
 }
+
+func TestBeforeHooks(t *testing.T) {
+	var actual Before
+
+	err := yaml.UnmarshalStrict([]byte(`hooks:
+ - go mod tidy
+ - cmd: go generate ./...
+   dir: ./submodule
+   output: true
+   env:
+    - TEST=value
+`), &actual)
+	require.NoError(t, err)
+	require.Equal(t, Hooks{
+		{
+			Cmd: "go mod tidy",
+		},
+		{
+			Cmd:    "go generate ./...",
+			Dir:    "./submodule",
+			Output: true,
+			Env:    []string{"TEST=value"},
+		},
+	}, actual.Hooks)
+}
@@ -34,6 +34,21 @@ nfpms:
 	require.Equal(t, "http://goreleaser.github.io", prop.NFPMs[0].Homepage, "yaml did not load correctly")
 }
 
+func TestLoadReaderReleaseDisable(t *testing.T) {
+	for value, expected := range map[string]string{
+		"true":                    "true",
+		"false":                   "false",
+		"auto":                    "auto",
+		`"{{ .Env.NO_RELEASE }}"`: "{{ .Env.NO_RELEASE }}",
+	} {
+		t.Run(value, func(t *testing.T) {
+			prop, err := LoadReader(strings.NewReader("release:\n  disable: " + value + "\n"))
+			require.NoError(t, err)
+			require.Equal(t, expected, prop.Release.Disable)
+		})
+	}
+}
+
 type errorReader struct{}
 
 func (errorReader) Read(p []byte) (n int, err error) {
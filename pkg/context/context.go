@@ -45,6 +45,17 @@ func (e Env) Copy() Env {
 	return out
 }
 
+// Only returns a copy of the environment containing only the given keys.
+func (e Env) Only(keys ...string) Env {
+	out := Env{}
+	for _, k := range keys {
+		if v, ok := e[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
 // Strings returns the current environment as a list of strings, suitable for
 // os executions.
 func (e Env) Strings() []string {
@@ -93,9 +104,11 @@ type Context struct {
 	SkipPublish        bool
 	SkipAnnounce       bool
 	SkipSign           bool
+	SkipTests          bool
 	SkipValidate       bool
 	SkipSBOMCataloging bool
 	RmDist             bool
+	Incremental        bool
 	PreRelease         bool
 	Deprecated         bool
 	Parallelism        int
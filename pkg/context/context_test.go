@@ -34,6 +34,12 @@ func TestNewWithTimeout(t *testing.T) {
 	require.EqualError(t, ctx.Err(), `context canceled`)
 }
 
+func TestEnvOnly(t *testing.T) {
+	env := Env{"FOO": "BAR", "HOME": "/home/foo", "PATH": "/bin"}
+	require.Equal(t, Env{"HOME": "/home/foo", "PATH": "/bin"}, env.Only("HOME", "PATH", "NOPE"))
+	require.Equal(t, Env{}, env.Only())
+}
+
 func TestToEnv(t *testing.T) {
 	require.Equal(t, Env{"FOO": "BAR"}, ToEnv([]string{"=nope", "FOO=BAR"}))
 	require.Equal(t, Env{"FOO": "BAR"}, ToEnv([]string{"nope", "FOO=BAR"}))
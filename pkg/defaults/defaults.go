@@ -7,11 +7,14 @@ import (
 
 	"github.com/goreleaser/goreleaser/internal/pipe/archive"
 	"github.com/goreleaser/goreleaser/internal/pipe/artifactory"
+	"github.com/goreleaser/goreleaser/internal/pipe/attestation"
 	"github.com/goreleaser/goreleaser/internal/pipe/aur"
+	"github.com/goreleaser/goreleaser/internal/pipe/bitbucket"
 	"github.com/goreleaser/goreleaser/internal/pipe/blob"
 	"github.com/goreleaser/goreleaser/internal/pipe/brew"
 	"github.com/goreleaser/goreleaser/internal/pipe/build"
 	"github.com/goreleaser/goreleaser/internal/pipe/checksums"
+	"github.com/goreleaser/goreleaser/internal/pipe/debugsymbols"
 	"github.com/goreleaser/goreleaser/internal/pipe/discord"
 	"github.com/goreleaser/goreleaser/internal/pipe/docker"
 	"github.com/goreleaser/goreleaser/internal/pipe/gofish"
@@ -22,6 +25,7 @@ import (
 	"github.com/goreleaser/goreleaser/internal/pipe/milestone"
 	"github.com/goreleaser/goreleaser/internal/pipe/nfpm"
 	"github.com/goreleaser/goreleaser/internal/pipe/project"
+	"github.com/goreleaser/goreleaser/internal/pipe/provenance"
 	"github.com/goreleaser/goreleaser/internal/pipe/reddit"
 	"github.com/goreleaser/goreleaser/internal/pipe/release"
 	"github.com/goreleaser/goreleaser/internal/pipe/sbom"
@@ -34,8 +38,10 @@ import (
 	"github.com/goreleaser/goreleaser/internal/pipe/sourcearchive"
 	"github.com/goreleaser/goreleaser/internal/pipe/teams"
 	"github.com/goreleaser/goreleaser/internal/pipe/telegram"
+	"github.com/goreleaser/goreleaser/internal/pipe/tests"
 	"github.com/goreleaser/goreleaser/internal/pipe/twitter"
 	"github.com/goreleaser/goreleaser/internal/pipe/universalbinary"
+	"github.com/goreleaser/goreleaser/internal/pipe/upx"
 	"github.com/goreleaser/goreleaser/internal/pipe/webhook"
 	"github.com/goreleaser/goreleaser/pkg/context"
 )
@@ -54,17 +60,24 @@ type Defaulter interface {
 var Defaulters = []Defaulter{
 	snapshot.Pipe{},
 	release.Pipe{},
+	bitbucket.Pipe{},
 	project.Pipe{},
 	gomod.Pipe{},
+	tests.Pipe{},
 	build.Pipe{},
 	universalbinary.Pipe{},
+	debugsymbols.Pipe{},
+	upx.Pipe{},
 	sourcearchive.Pipe{},
 	archive.Pipe{},
 	nfpm.Pipe{},
 	snapcraft.Pipe{},
 	checksums.Pipe{},
+	provenance.Pipe{},
+	attestation.Pipe{},
 	sign.Pipe{},
 	sign.DockerPipe{},
+	sign.BinaryPipe{},
 	sbom.Pipe{},
 	docker.Pipe{},
 	docker.ManifestPipe{},